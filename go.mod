@@ -4,6 +4,9 @@ go 1.24.1
 
 toolchain go1.24.5
 
-require github.com/modelcontextprotocol/go-sdk v0.2.0
+require (
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1
+	github.com/modelcontextprotocol/go-sdk v0.2.0
+)
 
 require github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLeaderboardDoesNotRankOtherDenoms(t *testing.T) {
+	response := buildAuctionLeaderboardResponse(1, []Bid{
+		{Bidder: testAlice, Amount: "50stake"},
+		{Bidder: testBob, Amount: "100uatom"},
+	})
+	entries := response.Details.Entries
+	if len(entries) != 2 || entries[0].Amount != "50stake" || !entries[0].IsLeader || entries[0].Rank != 1 {
+		t.Fatalf("entries = %+v, want 50stake ranked first as leader", entries)
+	}
+	if entries[1].Rank != 0 || entries[1].IsLeader {
+		t.Errorf("100uatom entry = %+v, want it unranked", entries[1])
+	}
+}
+
+func TestTVLSumsLeadingBidsInAuctionDenom(t *testing.T) {
+	auctions := []Auction{{ID: 1, Status: "open"}, {ID: 2, Status: "open"}}
+	bids := []Bid{
+		{AuctionID: 1, Amount: "50stake"},
+		{AuctionID: 1, Amount: "100uatom"},
+		{AuctionID: 2, Amount: "7stake"},
+	}
+	response := buildTVLAuctionsResponse(auctions, bids)
+	if got := response.Details.TotalsByDenom; len(got) != 1 || got["stake"] != "57" {
+		t.Errorf("totals = %v, want only 57stake", got)
+	}
+}
+
+// bidTxs is a `query txs` page of create-bid txs for auction 1 at the given
+// times, each signed by signer.
+func bidTxs(signer string, times ...string) string {
+	var txs []string
+	for _, at := range times {
+		txs = append(txs, fmt.Sprintf(`{"txhash":"T","timestamp":%q,"tx":{"body":{"messages":[{"@type":"/swechain.issuemarket.MsgCreateBid","creator":%q,"auctionId":"1"}]}}}`, at, signer))
+	}
+	txs = append(txs, `{"txhash":"S","timestamp":"2026-01-05T00:00:00Z","tx":{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"`+signer+`"}]}}}`)
+	return `{"txs":[` + strings.Join(txs, ",") + `],"page_total":"1"}`
+}
+
+func TestAuctionActivityUsesLatestBidTx(t *testing.T) {
+	withPaging(t, 50, 0)
+	runner := newFakeRunner().
+		on("query issuemarket list-bid", `{"Bid":[
+			{"auctionId":"1","amount":"50token","creator":"`+testAlice+`","bidder":"`+testAlice+`"},
+			{"auctionId":"1","amount":"70token","creator":"`+testBob+`","bidder":"`+testBob+`"},
+			{"auctionId":"2","amount":"5token","creator":"`+testBob+`","bidder":"`+testBob+`"}
+		],"pagination":{"next_key":null,"total":"3"}}`).
+		onCall("query txs", func(call int, args []string) (string, error) {
+			if slices.Contains(args, "message.sender="+testAlice) {
+				return bidTxs(testAlice, "2026-01-01T10:00:00Z"), nil
+			}
+			return bidTxs(testBob, "2026-01-02T12:00:00Z", "2026-01-01T09:00:00Z"), nil
+		})
+
+	text, isError := callTool(t, runner, auctionActivityHandler, AuctionActivityParams{AuctionId: "1"})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	var response AuctionActivityResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Details.BidCount != 2 || response.Details.Trend != "increasing" {
+		t.Errorf("details = %+v, want 2 increasing bids", response.Details)
+	}
+	if response.Details.LastBidAt != "2026-01-02T12:00:00Z" {
+		t.Errorf("lastBidAt = %q, want the later of bob's bids", response.Details.LastBidAt)
+	}
+	if calls := runner.callsTo("query txs"); len(calls) != 2 {
+		t.Errorf("tx searches = %v, want one per distinct bidder", calls)
+	}
+}
+
+func TestAuctionActivityFailsWhenBidTxSearchFails(t *testing.T) {
+	withPaging(t, 50, 0)
+	runner := newFakeRunner().
+		on("query issuemarket list-bid", `{"Bid":[{"auctionId":"1","amount":"50token","creator":"`+testAlice+`","bidder":"`+testAlice+`"}],"pagination":{"next_key":null,"total":"1"}}`).
+		fail("query txs", "Error: rpc error: code = InvalidArgument desc = bad query")
+
+	text, _ := callTool(t, runner, auctionActivityHandler, AuctionActivityParams{AuctionId: "1"})
+	if !strings.HasPrefix(text, "Error fetching bid txs for "+testAlice) {
+		t.Errorf("result = %s, want a fetch error for alice's bid txs", text)
+	}
+}
+
+func TestBuildAuctionActivityResponse(t *testing.T) {
+	now := time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC)
+	bids := []Bid{{AuctionID: 1, Amount: "90token"}, {AuctionID: 1, Amount: "60token"}}
+
+	response := buildAuctionActivityResponse(1, bids, []time.Time{now.Add(-26 * time.Hour), now.Add(-90 * time.Minute)}, now)
+	if response.Details.Trend != "decreasing" || response.Details.TimeSinceLastBid != "1h30m0s" || response.Details.LastBidAt != "2026-01-03T10:30:00Z" {
+		t.Errorf("details = %+v, want a decreasing trend with the last bid 1h30m ago", response.Details)
+	}
+
+	response = buildAuctionActivityResponse(1, bids, nil, now)
+	if response.Details.LastBidAt != "" || !strings.Contains(response.Summary, "last bid time unknown") {
+		t.Errorf("response = %+v, want an unknown last bid time", response)
+	}
+
+	response = buildAuctionActivityResponse(1, nil, nil, now)
+	if response.Details.BidCount != 0 || response.Summary != "Auction 1 has no bids" {
+		t.Errorf("response = %+v, want no bids", response)
+	}
+}
+
+func TestBidTxTimesMatchesOnlyCreateBidsForAuction(t *testing.T) {
+	var txs []map[string]interface{}
+	for _, raw := range []string{
+		`{"timestamp":"2026-01-01T10:00:00Z","tx":{"body":{"messages":[{"@type":"/swechain.issuemarket.MsgCreateBid","auctionId":"1"}]}}}`,
+		`{"timestamp":"2026-01-01T11:00:00Z","tx":{"body":{"messages":[{"@type":"/swechain.issuemarket.MsgCreateBid","auctionId":"2"}]}}}`,
+		`{"timestamp":"2026-01-01T12:00:00Z","tx":{"body":{"messages":[{"@type":"/swechain.issuemarket.MsgUpdateAuction","auctionId":"1"}]}}}`,
+		`{"timestamp":"not a time","tx":{"body":{"messages":[{"@type":"/swechain.issuemarket.MsgCreateBid","auctionId":"1"}]}}}`,
+	} {
+		var tx map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &tx); err != nil {
+			t.Fatal(err)
+		}
+		txs = append(txs, tx)
+	}
+
+	times := bidTxTimes(txs, 1)
+	if len(times) != 1 || !times[0].Equal(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("bidTxTimes = %v, want only the 10:00 bid on auction 1", times)
+	}
+}
+
+func TestResolveRelativeBid(t *testing.T) {
+	setDefaultDenom(t, "uswe")
+	ctx := withConfig(context.Background(), loadConfig())
+	bids := []Bid{{AuctionID: 1, Amount: "70token"}, {AuctionID: 1, Amount: "120token"}}
+
+	for _, tc := range []struct {
+		name      string
+		increment string
+		bids      []Bid
+		want      string
+		wantErr   string
+	}{
+		{"no bids counts from zero in the default denom", "+50", nil, "50uswe", ""},
+		{"no bids with a denom", "+50token", nil, "50token", ""},
+		{"bare increment takes the leader's denom", "+30", bids, "150token", ""},
+		{"increment with the leader's denom", "+30token", bids, "150token", ""},
+		{"large increment", "+100000000000000000000token", bids, "100000000000000000120token", ""},
+		{"mismatched denom", "+30stake", bids, "", "does not match"},
+		{"zero delta", "+0", bids, "", "greater than zero"},
+		{"zero delta with a denom", "+0token", bids, "", "greater than zero"},
+		{"malformed", "+1.5token", bids, "", "must look like"},
+	} {
+		got, err := resolveRelativeBid(ctx, tc.increment, tc.bids)
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: resolveRelativeBid(%q) = %q, %v; want an error containing %q", tc.name, tc.increment, got, err, tc.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("%s: resolveRelativeBid(%q) = %q, %v; want %q", tc.name, tc.increment, got, err, tc.want)
+		}
+	}
+}
+
+func TestBidsByBidderGroupsAuctionsAndTotalsByDenom(t *testing.T) {
+	auctions := []Auction{{ID: 1, Status: "open"}, {ID: 2, Status: "closed"}, {ID: 3, Status: "open"}}
+	bids := []Bid{
+		{AuctionID: 2, Bidder: testAlice, Amount: "30token"},
+		{AuctionID: 1, Bidder: testAlice, Amount: "50token"},
+		{AuctionID: 1, Bidder: testBob, Amount: "90token"},
+		{AuctionID: 1, Bidder: testAlice, Amount: "70token"},
+		{AuctionID: 3, Bidder: testAlice, Amount: "5stake"},
+		{AuctionID: 3, Bidder: testAlice, Amount: "not a coin"},
+	}
+
+	response := buildBidsByBidderResponse(testAlice, auctions, bids)
+	if response.Details.TotalBids != 5 {
+		t.Errorf("totalBids = %d, want alice's 5 bids", response.Details.TotalBids)
+	}
+	if got := response.Details.TotalsByDenom; len(got) != 2 || got["token"] != "150" || got["stake"] != "5" {
+		t.Errorf("totals = %v, want 150 token and 5 stake", got)
+	}
+
+	groups := response.Details.Auctions
+	if len(groups) != 3 {
+		t.Fatalf("auctions = %+v, want 3 groups", groups)
+	}
+	for i, want := range []struct {
+		id     int
+		status string
+		bids   int
+	}{{1, "open", 2}, {2, "closed", 1}, {3, "open", 2}} {
+		if groups[i].AuctionID != want.id || groups[i].Status != want.status || len(groups[i].Bids) != want.bids {
+			t.Errorf("group %d = %+v, want auction %d (%s) with %d bids", i, groups[i], want.id, want.status, want.bids)
+		}
+	}
+	for _, group := range groups {
+		for _, bid := range group.Bids {
+			if bid.Bidder != testAlice {
+				t.Errorf("auction %d includes %s's bid", group.AuctionID, bid.Bidder)
+			}
+		}
+	}
+}
+
+func TestCountBidsForOneAuctionAndAll(t *testing.T) {
+	withPaging(t, 50, 0)
+	runner := newFakeRunner().on("query issuemarket list-bid", `{"Bid":[
+		{"auctionId":"1","amount":"50token","creator":"`+testAlice+`","bidder":"`+testAlice+`"},
+		{"auctionId":"1","amount":"70token","creator":"`+testBob+`","bidder":"`+testBob+`"},
+		{"auctionId":"2","amount":"5token","creator":"`+testBob+`","bidder":"`+testBob+`"}
+	],"pagination":{"next_key":null,"total":"3"}}`)
+
+	for _, tc := range []struct {
+		auctionId string
+		want      int
+		summary   string
+	}{
+		{"1", 2, "2 bids for auction 1"},
+		{"2", 1, "1 bids for auction 2"},
+		{"9", 0, "0 bids for auction 9"},
+		{"all", 3, "3 total bids across all auctions"},
+		{"ALL", 3, "3 total bids across all auctions"},
+	} {
+		text, isError := callTool(t, runner, countBidsHandler, CountBidsParams{AuctionId: tc.auctionId})
+		var response struct {
+			Summary string `json:"summary"`
+			Details struct {
+				Count int `json:"count"`
+			} `json:"details"`
+		}
+		if err := json.Unmarshal([]byte(text), &response); err != nil || isError {
+			t.Fatalf("%s: response = %s", tc.auctionId, text)
+		}
+		if response.Details.Count != tc.want || response.Summary != tc.summary {
+			t.Errorf("%s: response = %+v, want count %d and summary %q", tc.auctionId, response, tc.want, tc.summary)
+		}
+		if strings.Contains(text, "50token") {
+			t.Errorf("%s: response includes bid data: %s", tc.auctionId, text)
+		}
+	}
+
+	text, isError := callTool(t, runner, countBidsHandler, CountBidsParams{AuctionId: "first"})
+	if !isError || !hasFieldError(fieldErrors(t, text), "auctionId", codeInvalidAuctionID) {
+		t.Errorf("result = %s, want invalid_auction_id", text)
+	}
+}
+
+func TestMyAuctionsCoversCreatedAndLeadingAuctions(t *testing.T) {
+	auctions := []Auction{
+		{ID: 4, Issue: "#4", Creator: testAlice, Status: "open"},
+		{ID: 1, Issue: "#1", Creator: testBob, Status: "open"},
+		{ID: 2, Issue: "#2", Creator: testBob, Status: "open"},
+		{ID: 3, Issue: "#3", Creator: testAlice, Status: "closed"},
+	}
+	bids := []Bid{
+		{AuctionID: 1, Bidder: testAlice, Amount: "90token"},
+		{AuctionID: 1, Bidder: testBob, Amount: "60token"},
+		{AuctionID: 2, Bidder: testAlice, Amount: "10token"},
+		{AuctionID: 2, Bidder: testBob, Amount: "40token"},
+		{AuctionID: 3, Bidder: testAlice, Amount: "5token"},
+	}
+
+	response := buildMyAuctionsResponse("alice", testAlice, auctions, bids)
+	if response.Details.Created != 2 || response.Details.Leading != 2 {
+		t.Errorf("created = %d, leading = %d; want 2 and 2", response.Details.Created, response.Details.Leading)
+	}
+	want := []MyAuctionEntry{
+		{AuctionID: 1, Issue: "#1", Status: "open", IsHighBidder: true, CurrentBidAmount: "90token", HighBidder: testAlice},
+		{AuctionID: 3, Issue: "#3", Status: "closed", IsCreator: true, IsHighBidder: true, CurrentBidAmount: "5token", HighBidder: testAlice},
+		{AuctionID: 4, Issue: "#4", Status: "open", IsCreator: true, CurrentBidAmount: "0"},
+	}
+	if !slices.Equal(response.Details.Auctions, want) {
+		t.Errorf("auctions = %+v\nwant %+v", response.Details.Auctions, want)
+	}
+
+	response = buildMyAuctionsResponse("carol", "cosmos1carolcarolcarolcarolcarolcarolcarol", auctions, bids)
+	if len(response.Details.Auctions) != 0 || response.Details.Auctions == nil {
+		t.Errorf("auctions = %#v, want an empty list", response.Details.Auctions)
+	}
+}
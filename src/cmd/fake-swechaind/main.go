@@ -0,0 +1,274 @@
+// Command fake-swechaind stands in for swechaind when exercising the MCP
+// server without a chain. It answers the subcommands the server runs for
+// auctions, bids, accounts, balances, staking, rewards, keys, mnemonics, node
+// status and tx broadcasts with canned output, and fails everything else the
+// way swechaind does for an unknown command.
+//
+// Point the server at it with:
+//
+//	go build -o bin/fake-swechaind ./src/cmd/fake-swechaind
+//	swechain-mcp-server -swechaind-path bin/fake-swechaind
+//
+// Every invocation is a fresh process, so broadcast txs are acknowledged but
+// not applied: the auctions, bids and balances below never change.
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	aliceAddress = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	bobAddress   = "cosmos1qgpqyqszqgpqyqszqgpqyqszqgpqyqszrh8mx2"
+	newAddress   = "cosmos1qvpsxqcrqvpsxqcrqvpsxqcrqvpsxqcrz8x6vt"
+
+	validatorAddress = "cosmosvaloper1qyqszqgpqyqszqgpqyqszqgpqyqszqgph84tp0"
+
+	latestHeight = 100
+)
+
+// mnemonic is the BIP39 test vector for all-zero entropy; never use it for real funds.
+const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+
+var keys = []map[string]interface{}{
+	{"name": "alice", "type": "local", "address": aliceAddress},
+	{"name": "bob", "type": "local", "address": bobAddress},
+}
+
+var auctions = []map[string]interface{}{
+	{"id": "0", "issue": "#1", "description": "Fix the flaky login test", "status": "open", "winner": "", "creator": aliceAddress},
+	{"id": "1", "issue": "#2", "description": "Add dark mode", "status": "closed", "winner": bobAddress, "creator": aliceAddress},
+}
+
+var bids = []map[string]interface{}{
+	{"auctionId": "0", "amount": "100token", "description": "Can do it today", "creator": bobAddress, "bidder": bobAddress},
+	{"auctionId": "0", "amount": "150token", "description": "Includes a regression test", "creator": aliceAddress, "bidder": aliceAddress},
+	{"auctionId": "1", "amount": "300token", "description": "Done in a week", "creator": bobAddress, "bidder": bobAddress},
+}
+
+var balances = map[string][]map[string]interface{}{
+	aliceAddress: {{"denom": "stake", "amount": "5000"}, {"denom": "token", "amount": "1000"}},
+	bobAddress:   {{"denom": "token", "amount": "250"}},
+}
+
+// Only alice stakes, with a single validator.
+var (
+	delegations = map[string][]map[string]interface{}{
+		aliceAddress: {{
+			"delegation": map[string]interface{}{"delegator_address": aliceAddress, "validator_address": validatorAddress, "shares": "2000.000000000000000000"},
+			"balance":    map[string]interface{}{"denom": "stake", "amount": "2000"},
+		}},
+	}
+	unbondings = map[string][]map[string]interface{}{
+		aliceAddress: {{
+			"delegator_address": aliceAddress,
+			"validator_address": validatorAddress,
+			"entries": []map[string]interface{}{
+				{"creation_height": "90", "completion_time": "2030-01-01T00:00:00Z", "initial_balance": "500", "balance": "500"},
+			},
+		}},
+	}
+	rewards = map[string][]map[string]interface{}{
+		aliceAddress: {{"denom": "stake", "amount": "12.500000000000000000"}},
+	}
+)
+
+// boolFlags are the flags the server passes without a value; every other
+// --flag is followed by its value.
+var boolFlags = map[string]bool{
+	"--yes":           true,
+	"--unsafe":        true,
+	"--unarmored-hex": true,
+	"--count-total":   true,
+}
+
+func main() {
+	positional, flags := splitArgs(os.Args[1:])
+
+	result, err := respond(positional, flags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Plain-text commands such as `keys mnemonic` return a string.
+	if text, ok := result.(string); ok {
+		fmt.Println(text)
+		return
+	}
+	encoded, _ := json.Marshal(result)
+	fmt.Println(string(encoded))
+}
+
+// splitArgs separates positional arguments from --flag values.
+func splitArgs(args []string) ([]string, map[string]string) {
+	var positional []string
+	flags := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			positional = append(positional, arg)
+			continue
+		}
+		if name, value, ok := strings.Cut(arg, "="); ok {
+			flags[name] = value
+		} else if boolFlags[arg] || i+1 == len(args) {
+			flags[arg] = "true"
+		} else {
+			flags[arg] = args[i+1]
+			i++
+		}
+	}
+	return positional, flags
+}
+
+func respond(args []string, flags map[string]string) (interface{}, error) {
+	// keys and `query tx` take their argument third, module queries fourth.
+	group := strings.Join(args[:min(len(args), 2)], " ")
+	command := strings.Join(args[:min(len(args), 3)], " ")
+	firstPage := flags["--page-offset"] == "" || flags["--page-offset"] == "0"
+
+	switch {
+	case group == "status":
+		return status(flags), nil
+
+	case group == "keys list":
+		return keys, nil
+	case group == "keys show":
+		return findKey(arg(args, 2))
+	case group == "keys mnemonic":
+		return mnemonic, nil
+	case group == "keys add":
+		return map[string]interface{}{"name": arg(args, 2), "type": "local", "address": newAddress}, nil
+
+	case command == "query issuemarket list-auction":
+		return page("Auction", auctions, firstPage), nil
+	case command == "query issuemarket list-bid":
+		return page("Bid", bids, firstPage), nil
+	case command == "query issuemarket show-auction":
+		for _, auction := range auctions {
+			if auction["id"] == arg(args, 3) {
+				return map[string]interface{}{"Auction": auction}, nil
+			}
+		}
+		return nil, fmt.Errorf("rpc error: code = NotFound desc = auction %s not found", arg(args, 3))
+
+	case command == "query auth account":
+		// Only addresses that have received funds have an account.
+		if balances[arg(args, 3)] == nil {
+			return nil, fmt.Errorf("rpc error: code = NotFound desc = account %s not found", arg(args, 3))
+		}
+		return map[string]interface{}{"account": map[string]interface{}{"address": arg(args, 3), "sequence": "0"}}, nil
+
+	case command == "query bank balances", command == "query bank spendable-balances":
+		found := balances[arg(args, 3)]
+		if found == nil {
+			found = []map[string]interface{}{}
+		}
+		return map[string]interface{}{"balances": found, "pagination": map[string]interface{}{"total": fmt.Sprint(len(found))}}, nil
+	case command == "query bank denom-owners":
+		var owners []map[string]interface{}
+		for address, coins := range balances {
+			for _, coin := range coins {
+				if coin["denom"] == arg(args, 3) {
+					owners = append(owners, map[string]interface{}{"address": address, "balance": coin})
+				}
+			}
+		}
+		return page("denom_owners", owners, firstPage), nil
+
+	case command == "query staking params":
+		return map[string]interface{}{"params": map[string]interface{}{"bond_denom": "stake"}}, nil
+	case command == "query staking delegations":
+		return page("delegation_responses", delegations[arg(args, 3)], firstPage), nil
+	case command == "query staking unbonding-delegations":
+		return page("unbonding_responses", unbondings[arg(args, 3)], firstPage), nil
+	case command == "query distribution rewards":
+		total := rewards[arg(args, 3)]
+		if total == nil {
+			total = []map[string]interface{}{}
+		}
+		return map[string]interface{}{"total": total}, nil
+
+	case group == "query tx":
+		return map[string]interface{}{"height": fmt.Sprint(latestHeight), "txhash": arg(args, 2), "code": 0, "raw_log": ""}, nil
+
+	case len(args) >= 3 && args[0] == "tx":
+		return broadcast(args, flags), nil
+	}
+
+	return nil, fmt.Errorf("unknown command %q for \"swechaind\"", command)
+}
+
+func status(flags map[string]string) map[string]interface{} {
+	network := flags["--chain-id"]
+	if network == "" {
+		network = "swechain"
+	}
+	return map[string]interface{}{
+		"node_info": map[string]interface{}{"network": network},
+		"sync_info": map[string]interface{}{
+			"latest_block_height": fmt.Sprint(latestHeight),
+			"latest_block_time":   time.Now().UTC().Format(time.RFC3339Nano),
+			"catching_up":         false,
+		},
+	}
+}
+
+func findKey(name string) (interface{}, error) {
+	for _, key := range keys {
+		if key["name"] == name || key["address"] == name {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not a valid name or address: key not found", name)
+}
+
+// page returns items under key on the first page and an empty list after it,
+// which is how the server's offset pagination detects the end.
+func page(key string, items []map[string]interface{}, first bool) map[string]interface{} {
+	if !first || items == nil {
+		items = []map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		key:          items,
+		"pagination": map[string]interface{}{"total": fmt.Sprint(len(items))},
+	}
+}
+
+// broadcast acknowledges a tx with a hash derived from its arguments, so the
+// same call always reports the same hash.
+func broadcast(args []string, flags map[string]string) map[string]interface{} {
+	sum := sha256.Sum256([]byte(strings.Join(args, " ") + flags["--from"]))
+	result := map[string]interface{}{
+		"height":  "0",
+		"txhash":  strings.ToUpper(hex.EncodeToString(sum[:])),
+		"code":    0,
+		"raw_log": "",
+	}
+	// Only a committed tx has events.
+	if flags["--broadcast-mode"] == "block" {
+		result["height"] = fmt.Sprint(latestHeight + 1)
+		result["events"] = []map[string]interface{}{{
+			"type": "message",
+			"attributes": []map[string]interface{}{
+				{"key": "action", "value": args[1] + "/" + args[2]},
+				{"key": "sender", "value": flags["--from"]},
+			},
+		}}
+	}
+	return result
+}
+
+func arg(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
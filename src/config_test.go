@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// restoreConfig puts the reloadable flags back as they were once t ends.
+func restoreConfig(t *testing.T) {
+	t.Helper()
+	previous := make(map[string]string, len(envFlags))
+	for _, ef := range envFlags {
+		previous[ef.flag] = flag.Lookup(ef.flag).Value.String()
+	}
+	previousFeesDerived := feesDerived
+	t.Cleanup(func() {
+		for name, value := range previous {
+			flag.Set(name, value)
+		}
+		if err := validateFlags(); err != nil {
+			t.Fatal(err)
+		}
+		feesDerived = previousFeesDerived
+	})
+}
+
+func TestRequestIDDoesNotHoldConfigLock(t *testing.T) {
+	locked := true
+	h := withRequestID("tool", func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetBalanceParams]) (*mcp.CallToolResultFor[any], error) {
+		if configMu.TryLock() {
+			locked = false
+			configMu.Unlock()
+		}
+		return textResult(`{"summary":"ok","details":{}}`), nil
+	})
+
+	callTool(t, newFakeRunner(), h, GetBalanceParams{Address: testAlice})
+	if locked {
+		t.Error("a reload could not take configMu while a tool call was running")
+	}
+}
+
+func TestCallKeepsConfigSnapshotAcrossReload(t *testing.T) {
+	restoreConfig(t)
+	ctx := withConfig(context.Background(), loadConfig())
+
+	t.Setenv("SWECHAIN_CHAIN_ID", "swechain-reloaded")
+	reloadConfig()
+
+	if got := configFrom(ctx).chainID; got == "swechain-reloaded" {
+		t.Errorf("running call saw the reloaded chain id")
+	}
+	if got := configFrom(context.Background()).chainID; got != "swechain-reloaded" {
+		t.Errorf("chain id after reload = %q, want swechain-reloaded", got)
+	}
+}
+
+func TestSIGHUPReloadsConfig(t *testing.T) {
+	restoreConfig(t)
+	watchReloadSignal()
+	t.Cleanup(func() { signal.Reset(syscall.SIGHUP) })
+
+	t.Setenv("SWECHAIN_CHAIN_ID", "swechain-hup")
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for configFrom(context.Background()).chainID != "swechain-hup" {
+		if time.Now().After(deadline) {
+			t.Fatal("chain id was not reloaded after SIGHUP")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReloadRederivesDefaultFees(t *testing.T) {
+	restoreConfig(t)
+	if !feesDerived {
+		t.Fatal("default test config should derive -fees from -default-denom")
+	}
+
+	t.Setenv("SWECHAIN_DENOM", "stake")
+	reloadConfig()
+	if *defaultFees != defaultFeeAmount+"stake" {
+		t.Errorf("-fees = %q after a denom reload, want %s", *defaultFees, defaultFeeAmount+"stake")
+	}
+
+	t.Setenv("SWECHAIN_FEES", "5stake")
+	reloadConfig()
+	t.Setenv("SWECHAIN_DENOM", "uatom")
+	reloadConfig()
+	if *defaultFees != "5stake" {
+		t.Errorf("-fees = %q, want the explicitly set 5stake kept across a denom reload", *defaultFees)
+	}
+}
+
+// setDefaultDenom configures -default-denom for one test, with -fees derived
+// from it as when -fees is not given.
+func setDefaultDenom(t *testing.T, denom string) {
+	t.Helper()
+	restoreConfig(t)
+	flag.Set("default-denom", denom)
+	flag.Set("fees", "")
+	if err := validateFlags(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDefaultDenomFlag(t *testing.T) {
+	restoreConfig(t)
+	t.Setenv("SWECHAIN_DENOM", "uswe")
+	reloadConfig()
+	if got := configFrom(context.Background()).denom; got != "uswe" {
+		t.Errorf("SWECHAIN_DENOM set the default denom to %q, want uswe", got)
+	}
+
+	if err := flag.Set("default-denom", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateFlags(); err == nil || !strings.Contains(err.Error(), "-default-denom") {
+		t.Errorf("validateFlags = %v, want an error naming -default-denom", err)
+	}
+}
+
+func TestQuerySupplyDefaultsToDefaultDenom(t *testing.T) {
+	setDefaultDenom(t, "stake")
+	runner := newFakeRunner().on("query bank total", `{"denom":"stake","amount":"123456789012345678901234567890"}`)
+
+	text, _ := callTool(t, runner, querySupplyHandler, QuerySupplyParams{})
+	if !strings.Contains(text, "Total supply of stake is 123456789012345678901234567890") {
+		t.Errorf("result = %s, want the stake supply", text)
+	}
+	if calls := runner.callsTo("query bank total"); len(calls) != 1 || argValue(calls[0], "--denom") != "stake" {
+		t.Errorf("calls = %v, want one supply query for stake", calls)
+	}
+}
+
+func TestExtraArgsAreAppendedToEveryCommand(t *testing.T) {
+	restoreConfig(t)
+	if err := flag.Set("extra-args", `--grpc-addr localhost:9090,--trace "--log_format json"`); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateFlags(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"--grpc-addr", "localhost:9090", "--trace", "--log_format json"}
+
+	runner := newFakeRunner().
+		on("query bank balances", `{"balances":[]}`).
+		on("tx bank send", `{"code":0,"txhash":"A"}`)
+	callTool(t, runner, getBalanceHandler, GetBalanceParams{Address: testAlice})
+	callTool(t, runner, payHandler, PayParams{From: testAlice, To: testBob, Amount: "1token"})
+
+	for _, command := range []string{"query bank balances", "tx bank send"} {
+		calls := runner.callsTo(command)
+		if len(calls) != 1 {
+			t.Fatalf("%s calls = %v, want one", command, calls)
+		}
+		if got := calls[0][len(calls[0])-len(want):]; !slices.Equal(got, want) {
+			t.Errorf("%s args = %q, want them to end with %q", command, calls[0], want)
+		}
+	}
+}
+
+func TestExtraArgsRejectShellMetacharacters(t *testing.T) {
+	for _, s := range []string{"--trace; rm -rf /", "$(whoami)", "--node `host`", `"unterminated`} {
+		if args, err := splitExtraArgs(s); err == nil {
+			t.Errorf("splitExtraArgs(%q) = %q, want an error", s, args)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useFakeSwechaind builds cmd/fake-swechaind and points swechaindCmd at it
+// for the rest of the test, so handlers run real commands end to end.
+func useFakeSwechaind(t *testing.T) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds the fake swechaind binary")
+	}
+	binary := filepath.Join(t.TempDir(), "fake-swechaind")
+	build := exec.Command("go", "build", "-o", binary, "./cmd/fake-swechaind")
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("building fake swechaind: %v\n%s", err, output)
+	}
+
+	previous := swechaindCmd
+	swechaindCmd = binary
+	t.Cleanup(func() { swechaindCmd = previous })
+}
+
+func TestFakeSwechaindEndToEnd(t *testing.T) {
+	useFakeSwechaind(t)
+	runner := execRunner{}
+
+	t.Run("bids for auction", func(t *testing.T) {
+		text, isError := callTool(t, runner, queryBidsForAuctionHandler, QueryBidsForAuctionParams{AuctionId: "0"})
+		if isError {
+			t.Fatalf("unexpected error result: %s", text)
+		}
+		var response AuctionBidsResponse
+		if err := json.Unmarshal([]byte(text), &response); err != nil {
+			t.Fatalf("response is not JSON: %v\n%s", err, text)
+		}
+		if len(response.Details.Bids) != 2 {
+			t.Errorf("got %d bids for auction 0, want 2: %s", len(response.Details.Bids), text)
+		}
+	})
+
+	t.Run("balance", func(t *testing.T) {
+		text, isError := callTool(t, runner, getBalanceHandler, GetBalanceParams{Address: testAlice, Denom: "token"})
+		if isError {
+			t.Fatalf("unexpected error result: %s", text)
+		}
+		if !strings.Contains(text, "has 1000 token") {
+			t.Errorf("summary missing alice's token balance: %s", text)
+		}
+	})
+
+	t.Run("pay broadcast", func(t *testing.T) {
+		text, isError := callTool(t, runner, payHandler, PayParams{From: testAlice, To: testBob, Amount: "10token"})
+		if isError {
+			t.Fatalf("unexpected error result: %s", text)
+		}
+		var response TxResultResponse
+		if err := json.Unmarshal([]byte(text), &response); err != nil {
+			t.Fatalf("response is not JSON: %v\n%s", err, text)
+		}
+		if response.Details.TxHash == "" || response.Details.Code != 0 {
+			t.Errorf("broadcast result has no txhash or a non-zero code: %s", text)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var fieldsExamplePattern = regexp.MustCompile(`e\.g\. (\S+) \(optional\)`)
+
+// hasJSONPath reports whether path names a field reachable from typ by JSON
+// name. Slices are transparent, as in pruneFields, and any key of a map matches.
+func hasJSONPath(typ reflect.Type, path []string) bool {
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if len(path) == 0 {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Map:
+		return hasJSONPath(typ.Elem(), path[1:])
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.Anonymous {
+				if hasJSONPath(field.Type, path) {
+					return true
+				}
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == path[0] {
+				return hasJSONPath(field.Type, path[1:])
+			}
+		}
+	}
+	return false
+}
+
+func TestFieldsExamplesExistInResponses(t *testing.T) {
+	for _, tc := range []struct {
+		params, response interface{}
+	}{
+		{GetAddressForKeyParams{}, KeyAddressResponse{}},
+		{GetBalanceParams{}, BalanceResponse{}},
+		{GetAuctionsParams{}, GetAuctionsResponse{}},
+		{QueryOpenAuctionsParams{}, AuctionSummaryResponse{}},
+		{QueryAllAuctionsParams{}, AuctionSummaryResponse{}},
+		{RecentAuctionsParams{}, RecentAuctionsResponse{}},
+		{QueryBidsForAuctionParams{}, AuctionBidsResponse{}},
+		{BidsByBidderParams{}, BidsByBidderResponse{}},
+		{AuctionActivityParams{}, AuctionActivityResponse{}},
+		{StakingRatioParams{}, StakingRatioResponse{}},
+		{InflationParams{}, InflationResponse{}},
+		{IsValidatorParams{}, IsValidatorResponse{}},
+		{AuctionLeaderboardParams{}, AuctionLeaderboardResponse{}},
+		{MyAuctionsParams{}, MyAuctionsResponse{}},
+		{DecodeTxParams{}, DecodedTxResponse{}},
+		{CreatorCapacityParams{}, CreatorCapacityResponse{}},
+		{ClosedAuctionsParams{}, ClosedAuctionsResponse{}},
+		{SendEnabledParams{}, SendEnabledResponse{}},
+		{TVLAuctionsParams{}, TVLAuctionsResponse{}},
+		{AuctionsWonParams{}, AuctionsWonResponse{}},
+		{ValidatorDetailsParams{}, ValidatorDetailsResponse{}},
+		{TxsInRangeParams{}, TxsInRangeResponse{}},
+		{TxsBySenderParams{}, TxsBySenderResponse{}},
+		{GetBlockchainStatusParams{}, BlockchainStatusResponse{}},
+		{NetworkCongestionParams{}, NetworkCongestionResponse{}},
+		{ChainInfoParams{}, ChainInfoResponse{}},
+		{GetKeysParams{}, KeySummaryResponse{}},
+		{FindKeysParams{}, KeySummaryResponse{}},
+		{PortfolioParams{}, PortfolioResponse{}},
+		{QueryFeegrantsParams{}, FeegrantsResponse{}},
+		{QueryDelegationsParams{}, DelegationsResponse{}},
+	} {
+		paramsType := reflect.TypeOf(tc.params)
+		field, ok := paramsType.FieldByName("Fields")
+		if !ok {
+			t.Errorf("%s has no Fields parameter", paramsType.Name())
+			continue
+		}
+		match := fieldsExamplePattern.FindStringSubmatch(field.Tag.Get("jsonschema"))
+		if match == nil {
+			t.Errorf("%s Fields has no example", paramsType.Name())
+			continue
+		}
+		details, _ := reflect.TypeOf(tc.response).FieldByName("Details")
+		for _, path := range parseFieldPaths(match[1]) {
+			if !hasJSONPath(details.Type, path) {
+				t.Errorf("%s example path %s is not in %s", paramsType.Name(), strings.Join(path, "."), reflect.TypeOf(tc.response).Name())
+			}
+		}
+	}
+}
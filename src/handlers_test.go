@@ -0,0 +1,1123 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"swechain-mcp-server/src/internal/parse"
+)
+
+func TestFailedListIsNotReportedAsEmpty(t *testing.T) {
+	runner := newFakeRunner().fail("query issuemarket list-bid", "Error: rpc error: code = InvalidArgument desc = bad pagination")
+	h := withEmptyList("bids", queryBidsForAuctionHandler)
+
+	text, _ := callTool(t, runner, h, QueryBidsForAuctionParams{AuctionId: "all"})
+	if !strings.HasPrefix(text, "Error fetching bids") {
+		t.Errorf("result = %s, want a fetch error", text)
+	}
+	if strings.Contains(text, `"empty"`) {
+		t.Errorf("failed fetch reported as an empty list: %s", text)
+	}
+}
+
+func TestGetKeysHandlerReportsKeyringFailure(t *testing.T) {
+	runner := newFakeRunner().fail("keys list", "Error: keyring locked")
+
+	text, _ := callTool(t, runner, getKeysHandler, GetKeysParams{})
+	if !strings.HasPrefix(text, "Error fetching keys") {
+		t.Errorf("result = %s, want a fetch error", text)
+	}
+}
+
+func TestPortfolioReportsDelegationFailures(t *testing.T) {
+	runner := newFakeRunner().
+		on("query bank balances", `{"balances":[{"denom":"token","amount":"1000"}]}`).
+		fail("query staking delegations", "Error: rpc error: code = InvalidArgument desc = bad request").
+		on("query staking unbonding-delegations", `{"unbonding_responses":[]}`).
+		on("query staking params", `{"params":{"bond_denom":"stake"}}`).
+		on("query distribution rewards", `{"rewards":[],"total":[]}`)
+
+	text, _ := callTool(t, runner, portfolioHandler, PortfolioParams{Address: testAlice})
+	var response PortfolioResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	if _, ok := response.Details.Errors["delegations"]; !ok {
+		t.Errorf("delegations failure missing from errors: %s", text)
+	}
+	if _, ok := response.Details.Errors["unbonding"]; ok {
+		t.Errorf("unbonding reported as failed: %s", text)
+	}
+}
+
+func TestStakingRatioReportsValidatorFailure(t *testing.T) {
+	runner := newFakeRunner().
+		on("query staking params", `{"params":{"bond_denom":"stake"}}`).
+		fail("query staking validators", "Error: rpc error: code = InvalidArgument desc = bad request").
+		on("query bank total", `{"denom":"stake","amount":"1000"}`)
+
+	text, _ := callTool(t, runner, stakingRatioHandler, StakingRatioParams{})
+	if !strings.HasPrefix(text, "Error fetching validators") {
+		t.Errorf("result = %s, want a fetch error rather than a 0%% ratio", text)
+	}
+}
+
+func TestBatchBalancesCollectsEveryAddressInOrder(t *testing.T) {
+	carol := bech32Encode("cosmos", slices.Repeat([]byte{3}, 32))
+	runner := newFakeRunner().onCall("query bank balances", func(call int, args []string) (string, error) {
+		switch args[3] {
+		case testAlice:
+			return `{"balances":[{"denom":"token","amount":"100"},{"denom":"stake","amount":"5"}]}`, nil
+		case testBob:
+			return `{"balances":[]}`, nil
+		}
+		return "", errors.New("Error: rpc error: code = InvalidArgument desc = bad request")
+	})
+
+	text, isError := callTool(t, runner, batchBalancesHandler, BatchBalancesParams{Addresses: []string{testAlice, " " + testBob + " ", carol}})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	var response struct {
+		Summary string `json:"summary"`
+		Details struct {
+			Balances []AddressBalances `json:"balances"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	balances := response.Details.Balances
+	if len(balances) != 3 || balances[0].Address != testAlice || balances[1].Address != testBob || balances[2].Address != carol {
+		t.Fatalf("balances = %+v, want one entry per address in request order", balances)
+	}
+	if b := balances[0].Balances; len(b) != 2 || b[0].Amount != "100" || b[0].Denom != "token" || balances[0].Error != "" {
+		t.Errorf("alice = %+v, want 100token and 5stake", balances[0])
+	}
+	if len(balances[1].Balances) != 0 || balances[1].Error != "" {
+		t.Errorf("bob = %+v, want an empty balance without error", balances[1])
+	}
+	if balances[2].Error == "" {
+		t.Errorf("carol = %+v, want the query failure reported", balances[2])
+	}
+	if response.Summary != "Fetched balances for 2 addresses (1 failed)" {
+		t.Errorf("summary = %q", response.Summary)
+	}
+	if calls := runner.callsTo("query bank balances"); len(calls) != 3 {
+		t.Errorf("calls = %v, want one balances query per address", calls)
+	}
+}
+
+func TestValidatorDetailsReportsCommissionSelfBondAndUptime(t *testing.T) {
+	operator, err := convertBech32Prefix(testValidator, "cosmos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	runner := newFakeRunner().
+		on("query staking validator", `{"validator":{"operator_address":"`+testValidator+`","description":{"moniker":"node0"},`+
+			`"status":"BOND_STATUS_BONDED","jailed":true,"tokens":"5000","min_self_delegation":"1",`+
+			`"consensus_pubkey":{"@type":"/cosmos.crypto.ed25519.PubKey","key":"AAAA"},`+
+			`"commission":{"commission_rates":{"rate":"0.100000000000000000","max_rate":"0.200000000000000000","max_change_rate":"0.010000000000000000"}}}}`).
+		on("query staking delegation", `{"delegation_response":{"balance":{"denom":"stake","amount":"1000"}}}`).
+		on("query slashing signing-info", `{"val_signing_info":{"missed_blocks_counter":"5"}}`).
+		on("query slashing params", `{"params":{"signed_blocks_window":"100"}}`)
+
+	text, isError := callTool(t, runner, validatorDetailsHandler, ValidatorDetailsParams{Validator: testValidator})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	var response ValidatorDetailsResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	details := response.Details
+	if details.CommissionRate != "0.1" || details.CommissionMaxRate != "0.2" || details.CommissionMaxChangeRate != "0.01" {
+		t.Errorf("commission = %s/%s/%s, want 0.1/0.2/0.01", details.CommissionRate, details.CommissionMaxRate, details.CommissionMaxChangeRate)
+	}
+	if details.SelfDelegation != "1000stake" {
+		t.Errorf("selfDelegation = %q, want 1000stake", details.SelfDelegation)
+	}
+	if details.Uptime != "0.9500" {
+		t.Errorf("uptime = %q, want 0.9500", details.Uptime)
+	}
+	if !details.Jailed || !strings.HasSuffix(response.Summary, " and is jailed") {
+		t.Errorf("jailed = %v, summary = %q, want a jailed validator", details.Jailed, response.Summary)
+	}
+
+	calls := runner.callsTo("query staking delegation")
+	if len(calls) != 1 || calls[0][3] != operator || calls[0][4] != testValidator {
+		t.Errorf("calls = %v, want the self-delegation of %s to %s", calls, operator, testValidator)
+	}
+}
+
+func TestValidatorDetailsRejectsAccountAddress(t *testing.T) {
+	runner := newFakeRunner()
+	text, isError := callTool(t, runner, validatorDetailsHandler, ValidatorDetailsParams{Validator: testAlice})
+	if !isError || !hasFieldError(fieldErrors(t, text), "validator", codeInvalidAddress) {
+		t.Errorf("result = %s, want invalid_address on validator", text)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("ran %v for an invalid validator address", runner.calls)
+	}
+}
+
+func TestTxsInRangeSearchesWithQuery(t *testing.T) {
+	runner := newFakeRunner().on("query txs", `{"page_total":"1","txs":[
+		{"txhash":"AAA","height":"5","timestamp":"2024-01-01T00:00:00Z","tx":{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"}]}}},
+		{"txhash":"BBB","height":"7","timestamp":"2024-01-01T00:00:10Z","tx":{"body":{"messages":[{"@type":"/issuemarket.MsgCreateBid"}]}}}]}`)
+
+	text, isError := callTool(t, runner, txsInRangeHandler, TxsInRangeParams{MinHeight: 5, MaxHeight: 10})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	var response TxsInRangeResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	if txs := response.Details.Txs; len(txs) != 2 || txs[0].TxHash != "AAA" || txs[1].Height != "7" || txs[1].MessageType != "/issuemarket.MsgCreateBid" {
+		t.Errorf("txs = %+v, want both txs summarized", txs)
+	}
+
+	calls := runner.callsTo("query txs")
+	if len(calls) != 1 {
+		t.Fatalf("calls = %v, want one tx search", calls)
+	}
+	if got := argValue(calls[0], "--query"); got != "tx.height>=5 AND tx.height<=10" {
+		t.Errorf("--query = %q, want the height range", got)
+	}
+	if argValue(calls[0], "--events") != "" {
+		t.Errorf("height range passed as --events: %v", calls[0])
+	}
+}
+
+func TestInflationNotAvailableOnlyWithoutMintModule(t *testing.T) {
+	runner := newFakeRunner().fail("query mint inflation", `Error: unknown command "mint" for "swechaind query"`)
+	text, _ := callTool(t, runner, inflationHandler, InflationParams{})
+	var response InflationResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	if response.Details.Available || !strings.Contains(response.Summary, "not available") {
+		t.Errorf("result = %s, want not available", text)
+	}
+
+	runner = newFakeRunner().fail("query mint inflation", "Error: rpc error: code = Unimplemented desc = unknown service cosmos.mint.v1beta1.Query")
+	if text, _ := callTool(t, runner, inflationHandler, InflationParams{}); !strings.Contains(text, "not available") {
+		t.Errorf("result = %s, want not available for an unimplemented module", text)
+	}
+
+	runner = newFakeRunner().fail("query mint inflation", "Error: rpc error: code = InvalidArgument desc = bad request")
+	if text, _ := callTool(t, runner, inflationHandler, InflationParams{}); !strings.HasPrefix(text, "Error fetching inflation") {
+		t.Errorf("result = %s, want a fetch error rather than not available", text)
+	}
+}
+
+func TestInflationCombinesMintQueries(t *testing.T) {
+	runner := newFakeRunner().
+		on("query mint inflation", `{"inflation":"0.130000000000000000"}`).
+		on("query mint annual-provisions", `{"annual_provisions":"1300000.000000000000000000"}`).
+		on("query mint params", `{"params":{"mint_denom":"stake"}}`)
+
+	text, _ := callTool(t, runner, inflationHandler, InflationParams{})
+	var response InflationResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	if d := response.Details; !d.Available || d.Inflation != "0.13" || d.AnnualProvisions != "1300000" || d.MintDenom != "stake" {
+		t.Errorf("details = %+v", d)
+	}
+}
+
+const testStatus = `{"node_info":{"network":"swechain-test"},"sync_info":{"latest_block_height":"42","latest_block_time":"2024-01-01T00:00:00Z"}}`
+
+func TestBlockHeightReportsIntegerHeightAndChainID(t *testing.T) {
+	reset := func() {
+		blockHeightCache.Lock()
+		blockHeightCache.fetchedAt = time.Time{}
+		blockHeightCache.Unlock()
+	}
+	reset()
+	t.Cleanup(reset)
+
+	runner := newFakeRunner().on("status", testStatus)
+	text, isError := callTool(t, runner, blockHeightHandler, BlockHeightParams{})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var response struct {
+		Summary string                 `json:"summary"`
+		Details map[string]interface{} `json:"details"`
+	}
+	if err := decoder.Decode(&response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	if height, ok := response.Details["height"].(json.Number); !ok || height.String() != "42" {
+		t.Errorf("height = %#v, want the integer 42", response.Details["height"])
+	}
+	if response.Details["chainId"] != "swechain-test" {
+		t.Errorf("chainId = %#v, want swechain-test", response.Details["chainId"])
+	}
+	if len(response.Details) != 2 {
+		t.Errorf("details = %v, want only height and chainId", response.Details)
+	}
+	if response.Summary != "Latest block height on swechain-test is 42" {
+		t.Errorf("summary = %q", response.Summary)
+	}
+}
+
+func TestBlockHeightQueriesOutsideCacheLock(t *testing.T) {
+	blockHeightCache.Lock()
+	blockHeightCache.fetchedAt = time.Time{}
+	blockHeightCache.Unlock()
+
+	lockFree := false
+	runner := newFakeRunner().onCall("status", func(call int, args []string) (string, error) {
+		if blockHeightCache.TryLock() {
+			lockFree = true
+			blockHeightCache.Unlock()
+		}
+		return testStatus, nil
+	})
+
+	text, _ := callTool(t, runner, blockHeightHandler, BlockHeightParams{})
+	if !strings.Contains(text, "Latest block height on swechain-test is 42") {
+		t.Errorf("result = %s, want height 42", text)
+	}
+	if !lockFree {
+		t.Error("the cache lock was held while querying status")
+	}
+
+	// A second call within the TTL is served from the cache.
+	callTool(t, runner, blockHeightHandler, BlockHeightParams{})
+	if calls := len(runner.callsTo("status")); calls != 1 {
+		t.Errorf("status queried %d times, want 1", calls)
+	}
+}
+
+// resetChainInfoCache empties the chain-info cache now and once t ends.
+func resetChainInfoCache(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		chainInfoCache.Lock()
+		chainInfoCache.fetchedAt = time.Time{}
+		chainInfoCache.Unlock()
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+func TestChainInfoCombinesStatusWithStakingAndMintParams(t *testing.T) {
+	resetChainInfoCache(t)
+	runner := newFakeRunner().
+		on("status", testStatus).
+		on("query staking params", `{"params":{"bond_denom":"stake","unbonding_time":"1814400s","max_validators":100}}`).
+		on("query mint params", `{"params":{"mint_denom":"stake","inflation_min":"0.070000000000000000","inflation_max":"0.200000000000000000"}}`)
+
+	text, isError := callTool(t, runner, chainInfoHandler, ChainInfoParams{})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	var response ChainInfoResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	details := response.Details
+	if details.ChainID != "swechain-test" || details.LatestHeight != 42 || details.LatestBlockTime != "2024-01-01T00:00:00Z" {
+		t.Errorf("status details = %+v, want swechain-test at 42", details)
+	}
+	if details.BondDenom != "stake" || details.Staking["unbonding_time"] != "1814400s" {
+		t.Errorf("staking = %v, bond denom %q, want the staking params", details.Staking, details.BondDenom)
+	}
+	if details.Mint["mint_denom"] != "stake" || details.Mint["inflation_max"] != "0.200000000000000000" {
+		t.Errorf("mint = %v, want the mint params", details.Mint)
+	}
+	want := "Chain swechain-test at height 42, bond denom stake, inflation between 0.070000000000000000 and 0.200000000000000000"
+	if response.Summary != want {
+		t.Errorf("summary = %q, want %q", response.Summary, want)
+	}
+
+	// A second call within the TTL is served from the cache.
+	if again, _ := callTool(t, runner, chainInfoHandler, ChainInfoParams{}); again != text {
+		t.Errorf("cached result = %s, want %s", again, text)
+	}
+	if calls := len(runner.callsTo("status")); calls != 1 {
+		t.Errorf("status queried %d times, want 1", calls)
+	}
+}
+
+func TestChainInfoOmitsMissingModules(t *testing.T) {
+	resetChainInfoCache(t)
+	runner := newFakeRunner().
+		on("status", testStatus).
+		fail("query staking params", `Error: unknown command "staking" for "swechaind query"`).
+		fail("query mint params", `Error: unknown command "mint" for "swechaind query"`)
+
+	text, isError := callTool(t, runner, chainInfoHandler, ChainInfoParams{})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	var response struct {
+		Details map[string]interface{} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	for _, key := range []string{"bondDenom", "staking", "mint"} {
+		if _, ok := response.Details[key]; ok {
+			t.Errorf("details = %v, want %s omitted", response.Details, key)
+		}
+	}
+	if response.Details["chainId"] != "swechain-test" {
+		t.Errorf("details = %v, want the chain id", response.Details)
+	}
+}
+
+func TestChainInfoQueriesOutsideCacheLock(t *testing.T) {
+	resetChainInfoCache(t)
+
+	lockFree := false
+	runner := newFakeRunner().
+		onCall("status", func(call int, args []string) (string, error) {
+			if chainInfoCache.TryLock() {
+				lockFree = true
+				chainInfoCache.Unlock()
+			}
+			return testStatus, nil
+		}).
+		on("query staking params", `{"params":{"bond_denom":"stake"}}`).
+		fail("query mint params", `Error: unknown command "mint" for "swechaind query"`)
+
+	text, _ := callTool(t, runner, chainInfoHandler, ChainInfoParams{})
+	if !strings.Contains(text, "swechain-test") {
+		t.Errorf("result = %s, want the chain id", text)
+	}
+	if !lockFree {
+		t.Error("the cache lock was held while querying status")
+	}
+}
+
+func TestQueryFeegrantsListsActiveAllowances(t *testing.T) {
+	runner := newFakeRunner().on("query feegrant grants-by-grantee", `{"allowances":[
+		{"granter":"`+testBob+`","grantee":"`+testAlice+`","allowance":{"@type":"/cosmos.feegrant.v1beta1.BasicAllowance","spend_limit":[{"denom":"token","amount":"500"}],"expiration":"2999-01-01T00:00:00Z"}},
+		{"granter":"`+testBob+`","grantee":"`+testAlice+`","allowance":{"@type":"/cosmos.feegrant.v1beta1.BasicAllowance","expiration":"2000-01-01T00:00:00Z"}}
+	],"pagination":{"next_key":null,"total":"2"}}`)
+
+	text, isError := callTool(t, runner, queryFeegrantsHandler, QueryFeegrantsParams{Grantee: testAlice})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	var response FeegrantsResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	allowances := response.Details.Allowances
+	if len(allowances) != 1 {
+		t.Fatalf("allowances = %+v, want only the unexpired one", allowances)
+	}
+	if got := allowances[0]; got.Granter != testBob || got.Expiration != "2999-01-01T00:00:00Z" || len(got.SpendLimit) != 1 || got.SpendLimit[0].Amount != "500" {
+		t.Errorf("allowance = %+v", got)
+	}
+}
+
+func TestQueryFeegrantsValidatesGrantee(t *testing.T) {
+	runner := newFakeRunner()
+	text, isError := callTool(t, runner, queryFeegrantsHandler, QueryFeegrantsParams{Grantee: "cosmos1nope"})
+	if !isError || !strings.Contains(text, codeInvalidAddress) {
+		t.Errorf("result = %s, want an invalid_address error", text)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("queried %v with an invalid grantee", runner.calls)
+	}
+}
+
+func TestMinGasPricesFromNodeConfig(t *testing.T) {
+	runner := newFakeRunner().on("query node config", `{"minimum_gas_price":"0.025000000000000000token,0.100000000000000000stake","pruning_keep_recent":"0"}`)
+
+	text, isError := callTool(t, runner, minGasPricesHandler, MinGasPricesParams{})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	var response struct {
+		Details struct {
+			MinimumGasPrices []Balance `json:"minimumGasPrices"`
+			Source           string    `json:"source"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	prices := response.Details.MinimumGasPrices
+	if len(prices) != 2 || prices[0] != (Balance{Denom: "token", Amount: "0.025"}) || prices[1] != (Balance{Denom: "stake", Amount: "0.1"}) || response.Details.Source != "node" {
+		t.Errorf("result = %s, want both prices from the node", text)
+	}
+}
+
+func TestMinGasPricesFallsBackToAppToml(t *testing.T) {
+	restoreConfig(t)
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, "config"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	appToml := "# comment\nminimum-gas-prices = \"0.5token\"\n\n[api]\nenable = false\n"
+	if err := os.WriteFile(filepath.Join(home, "config", "app.toml"), []byte(appToml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	flag.Set("home", home)
+	runner := newFakeRunner().fail("query node config", `Error: unknown command "node" for "swechaind query"`)
+
+	text, _ := callTool(t, runner, minGasPricesHandler, MinGasPricesParams{})
+	if !strings.Contains(text, "at least 0.5token") || !strings.Contains(text, "app.toml") {
+		t.Errorf("result = %s, want the app.toml price", text)
+	}
+}
+
+func TestMinGasPricesNotAvailable(t *testing.T) {
+	restoreConfig(t)
+	flag.Set("home", "")
+	runner := newFakeRunner().fail("query node config", `Error: unknown command "node" for "swechaind query"`)
+
+	text, isError := callTool(t, runner, minGasPricesHandler, MinGasPricesParams{})
+	var response struct {
+		Details struct {
+			Available *bool `json:"available"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	if isError || response.Details.Available == nil || *response.Details.Available {
+		t.Errorf("result = %s, want a not-available response", text)
+	}
+}
+
+// auctionCountResponse decodes an auction-count result.
+func auctionCountResponse(t *testing.T, text string) (int, string) {
+	t.Helper()
+	var response struct {
+		Details struct {
+			Count  int    `json:"count"`
+			Source string `json:"source"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	return response.Details.Count, response.Details.Source
+}
+
+func TestAuctionCountReadsCounter(t *testing.T) {
+	runner := newFakeRunner().on("query issuemarket list-auction", `{"Auction":[{"id":"0","status":"open"}],"pagination":{"next_key":"AQ==","total":"42"}}`)
+
+	text, isError := callTool(t, runner, auctionCountHandler, AuctionCountParams{})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	if count, source := auctionCountResponse(t, text); count != 42 || source != "counter" {
+		t.Errorf("count = %d from %s, want 42 from the counter", count, source)
+	}
+	calls := runner.callsTo("query issuemarket list-auction")
+	if len(calls) != 1 || argValue(calls[0], "--page-limit") != "1" {
+		t.Errorf("calls = %v, want a single one-item page", calls)
+	}
+}
+
+func TestAuctionCountFallsBackToPagination(t *testing.T) {
+	auctions := `{"Auction":[{"id":"0","status":"open"},{"id":"1","status":"closed"},{"id":"2","status":"open"}],"pagination":{"next_key":null,"total":"0"}}`
+	for name, counter := range map[string]func() (string, error){
+		"total not reported": func() (string, error) { return auctions, nil },
+		"counter query fails": func() (string, error) {
+			return "", errors.New(`Error: unknown flag: --count-total`)
+		},
+	} {
+		runner := newFakeRunner().onCall("query issuemarket list-auction", func(call int, args []string) (string, error) {
+			if slices.Contains(args, "--count-total") {
+				return counter()
+			}
+			return auctions, nil
+		})
+
+		text, isError := callTool(t, runner, auctionCountHandler, AuctionCountParams{})
+		if isError {
+			t.Fatalf("%s: unexpected error result: %s", name, text)
+		}
+		if count, source := auctionCountResponse(t, text); count != 3 || source != "pagination" {
+			t.Errorf("%s: count = %d from %s, want 3 from pagination", name, count, source)
+		}
+	}
+}
+
+func TestResponsesAreStableForIdenticalInputs(t *testing.T) {
+	keys := newFakeRunner().on("keys show", `{"name":"alice","type":"local","address":"`+testAlice+`","pubkey":""}`)
+	bids := newFakeRunner().on("query issuemarket list-bid", `{"Bid":[
+		{"auctionId":"1","amount":"50token","description":"first","creator":"`+testAlice+`","bidder":"`+testAlice+`"},
+		{"auctionId":"1","amount":"70token","description":"second","creator":"`+testBob+`","bidder":"`+testBob+`"}
+	],"pagination":{"next_key":null,"total":"2"}}`)
+
+	for name, call := range map[string]func() string{
+		"get-address-for-key": func() string {
+			text, _ := callTool(t, keys, getAddressForKeyHandler, GetAddressForKeyParams{KeyName: "alice"})
+			return text
+		},
+		"query-bids-for-auction": func() string {
+			text, _ := callTool(t, bids, queryBidsForAuctionHandler, QueryBidsForAuctionParams{AuctionId: "1"})
+			return text
+		},
+	} {
+		first := call()
+		for i := 0; i < 20; i++ {
+			if again := call(); again != first {
+				t.Fatalf("%s: output changed between identical calls:\n%s\n---\n%s", name, first, again)
+			}
+		}
+	}
+}
+
+func TestNetworkCongestionOverBlockFixtures(t *testing.T) {
+	gasUsed := map[string]string{"42": "900000", "41": "700000", "40": "500000"}
+	runner := newFakeRunner().
+		on("status", testStatus).
+		onCall("query block-results", func(call int, args []string) (string, error) {
+			height := args[2]
+			used, ok := gasUsed[height]
+			if !ok {
+				return "", errors.New("Error: rpc error: code = InvalidArgument desc = height not sampled")
+			}
+			return `{"height":"` + height + `","txs_results":[{"gas_wanted":"1000000","gas_used":"` + used + `"}]}`, nil
+		})
+
+	var response NetworkCongestionResponse
+	decode := func(text string) {
+		t.Helper()
+		if err := json.Unmarshal([]byte(text), &response); err != nil {
+			t.Fatalf("response is not JSON: %v\n%s", err, text)
+		}
+	}
+
+	// Without a block gas limit the fixed thresholds apply.
+	runner.fail("query consensus params", "Error: rpc error: code = InvalidArgument desc = unsupported")
+	text, isError := callTool(t, runner, networkCongestionHandler, NetworkCongestionParams{Blocks: 3})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	decode(text)
+	if d := response.Details; len(d.Blocks) != 3 || d.AvgGasUsed != 700000 || d.AvgTxs != 1 || d.Congestion != "low" {
+		t.Errorf("details = %+v, want 3 blocks averaging 700000 gas, low", d)
+	}
+
+	// Against a 1,000,000 gas limit, 70% utilization is medium.
+	runner.on("query consensus params", `{"params":{"block":{"max_gas":"1000000"}}}`)
+	text, _ = callTool(t, runner, networkCongestionHandler, NetworkCongestionParams{Blocks: 3})
+	decode(text)
+	if d := response.Details; d.MaxBlockGas != 1000000 || d.GasUtilization != 70 || d.Congestion != "medium" {
+		t.Errorf("details = %+v, want 70%% utilization, medium", d)
+	}
+}
+
+func TestNetworkCongestionHigh(t *testing.T) {
+	response := buildNetworkCongestionResponse([]parse.BlockGas{{Height: 1, Txs: 9, GasUsed: 850}, {Height: 2, Txs: 11, GasUsed: 950}}, 1000)
+	if response.Details.Congestion != "high" || response.Details.AvgTxs != 10 {
+		t.Errorf("details = %+v, want high congestion with 10 txs per block", response.Details)
+	}
+}
+
+func TestGetAuctionsReportsMissingPerID(t *testing.T) {
+	runner := newFakeRunner().onCall("query issuemarket show-auction", func(call int, args []string) (string, error) {
+		switch id := args[3]; id {
+		case "1", "4":
+			return `{"Auction":{"id":"` + id + `","issue":"issue-` + id + `","status":"open","creator":"` + testAlice + `"}}`, nil
+		case "2":
+			return "", errors.New("Error: rpc error: code = NotFound desc = key not found")
+		default:
+			return "", errors.New("Error: rpc error: code = InvalidArgument desc = bad request")
+		}
+	})
+
+	text, isError := callTool(t, runner, getAuctionsHandler, GetAuctionsParams{AuctionIds: []string{"1", "2", "3", " 4 "}})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	var response GetAuctionsResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	lookups := response.Details.Auctions
+	if len(lookups) != 4 {
+		t.Fatalf("got %d lookups, want 4: %s", len(lookups), text)
+	}
+	for i, want := range []struct {
+		id       string
+		found    bool
+		hasError bool
+	}{{"1", true, false}, {"2", false, false}, {"3", false, true}, {"4", true, false}} {
+		got := lookups[i]
+		if got.AuctionID != want.id || got.Found != want.found || (got.Error != "") != want.hasError {
+			t.Errorf("lookup %d = %+v, want id %s found=%v error=%v", i, got, want.id, want.found, want.hasError)
+		}
+		if got.Found && (got.Auction == nil || got.Auction.Issue != "issue-"+want.id) {
+			t.Errorf("lookup %d auction = %+v", i, got.Auction)
+		}
+	}
+	if !strings.Contains(response.Summary, "Found 2 of 4") || !strings.Contains(response.Summary, "1 not found") || !strings.Contains(response.Summary, "1 failed") {
+		t.Errorf("summary = %q", response.Summary)
+	}
+}
+
+func TestGetAuctionsValidatesEachID(t *testing.T) {
+	runner := newFakeRunner()
+	text, isError := callTool(t, runner, getAuctionsHandler, GetAuctionsParams{AuctionIds: []string{"1", "two", "-3"}})
+	if !isError {
+		t.Fatalf("expected a validation error: %s", text)
+	}
+	errs := fieldErrors(t, text)
+	if len(errs) != 2 || !hasFieldError(errs, "auctionIds[1]", codeInvalidAuctionID) || !hasFieldError(errs, "auctionIds[2]", codeInvalidAuctionID) {
+		t.Errorf("errors = %+v, want invalid_auction_id for the second and third IDs", errs)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("queried %v despite invalid IDs", runner.calls)
+	}
+}
+
+func TestAuctionExists(t *testing.T) {
+	runner := newFakeRunner().onCall("query issuemarket show-auction", func(call int, args []string) (string, error) {
+		switch args[3] {
+		case "1":
+			return `{"Auction":{"id":"1","issue":"issue-1","status":"closed"}}`, nil
+		case "2":
+			return "", errors.New("Error: rpc error: code = NotFound desc = key 2 doesn't exist: key not found")
+		default:
+			return "", errors.New("Error: rpc error: code = InvalidArgument desc = bad request")
+		}
+	})
+
+	var response AuctionExistsResponse
+	for _, tc := range []struct {
+		id     string
+		exists bool
+		status string
+	}{{"1", true, "closed"}, {"2", false, ""}} {
+		text, isError := callTool(t, runner, auctionExistsHandler, AuctionExistsParams{AuctionId: tc.id})
+		if isError {
+			t.Fatalf("auction %s: unexpected error result: %s", tc.id, text)
+		}
+		response = AuctionExistsResponse{}
+		if err := json.Unmarshal([]byte(text), &response); err != nil {
+			t.Fatalf("response is not JSON: %v\n%s", err, text)
+		}
+		if d := response.Details; d.AuctionID != tc.id || d.Exists != tc.exists || d.Status != tc.status {
+			t.Errorf("auction %s: details = %+v, want exists=%v status=%q", tc.id, d, tc.exists, tc.status)
+		}
+	}
+
+	// A failed query is an error, not a confident "does not exist".
+	if text, _ := callTool(t, runner, auctionExistsHandler, AuctionExistsParams{AuctionId: "3"}); !strings.HasPrefix(text, "Error fetching auction 3") {
+		t.Errorf("result = %s, want a fetch error", text)
+	}
+}
+
+func TestAuctionExistsValidatesID(t *testing.T) {
+	runner := newFakeRunner()
+	text, isError := callTool(t, runner, auctionExistsHandler, AuctionExistsParams{AuctionId: "12a"})
+	if !isError || !hasFieldError(fieldErrors(t, text), "auctionId", codeInvalidAuctionID) {
+		t.Errorf("result = %s, want an invalid_auction_id error", text)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("queried %v with an invalid ID", runner.calls)
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"auctionId":        "auction_id",
+		"currentBidAmount": "current_bid_amount",
+		"txHash":           "tx_hash",
+		"feeGranterID":     "fee_granter_id",
+		"height":           "height",
+		"block2Height":     "block2_height",
+	} {
+		if got := toSnakeCase(name); got != want {
+			t.Errorf("toSnakeCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestJSONCaseSnakeMatchesCamelOutput(t *testing.T) {
+	previous := *jsonCase
+	t.Cleanup(func() { *jsonCase = previous })
+	runner := newFakeRunner().on("query issuemarket list-bid", `{"Bid":[
+		{"auctionId":"1","amount":"50token","description":"first","creator":"`+testAlice+`","bidder":"`+testAlice+`"}
+	],"pagination":{"next_key":null,"total":"1"}}`)
+	params := QueryBidsForAuctionParams{AuctionId: "1", Fields: "bids.auctionId,bids.amount"}
+	withReadCacheTTL(t, 0)
+	h := withReadCache("query-bids-for-auction", queryBidsForAuctionHandler)
+
+	*jsonCase = "camel"
+	camelText, _ := callTool(t, runner, h, params)
+	*jsonCase = "snake"
+	snakeText, _ := callTool(t, runner, h, params)
+
+	var camel, snake map[string]interface{}
+	if err := json.Unmarshal([]byte(camelText), &camel); err != nil {
+		t.Fatalf("camel response is not JSON: %v\n%s", err, camelText)
+	}
+	if err := json.Unmarshal([]byte(snakeText), &snake); err != nil {
+		t.Fatalf("snake response is not JSON: %v\n%s", err, snakeText)
+	}
+	camelBid := camel["details"].(map[string]interface{})["bids"].([]interface{})[0].(map[string]interface{})
+	snakeBid := snake["details"].(map[string]interface{})["bids"].([]interface{})[0].(map[string]interface{})
+	if camelBid["auctionId"] != "1" || camelBid["auction_id"] != nil {
+		t.Errorf("camel bid = %v, want auctionId", camelBid)
+	}
+	// The same values, with snake_case keys; fields paths stay camelCase.
+	if snakeBid["auction_id"] != "1" || snakeBid["auctionId"] != nil || snakeBid["amount"] != camelBid["amount"] || len(snakeBid) != len(camelBid) {
+		t.Errorf("snake bid = %v, want %v with snake_case keys", snakeBid, camelBid)
+	}
+	if _, ok := snake["details"].(map[string]interface{})["fetched_at"]; !ok {
+		t.Errorf("snake details lack fetched_at: %s", snakeText)
+	}
+}
+
+func TestSnakeCaseKeepsDataKeys(t *testing.T) {
+	converted := snakeCaseKeys(map[string]interface{}{
+		"totalsByDenom": map[string]string{"ibc/27394FB0": "5", "uatom": "7"},
+	}).(map[string]interface{})
+	totals, ok := converted["totals_by_denom"].(map[string]interface{})
+	if !ok || totals["ibc/27394FB0"] != "5" || totals["uatom"] != "7" {
+		t.Errorf("converted = %v, want denom keys unchanged", converted)
+	}
+}
+
+func TestRecentAuctionsNewestFirst(t *testing.T) {
+	auctions := []Auction{{ID: 3}, {ID: 10}, {ID: 1}, {ID: 7}}
+	recent := recentAuctions(auctions, 3)
+	if len(recent) != 3 || recent[0].ID != 10 || recent[1].ID != 7 || recent[2].ID != 3 {
+		t.Errorf("recentAuctions = %+v, want IDs 10, 7, 3", recent)
+	}
+	if auctions[0].ID != 3 {
+		t.Errorf("recentAuctions reordered its input: %+v", auctions)
+	}
+	if all := recentAuctions(auctions, 10); len(all) != 4 {
+		t.Errorf("got %d auctions, want all 4 when count exceeds them", len(all))
+	}
+}
+
+func TestRecentAuctionsDefaultAndCap(t *testing.T) {
+	const total = 150
+	withPaging(t, 50, 0)
+	runner := newFakeRunner().
+		onCall("query issuemarket list-auction", func(call int, args []string) (string, error) {
+			offset, _ := strconv.Atoi(argValue(args, "--page-offset"))
+			limit, _ := strconv.Atoi(argValue(args, "--page-limit"))
+			var page []string
+			for id := offset; id < min(offset+limit, total); id++ {
+				page = append(page, fmt.Sprintf(`{"id":"%d","issue":"issue-%d","status":"open"}`, id, id))
+			}
+			return `{"Auction":[` + strings.Join(page, ",") + `],"pagination":{}}`, nil
+		}).
+		on("query issuemarket list-bid", `{"Bid":[],"pagination":{}}`).
+		on("keys list", `[]`)
+
+	for _, tc := range []struct {
+		count, want int
+	}{{0, defaultRecentAuctions}, {5, 5}, {500, maxRecentAuctions}} {
+		text, isError := callTool(t, runner, recentAuctionsHandler, RecentAuctionsParams{Count: tc.count})
+		if isError {
+			t.Fatalf("count %d: unexpected error result: %s", tc.count, text)
+		}
+		var response RecentAuctionsResponse
+		if err := json.Unmarshal([]byte(text), &response); err != nil {
+			t.Fatalf("response is not JSON: %v\n%s", err, text)
+		}
+		got := response.Details.Auctions
+		if len(got) != tc.want {
+			t.Errorf("count %d: got %d auctions, want %d", tc.count, len(got), tc.want)
+			continue
+		}
+		for i, auction := range got {
+			if auction.AuctionID != total-1-i {
+				t.Errorf("count %d: auction %d has ID %d, want %d", tc.count, i, auction.AuctionID, total-1-i)
+				break
+			}
+		}
+	}
+
+	text, isError := callTool(t, runner, recentAuctionsHandler, RecentAuctionsParams{Count: -1})
+	if !isError || !hasFieldError(fieldErrors(t, text), "count", codeOutOfRange) {
+		t.Errorf("result = %s, want an out_of_range error for a negative count", text)
+	}
+}
+
+func TestGetBalanceDistinguishesNeverFundedAddress(t *testing.T) {
+	yes, no := true, false
+	for _, tc := range []struct {
+		name    string
+		account func(*fakeRunner) *fakeRunner
+		exists  *bool
+		summary string
+	}{
+		{"never funded", func(r *fakeRunner) *fakeRunner {
+			return r.fail("query auth account", "Error: rpc error: code = NotFound desc = account "+testAlice+" not found: key not found")
+		}, &no, "has never received funds"},
+		{"drained", func(r *fakeRunner) *fakeRunner {
+			return r.on("query auth account", `{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"`+testAlice+`","sequence":"3"}}`)
+		}, &yes, "has an account but holds no tokens"},
+		{"check failed", func(r *fakeRunner) *fakeRunner {
+			return r.fail("query auth account", "Error: rpc error: code = InvalidArgument desc = bad request")
+		}, nil, "has 0 "},
+	} {
+		runner := tc.account(newFakeRunner().on("query bank balances", `{"balances":[],"pagination":{}}`))
+
+		text, isError := callTool(t, runner, getBalanceHandler, GetBalanceParams{Address: testAlice})
+		if isError {
+			t.Fatalf("%s: unexpected error result: %s", tc.name, text)
+		}
+		var response BalanceResponse
+		if err := json.Unmarshal([]byte(text), &response); err != nil {
+			t.Fatalf("%s: response is not JSON: %v\n%s", tc.name, err, text)
+		}
+		if !strings.Contains(response.Summary, tc.summary) {
+			t.Errorf("%s: summary = %q, want it to say %q", tc.name, response.Summary, tc.summary)
+		}
+		got := response.Details.AccountExists
+		if (got == nil) != (tc.exists == nil) || (got != nil && *got != *tc.exists) {
+			t.Errorf("%s: accountExists = %v, want %v", tc.name, got, tc.exists)
+		}
+	}
+}
+
+func TestGetBalanceSkipsAccountCheckWhenFunded(t *testing.T) {
+	runner := newFakeRunner().on("query bank balances", `{"balances":[{"denom":"token","amount":"5"}]}`)
+	callTool(t, runner, getBalanceHandler, GetBalanceParams{Address: testAlice})
+	if calls := runner.callsTo("query auth account"); len(calls) != 0 {
+		t.Errorf("checked the account of a funded address: %v", calls)
+	}
+}
+
+func TestGetBalanceFiltersByDenom(t *testing.T) {
+	for _, tc := range []struct {
+		denom    string
+		balances []Balance
+		summary  string
+	}{
+		{"stake", []Balance{{Denom: "stake", Amount: "7"}}, "Address " + testAlice + " has 7 stake"},
+		{"ibc/ABC123", []Balance{{Denom: "ibc/ABC123", Amount: "3"}}, "Address " + testAlice + " has 3 ibc/ABC123"},
+		{"uatom", []Balance{}, "Address " + testAlice + " has 0 uatom"},
+	} {
+		runner := newFakeRunner().on("query bank balances", `{"balances":[{"denom":"ibc/ABC123","amount":"3"},{"denom":"stake","amount":"7"},{"denom":"token","amount":"100"}]}`)
+
+		text, isError := callTool(t, runner, getBalanceHandler, GetBalanceParams{Address: testAlice, Denom: tc.denom})
+		if isError {
+			t.Fatalf("%s: unexpected error result: %s", tc.denom, text)
+		}
+		var response BalanceResponse
+		if err := json.Unmarshal([]byte(text), &response); err != nil {
+			t.Fatalf("%s: response is not JSON: %v\n%s", tc.denom, err, text)
+		}
+		if response.Details.Balances == nil || !reflect.DeepEqual(response.Details.Balances, tc.balances) {
+			t.Errorf("%s: balances = %+v, want %+v", tc.denom, response.Details.Balances, tc.balances)
+		}
+		if response.Summary != tc.summary {
+			t.Errorf("%s: summary = %q, want %q", tc.denom, response.Summary, tc.summary)
+		}
+	}
+}
+
+func TestGetBalanceRejectsInvalidDenom(t *testing.T) {
+	runner := newFakeRunner()
+	text, isError := callTool(t, runner, getBalanceHandler, GetBalanceParams{Address: testAlice, Denom: "1bad denom"})
+	if !isError || !hasFieldError(fieldErrors(t, text), "denom", codeInvalidDenom) {
+		t.Errorf("result = %s, want invalid_denom on denom", text)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("ran %v for an invalid denom", runner.calls)
+	}
+}
+
+func TestAuctionSummaryNamesLocalCreatorsOnly(t *testing.T) {
+	auctions := []Auction{
+		{ID: 1, Issue: "#1", Creator: testAlice, Status: "open"},
+		{ID: 2, Issue: "#2", Creator: testBob, Status: "open"},
+	}
+	keyNames := map[string]string{testAlice: "alice"}
+
+	response := buildAuctionSummaryResponse(withConfig(context.Background(), loadConfig()), auctions, nil, nil, keyNames, "open")
+	details := response.Details.Auctions
+	if len(details) != 2 {
+		t.Fatalf("auctions = %+v, want 2", details)
+	}
+	if details[0].Creator != testAlice || details[0].CreatorName != "alice" {
+		t.Errorf("local creator = %+v, want alice's key name", details[0])
+	}
+	if details[1].Creator != testBob || details[1].CreatorName != "" {
+		t.Errorf("remote creator = %+v, want no name", details[1])
+	}
+
+	encoded, _ := json.Marshal(details[1])
+	if strings.Contains(string(encoded), "creatorName") {
+		t.Errorf("remote creator encodes as %s, want creatorName omitted", encoded)
+	}
+}
+
+func TestCreatorCapacityComparesSpendableBalanceWithLeadingBid(t *testing.T) {
+	withPaging(t, 50, 0)
+	bids := `{"Bid":[
+		{"auctionId":"1","amount":"300token","bidder":"` + testBob + `"},
+		{"auctionId":"1","amount":"120token","bidder":"` + testBob + `"},
+		{"auctionId":"1","amount":"900stake","bidder":"` + testBob + `"},
+		{"auctionId":"2","amount":"5000token","bidder":"` + testBob + `"}
+	],"pagination":{"next_key":null,"total":"4"}}`
+
+	for _, tc := range []struct {
+		name, auctionId, spendable, bid string
+		canCover                        bool
+	}{
+		{"covers", "1", `[{"denom":"token","amount":"500"},{"denom":"stake","amount":"1"}]`, "300token", true},
+		{"exactly covers", "1", `[{"denom":"token","amount":"300"}]`, "300token", true},
+		{"short", "1", `[{"denom":"token","amount":"299"},{"denom":"stake","amount":"100000"}]`, "300token", false},
+		{"nothing spendable", "1", `[]`, "300token", false},
+		{"no bids", "3", `[{"denom":"token","amount":"1"}]`, "0", true},
+	} {
+		runner := newFakeRunner().
+			on("query issuemarket show-auction", `{"Auction":{"id":"`+tc.auctionId+`","issue":"#1","creator":"`+testAlice+`","status":"open"}}`).
+			on("query bank spendable-balances", `{"balances":`+tc.spendable+`,"pagination":{}}`).
+			on("query issuemarket list-bid", bids)
+
+		text, isError := callTool(t, runner, creatorCapacityHandler, CreatorCapacityParams{AuctionId: tc.auctionId})
+		var response CreatorCapacityResponse
+		if err := json.Unmarshal([]byte(text), &response); err != nil || isError {
+			t.Fatalf("%s: response = %s", tc.name, text)
+		}
+		if response.Details.Creator != testAlice || response.Details.CurrentBidAmount != tc.bid || response.Details.CanCoverCurrentBid != tc.canCover {
+			t.Errorf("%s: details = %+v, want bid %s and canCover %v", tc.name, response.Details, tc.bid, tc.canCover)
+		}
+		if calls := runner.callsTo("query bank spendable-balances"); len(calls) != 1 || calls[0][3] != testAlice {
+			t.Errorf("%s: spendable balance calls = %v, want the creator's", tc.name, calls)
+		}
+	}
+
+	runner := newFakeRunner().fail("query issuemarket show-auction", "Error: rpc error: code = NotFound desc = key not found")
+	text, isError := callTool(t, runner, creatorCapacityHandler, CreatorCapacityParams{AuctionId: "7"})
+	if !isError || !hasFieldError(fieldErrors(t, text), "auctionId", codeNotFound) {
+		t.Errorf("result = %s, want not_found on auctionId", text)
+	}
+}
+
+func TestClosedAuctionsListsOnlyClosedWithWinners(t *testing.T) {
+	withPaging(t, 50, 0)
+	runner := newFakeRunner().
+		on("query issuemarket list-auction", `{"Auction":[
+			{"id":"3","issue":"#3","creator":"`+testAlice+`","status":"closed","winner":""},
+			{"id":"1","issue":"#1","creator":"`+testAlice+`","status":"open","winner":""},
+			{"id":"2","issue":"#2","creator":"`+testBob+`","status":"Closed","winner":"`+testAlice+`"},
+			{"id":"4","issue":"#4","creator":"`+testBob+`","status":"closed","winner":""},
+			{"id":"5","issue":"#5","creator":"`+testBob+`","status":"cancelled","winner":""}
+		],"pagination":{"next_key":null,"total":"5"}}`).
+		on("query issuemarket list-bid", `{"Bid":[
+			{"auctionId":"1","amount":"999token","bidder":"`+testBob+`"},
+			{"auctionId":"2","amount":"40token","bidder":"`+testAlice+`"},
+			{"auctionId":"2","amount":"25token","bidder":"`+testBob+`"},
+			{"auctionId":"3","amount":"70token","bidder":"`+testBob+`"}
+		],"pagination":{"next_key":null,"total":"4"}}`)
+
+	text, isError := callTool(t, runner, closedAuctionsHandler, ClosedAuctionsParams{})
+	var response ClosedAuctionsResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil || isError {
+		t.Fatalf("response = %s", text)
+	}
+	want := []ClosedAuctionEntry{
+		{AuctionID: 2, Issue: "#2", Creator: testBob, Winner: testAlice, WinningAmount: "40token", BidCount: 2},
+		{AuctionID: 3, Issue: "#3", Creator: testAlice, Winner: testBob, WinningAmount: "70token", BidCount: 1},
+		{AuctionID: 4, Issue: "#4", Creator: testBob, WinningAmount: "0"},
+	}
+	if !slices.Equal(response.Details.Auctions, want) {
+		t.Errorf("auctions = %+v\nwant %+v", response.Details.Auctions, want)
+	}
+	if response.Summary != "Found 3 closed auctions" {
+		t.Errorf("summary = %q", response.Summary)
+	}
+}
+
+func TestCompactJSONIsSmallerWithSameContent(t *testing.T) {
+	previous := *compactJSON
+	t.Cleanup(func() { *compactJSON = previous })
+	runner := newFakeRunner().on("query issuemarket list-bid", `{"Bid":[
+		{"auctionId":"1","amount":"50token","description":"first","creator":"`+testAlice+`","bidder":"`+testAlice+`"},
+		{"auctionId":"1","amount":"70token","description":"second","creator":"`+testBob+`","bidder":"`+testBob+`"}
+	],"pagination":{"next_key":null,"total":"2"}}`)
+
+	*compactJSON = false
+	indented, _ := callTool(t, runner, queryBidsForAuctionHandler, QueryBidsForAuctionParams{AuctionId: "1"})
+	*compactJSON = true
+	compact, _ := callTool(t, runner, queryBidsForAuctionHandler, QueryBidsForAuctionParams{AuctionId: "1"})
+
+	if strings.Contains(compact, "\n") || !strings.Contains(indented, "\n  ") {
+		t.Errorf("compact output has newlines or indented output has none:\n%s\n---\n%s", compact, indented)
+	}
+	if len(compact) >= len(indented) {
+		t.Errorf("compact output is %d bytes, indented %d; want compact smaller", len(compact), len(indented))
+	}
+	var compactValue, indentedValue interface{}
+	if err := json.Unmarshal([]byte(compact), &compactValue); err != nil {
+		t.Fatalf("compact output is not JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(indented), &indentedValue); err != nil {
+		t.Fatalf("indented output is not JSON: %v", err)
+	}
+	if !reflect.DeepEqual(compactValue, indentedValue) {
+		t.Errorf("compact and indented outputs decode differently:\n%s\n---\n%s", compact, indented)
+	}
+}
+
+func TestAuctionsWonUsesWinnersOwnHighestBid(t *testing.T) {
+	withPaging(t, 50, 0)
+	runner := newFakeRunner().
+		on("query issuemarket list-auction", `{"Auction":[
+			{"id":"4","issue":"#4","creator":"`+testBob+`","status":"closed","winner":"`+testAlice+`"},
+			{"id":"1","issue":"#1","creator":"`+testBob+`","status":"closed","winner":"`+testAlice+`"},
+			{"id":"2","issue":"#2","creator":"`+testAlice+`","status":"closed","winner":"`+testBob+`"},
+			{"id":"3","issue":"#3","creator":"`+testBob+`","status":"open","winner":""},
+			{"id":"5","issue":"#5","creator":"`+testBob+`","status":"closed","winner":"`+testAlice+`"}
+		],"pagination":{"next_key":null,"total":"5"}}`).
+		on("query issuemarket list-bid", `{"Bid":[
+			{"auctionId":"1","amount":"40token","bidder":"`+testAlice+`"},
+			{"auctionId":"1","amount":"60token","bidder":"`+testAlice+`"},
+			{"auctionId":"1","amount":"90token","bidder":"`+testBob+`"},
+			{"auctionId":"2","amount":"80token","bidder":"`+testBob+`"},
+			{"auctionId":"3","amount":"500token","bidder":"`+testAlice+`"},
+			{"auctionId":"4","amount":"7stake","bidder":"`+testAlice+`"}
+		],"pagination":{"next_key":null,"total":"6"}}`)
+
+	text, isError := callTool(t, runner, auctionsWonHandler, AuctionsWonParams{Address: testAlice})
+	var response AuctionsWonResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil || isError {
+		t.Fatalf("response = %s", text)
+	}
+	want := []ClosedAuctionEntry{
+		{AuctionID: 1, Issue: "#1", Creator: testBob, Winner: testAlice, WinningAmount: "60token", BidCount: 3},
+		{AuctionID: 4, Issue: "#4", Creator: testBob, Winner: testAlice, WinningAmount: "7stake", BidCount: 1},
+		{AuctionID: 5, Issue: "#5", Creator: testBob, Winner: testAlice, WinningAmount: "0"},
+	}
+	if !slices.Equal(response.Details.Auctions, want) {
+		t.Errorf("auctions = %+v\nwant %+v", response.Details.Auctions, want)
+	}
+	if got := response.Details.TotalsByDenom; len(got) != 2 || got["token"] != "60" || got["stake"] != "7" {
+		t.Errorf("totals = %v, want 60 token and 7 stake", got)
+	}
+
+	text, _ = callTool(t, runner, auctionsWonHandler, AuctionsWonParams{Address: "cosmos1carolcarolcarolcarolcarolcarolcarol"})
+	if err := json.Unmarshal([]byte(text), &response); err != nil || len(response.Details.Auctions) != 0 {
+		t.Errorf("response = %s, want no auctions won", text)
+	}
+}
@@ -0,0 +1,92 @@
+package parse
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// SendEnabledEntry is a per-denom override from `query bank send-enabled`.
+type SendEnabledEntry struct {
+	Denom   string `json:"denom"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Supply extracts the amount for denom from a bank supply query. It accepts
+// a bare coin, a coin nested under "amount", or a full "supply" list.
+func Supply(output, denom string) (string, error) {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return "", OutputError("supply data", err, output)
+	}
+
+	if coin, ok := responseData["amount"].(map[string]interface{}); ok {
+		responseData = coin
+	}
+	if String(responseData, "denom") == denom {
+		return String(responseData, "amount"), nil
+	}
+
+	if supply, ok := responseData["supply"].([]interface{}); ok {
+		for _, raw := range supply {
+			coin, ok := raw.(map[string]interface{})
+			if ok && String(coin, "denom") == denom {
+				return String(coin, "amount"), nil
+			}
+		}
+	}
+
+	return "0", nil
+}
+
+// SendEnabled reads `query bank send-enabled`, sorted by denom. Empty output
+// means there are no overrides.
+func SendEnabled(output string) ([]SendEnabledEntry, error) {
+	entries := []SendEnabledEntry{}
+	if strings.TrimSpace(output) == "" {
+		return entries, nil
+	}
+
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return nil, OutputError("send-enabled data", err, output)
+	}
+
+	rawEntries, _ := responseData["send_enabled"].([]interface{})
+	for _, raw := range rawEntries {
+		rawMap, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		entries = append(entries, SendEnabledEntry{
+			Denom:   String(rawMap, "denom"),
+			Enabled: Bool(rawMap["enabled"]),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Denom < entries[j].Denom })
+	return entries, nil
+}
+
+// CommunityPool reads `query distribution community-pool`, whose pool is a
+// list of DecCoins, or a single DecCoins string in some older versions.
+func CommunityPool(output string) ([]Balance, error) {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return nil, OutputError("community pool data", err, output)
+	}
+
+	if text, ok := responseData["pool"].(string); ok {
+		return DecCoinsString(text)
+	}
+	return DecCoins(responseData["pool"])
+}
+
+// NodeMinGasPrices reads `query node config`, which reports the prices as
+// a single DecCoins string under minimum_gas_price.
+func NodeMinGasPrices(output string) ([]Balance, error) {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return nil, OutputError("node config", err, output)
+	}
+	return DecCoinsString(String(responseData, "minimum_gas_price"))
+}
@@ -0,0 +1,59 @@
+package parse
+
+import "testing"
+
+func TestSupply(t *testing.T) {
+	for _, tc := range []struct {
+		name, output, want string
+	}{
+		{"bare coin", `{"denom":"stake","amount":"500"}`, "500"},
+		{"nested coin", `{"amount":{"denom":"stake","amount":"500"}}`, "500"},
+		{"supply list", `{"supply":[{"denom":"token","amount":"1"},{"denom":"stake","amount":"500"}]}`, "500"},
+		{"missing", `{"supply":[{"denom":"token","amount":"1"}]}`, "0"},
+	} {
+		got, err := Supply(tc.output, "stake")
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		} else if got != tc.want {
+			t.Errorf("%s: got %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSendEnabledSortsByDenom(t *testing.T) {
+	entries, err := SendEnabled(`{"send_enabled":[{"denom":"token","enabled":false},{"denom":"stake","enabled":true}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0] != (SendEnabledEntry{"stake", true}) || entries[1] != (SendEnabledEntry{"token", false}) {
+		t.Errorf("entries = %+v", entries)
+	}
+	if entries, err := SendEnabled(""); err != nil || entries == nil || len(entries) != 0 {
+		t.Errorf("SendEnabled(\"\") = %v, %v, want an empty list", entries, err)
+	}
+}
+
+func TestCommunityPoolListOrString(t *testing.T) {
+	for _, output := range []string{
+		`{"pool":[{"denom":"stake","amount":"12.500000000000000000"}]}`,
+		`{"pool":"12.500000000000000000stake"}`,
+	} {
+		pool, err := CommunityPool(output)
+		if err != nil {
+			t.Fatalf("%s: %v", output, err)
+		}
+		if len(pool) != 1 || pool[0].Denom != "stake" || pool[0].Amount != "12.5" {
+			t.Errorf("CommunityPool(%s) = %+v", output, pool)
+		}
+	}
+}
+
+func TestNodeMinGasPrices(t *testing.T) {
+	prices, err := NodeMinGasPrices(`{"minimum_gas_price":"0.025000000000000000stake"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 1 || prices[0].Denom != "stake" || prices[0].Amount != "0.025" {
+		t.Errorf("prices = %+v", prices)
+	}
+}
@@ -0,0 +1,36 @@
+package parse
+
+import (
+	"math/big"
+)
+
+// AuctionBidDenom returns the denom an auction is bid in: that of its first
+// bid with a valid amount, or "" when there is none. Amounts in different
+// denoms can't be compared, so bids in any other denom never rank or lead.
+func AuctionBidDenom(bids []Bid) string {
+	for _, bid := range bids {
+		if _, denom, err := CoinAmount(bid.Amount); err == nil {
+			return denom
+		}
+	}
+	return ""
+}
+
+// HighestBid returns the bid with the largest amount in the auction's bid
+// denom (see AuctionBidDenom). Bids with unparseable amounts or another denom
+// are ignored; ties keep the earliest bid.
+func HighestBid(bids []Bid) (Bid, bool) {
+	auctionDenom := AuctionBidDenom(bids)
+	var best Bid
+	var bestValue *big.Int
+	for _, bid := range bids {
+		value, denom, err := CoinAmount(bid.Amount)
+		if err != nil || denom != auctionDenom {
+			continue
+		}
+		if bestValue == nil || value.Cmp(bestValue) > 0 {
+			best, bestValue = bid, value
+		}
+	}
+	return best, bestValue != nil
+}
@@ -0,0 +1,36 @@
+package parse
+
+import "testing"
+
+func TestHighestBidIgnoresOtherDenoms(t *testing.T) {
+	bids := []Bid{
+		{AuctionID: 1, Bidder: "cosmos1alice", Amount: "50stake"},
+		{AuctionID: 1, Bidder: "cosmos1bob", Amount: "100uatom"},
+		{AuctionID: 1, Bidder: "cosmos1bob", Amount: "60stake"},
+	}
+	leader, ok := HighestBid(bids)
+	if !ok || leader.Amount != "60stake" {
+		t.Errorf("HighestBid = %+v, want the 60stake bid rather than 100uatom", leader)
+	}
+}
+
+func TestHighestBidBeyondInt64(t *testing.T) {
+	bids := []Bid{
+		{Bidder: "cosmos1alice", Amount: "9223372036854775807token"},
+		{Bidder: "cosmos1bob", Amount: "9223372036854775808000token"},
+	}
+	leader, ok := HighestBid(bids)
+	if !ok || leader.Bidder != "cosmos1bob" {
+		t.Errorf("HighestBid = %+v, want the bid larger than int64 max", leader)
+	}
+}
+
+func TestAuctionBidDenomSkipsInvalidAmounts(t *testing.T) {
+	bids := []Bid{{Amount: "lots"}, {Amount: "5stake"}, {Amount: "9uatom"}}
+	if got := AuctionBidDenom(bids); got != "stake" {
+		t.Errorf("AuctionBidDenom = %q, want stake", got)
+	}
+	if got := AuctionBidDenom(nil); got != "" {
+		t.Errorf("AuctionBidDenom(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,171 @@
+package parse
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+var coinPattern = regexp.MustCompile(`^([0-9]+)([a-zA-Z][a-zA-Z0-9/:._-]{1,127})$`)
+
+var decCoinPattern = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)([a-zA-Z][a-zA-Z0-9/:._-]{1,127})$`)
+
+// SplitCoin splits a coin string such as "100token" into its amount and denom.
+func SplitCoin(coin string) (string, string, bool) {
+	match := coinPattern.FindStringSubmatch(strings.TrimSpace(coin))
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// Amount parses an integer coin amount without overflow, since amounts
+// can exceed int64 on high-precision chains.
+func Amount(s string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	if value.Sign() < 0 {
+		return nil, fmt.Errorf("amount %q must not be negative", s)
+	}
+	return value, nil
+}
+
+// CoinAmount parses a coin string such as "100token" into its amount and denom.
+func CoinAmount(coin string) (*big.Int, string, error) {
+	amount, denom, ok := SplitCoin(coin)
+	if !ok {
+		return nil, "", fmt.Errorf("invalid coin %q", coin)
+	}
+	value, err := Amount(amount)
+	if err != nil {
+		return nil, "", err
+	}
+	return value, denom, nil
+}
+
+// DecAmount parses a decimal amount such as "123.456789" exactly.
+func DecAmount(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		return nil, fmt.Errorf("invalid decimal amount %q", s)
+	}
+	value, ok := new(big.Rat).SetString(s)
+	if !ok || strings.ContainsAny(s, "eE/") {
+		return nil, fmt.Errorf("invalid decimal amount %q", s)
+	}
+	return value, nil
+}
+
+// DecCoin parses a DecCoin string such as "123.456789token", as used for
+// rewards, commission and the community pool. Send amounts stay integer coins.
+func DecCoin(coin string) (*big.Rat, string, error) {
+	match := decCoinPattern.FindStringSubmatch(strings.TrimSpace(coin))
+	if match == nil {
+		return nil, "", fmt.Errorf("invalid decimal coin %q", coin)
+	}
+	value, err := DecAmount(match[1])
+	if err != nil {
+		return nil, "", err
+	}
+	return value, match[2], nil
+}
+
+// DecCoins reads a JSON list of {denom, amount} DecCoins, as found under
+// "pool", "rewards" or "commission" in distribution query output.
+func DecCoins(raw interface{}) ([]Balance, error) {
+	list, _ := raw.([]interface{})
+	coins := []Balance{}
+	for _, item := range list {
+		rawMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, err := DecAmount(String(rawMap, "amount"))
+		if err != nil {
+			return nil, err
+		}
+		coins = append(coins, Balance{Denom: String(rawMap, "denom"), Amount: FormatDec(value)})
+	}
+	return coins, nil
+}
+
+// DecCoinsString parses a comma-separated DecCoins string such as
+// "0.025stake,1token". Empty entries are skipped, so "" yields no coins.
+func DecCoinsString(text string) ([]Balance, error) {
+	coins := []Balance{}
+	for _, coin := range strings.Split(text, ",") {
+		if strings.TrimSpace(coin) == "" {
+			continue
+		}
+		value, denom, err := DecCoin(coin)
+		if err != nil {
+			return nil, err
+		}
+		coins = append(coins, Balance{Denom: denom, Amount: FormatDec(value)})
+	}
+	return coins, nil
+}
+
+// FormatDec renders a decimal without the trailing zeros of the SDK's
+// 18-digit precision, e.g. 123.456000000000000000 as 123.456.
+func FormatDec(value *big.Rat) string {
+	text := value.FloatString(18)
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+	return text
+}
+
+// DecString trims an SDK decimal string such as "0.100000000000000000" to
+// "0.1", returning it unchanged if it doesn't parse.
+func DecString(s string) string {
+	value, err := DecAmount(s)
+	if err != nil {
+		return s
+	}
+	return FormatDec(value)
+}
+
+// decPrecision is the number of fractional digits in an SDK LegacyDec.
+const decPrecision = 18
+
+// MintDec reads a decimal from `query mint inflation` or
+// `query mint annual-provisions`. Older versions print the bare decimal,
+// newer ones a {key: value} object, and SDK 0.50 base64-encodes the value
+// as the LegacyDec's integer form, scaled by 10^18.
+func MintDec(output, key string) (*big.Rat, error) {
+	text := strings.TrimSpace(output)
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &responseData); err == nil {
+		text = String(responseData, key)
+	} else {
+		var quoted string
+		if json.Unmarshal([]byte(text), &quoted) == nil {
+			text = quoted
+		}
+	}
+	if text == "" {
+		return nil, fmt.Errorf("%s not found in output", key)
+	}
+
+	if value, err := DecAmount(text); err == nil {
+		return value, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", key, text)
+	}
+	if strings.Contains(string(decoded), ".") {
+		return DecAmount(string(decoded))
+	}
+	scaled, err := Amount(string(decoded))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", key, text)
+	}
+	return new(big.Rat).SetFrac(scaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(decPrecision), nil)), nil
+}
@@ -0,0 +1,81 @@
+package parse
+
+import "testing"
+
+func TestMintDec(t *testing.T) {
+	for _, tc := range []struct {
+		name, output, key, want string
+	}{
+		{"json", `{"inflation":"0.130000000000000000"}`, "inflation", "0.13"},
+		{"bare", "0.130000000000000000\n", "inflation", "0.13"},
+		{"quoted", `"0.130000000000000000"`, "inflation", "0.13"},
+		{"base64 scaled", `{"inflation":"MTMwMDAwMDAwMDAwMDAwMDAw"}`, "inflation", "0.13"},
+		{"base64 decimal", `{"annual_provisions":"MC4xMzAwMDAwMDAwMDAwMDAwMDA="}`, "annual_provisions", "0.13"},
+		{"provisions", `{"annual_provisions":"12345678.500000000000000000"}`, "annual_provisions", "12345678.5"},
+	} {
+		value, err := MintDec(tc.output, tc.key)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if got := FormatDec(value); got != tc.want {
+			t.Errorf("%s: got %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestMintDecRejectsMissingOrInvalid(t *testing.T) {
+	for _, output := range []string{`{"other":"1"}`, `{"inflation":"not a number!"}`, ""} {
+		if value, err := MintDec(output, "inflation"); err == nil {
+			t.Errorf("MintDec(%q) = %v, want an error", output, value)
+		}
+	}
+}
+
+func TestSplitCoin(t *testing.T) {
+	for _, tc := range []struct {
+		coin, amount, denom string
+		ok                  bool
+	}{
+		{"100token", "100", "token", true},
+		{" 5ibc/ABC123 ", "5", "ibc/ABC123", true},
+		{"0stake", "0", "stake", true},
+		{"token", "", "", false},
+		{"100", "", "", false},
+		{"10 token", "", "", false},
+		{"-5token", "", "", false},
+		{"1.5token", "", "", false},
+		{"5t", "", "", false},
+	} {
+		amount, denom, ok := SplitCoin(tc.coin)
+		if amount != tc.amount || denom != tc.denom || ok != tc.ok {
+			t.Errorf("SplitCoin(%q) = %q, %q, %v, want %q, %q, %v", tc.coin, amount, denom, ok, tc.amount, tc.denom, tc.ok)
+		}
+	}
+}
+
+func TestAmount(t *testing.T) {
+	for s, want := range map[string]string{"0": "0", " 42 ": "42", "123456789012345678901234567890": "123456789012345678901234567890"} {
+		value, err := Amount(s)
+		if err != nil || value.String() != want {
+			t.Errorf("Amount(%q) = %v, %v, want %s", s, value, err, want)
+		}
+	}
+	for _, s := range []string{"", "-1", "1.5", "abc", "1e3"} {
+		if value, err := Amount(s); err == nil {
+			t.Errorf("Amount(%q) = %v, want an error", s, value)
+		}
+	}
+}
+
+func TestCoinAmount(t *testing.T) {
+	value, denom, err := CoinAmount("99999999999999999999token")
+	if err != nil || value.String() != "99999999999999999999" || denom != "token" {
+		t.Errorf("CoinAmount = %v, %q, %v, want 99999999999999999999 token", value, denom, err)
+	}
+	for _, coin := range []string{"", "token", "100", "-5token", "1.5token"} {
+		if value, denom, err := CoinAmount(coin); err == nil {
+			t.Errorf("CoinAmount(%q) = %v, %q, want an error", coin, value, denom)
+		}
+	}
+}
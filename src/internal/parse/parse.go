@@ -0,0 +1,321 @@
+// Package parse holds the pure parsing and formatting helpers for swechaind
+// JSON output. Nothing here runs commands, so it can be exercised without a
+// swechaind binary.
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Key is an entry of `keys list` output.
+type Key struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// Auction is an issuemarket auction as listed by `query issuemarket list-auction`.
+type Auction struct {
+	ID          int    `json:"id,string"`
+	Issue       string `json:"issue"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Winner      string `json:"winner"`
+	Creator     string `json:"creator"`
+}
+
+// Bid is an issuemarket bid as listed by `query issuemarket list-bid`.
+type Bid struct {
+	AuctionID   int    `json:"auctionId,string"`
+	Amount      string `json:"amount"`
+	Description string `json:"description"`
+	Creator     string `json:"creator"`
+	Bidder      string `json:"bidder"`
+}
+
+// Balance is a single coin, used for bank balances and fee amounts alike.
+type Balance struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// MaxRawOutputBytes caps how much unparseable command output is quoted back in errors.
+const MaxRawOutputBytes = 512
+
+// OutputError reports a JSON parse failure together with the start of the
+// raw output, which usually explains it (a warning banner, a usage message, ...).
+func OutputError(what string, err error, output string) error {
+	raw := strings.TrimSpace(output)
+	if len(raw) > MaxRawOutputBytes {
+		raw = raw[:MaxRawOutputBytes] + "..."
+	}
+	return fmt.Errorf("failed to parse %s: %w; raw output: %q", what, err, raw)
+}
+
+// String returns the string form of m[key], or "" when the key is missing or nil.
+func String(m map[string]interface{}, key string) string {
+	v, ok := m[key]
+	if !ok || v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// Bool accepts a JSON bool or its string form; amino JSON output
+// renders some bools as strings.
+func Bool(v interface{}) bool {
+	switch value := v.(type) {
+	case bool:
+		return value
+	case string:
+		b, _ := strconv.ParseBool(value)
+		return b
+	}
+	return false
+}
+
+// NormalizeStatus returns the canonical lowercase, trimmed form of an auction status.
+func NormalizeStatus(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// Auctions converts paginated list-auction entries.
+func Auctions(rawData []map[string]interface{}) []Auction {
+	var auctions []Auction
+	for _, raw := range rawData {
+		idStr := String(raw, "id")
+		id, _ := strconv.Atoi(idStr)
+
+		auction := Auction{
+			ID:          id,
+			Issue:       String(raw, "issue"),
+			Description: String(raw, "description"),
+			Status:      NormalizeStatus(String(raw, "status")),
+			Winner:      String(raw, "winner"),
+			Creator:     String(raw, "creator"),
+		}
+		auctions = append(auctions, auction)
+	}
+	return auctions
+}
+
+// Bids converts paginated list-bid entries.
+func Bids(rawData []map[string]interface{}) []Bid {
+	var bids []Bid
+	for _, raw := range rawData {
+		auctionIDStr := String(raw, "auctionId")
+		auctionID, _ := strconv.Atoi(auctionIDStr)
+
+		bid := Bid{
+			AuctionID:   auctionID,
+			Amount:      String(raw, "amount"),
+			Description: String(raw, "description"),
+			Creator:     String(raw, "creator"),
+			Bidder:      String(raw, "bidder"),
+		}
+		bids = append(bids, bid)
+	}
+	return bids
+}
+
+// Balances reads the balances array shared by the bank balance queries.
+func Balances(output string) ([]Balance, error) {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return nil, OutputError("balance data", err, output)
+	}
+
+	rawBalances, ok := responseData["balances"].([]interface{})
+	if !ok {
+		return []Balance{}, nil // No balances found
+	}
+
+	var balances []Balance
+	for _, raw := range rawBalances {
+		rawMap, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		amount := String(rawMap, "amount")
+		denom := String(rawMap, "denom")
+
+		balances = append(balances, Balance{
+			Amount: amount,
+			Denom:  denom,
+		})
+	}
+
+	return balances, nil
+}
+
+// Keys accepts the shapes `keys list` produces across versions: a bare
+// array, null or empty output for an empty keyring, or an object wrapping the
+// array under "keys".
+func Keys(output string) ([]Key, error) {
+	output = strings.TrimSpace(output)
+	if output == "" || output == "null" {
+		return []Key{}, nil
+	}
+
+	var keys []Key
+	if strings.HasPrefix(output, "[") {
+		if err := json.Unmarshal([]byte(output), &keys); err != nil {
+			return nil, OutputError("keys", err, output)
+		}
+		return keys, nil
+	}
+
+	var wrapped struct {
+		Keys []Key `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(output), &wrapped); err != nil {
+		return nil, OutputError("keys", err, output)
+	}
+	if wrapped.Keys == nil {
+		return []Key{}, nil
+	}
+	return wrapped.Keys, nil
+}
+
+// TxSearch extracts the tx responses from a `query txs` response and
+// returns the total page count reported by the node.
+func TxSearch(output string) ([]map[string]interface{}, int, error) {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return nil, 0, OutputError("tx search data", err, output)
+	}
+
+	pageTotal, _ := strconv.Atoi(String(responseData, "page_total"))
+
+	rawTxs, ok := responseData["txs"].([]interface{})
+	if !ok {
+		return []map[string]interface{}{}, pageTotal, nil
+	}
+
+	var txs []map[string]interface{}
+	for _, raw := range rawTxs {
+		if rawMap, ok := raw.(map[string]interface{}); ok {
+			txs = append(txs, rawMap)
+		}
+	}
+
+	return txs, pageTotal, nil
+}
+
+// ModuleParams reads a module `params` query, returning an empty map when
+// the module defines no parameters.
+func ModuleParams(output string) (map[string]interface{}, error) {
+	if strings.TrimSpace(output) == "" {
+		return map[string]interface{}{}, nil
+	}
+
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return nil, OutputError("params data", err, output)
+	}
+
+	if nested, ok := responseData["params"].(map[string]interface{}); ok {
+		return nested, nil
+	}
+	if _, ok := responseData["params"]; ok {
+		return map[string]interface{}{}, nil
+	}
+	return responseData, nil
+}
+
+// NodeStatus extracts the latest block height and time from `swechaind status`,
+// accepting both the sync_info and legacy SyncInfo keys.
+func NodeStatus(output string) (int64, time.Time, error) {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return 0, time.Time{}, OutputError("status data", err, output)
+	}
+
+	syncInfo, ok := responseData["sync_info"].(map[string]interface{})
+	if !ok {
+		syncInfo, ok = responseData["SyncInfo"].(map[string]interface{})
+	}
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("sync_info not found in status data")
+	}
+
+	height, err := strconv.ParseInt(String(syncInfo, "latest_block_height"), 10, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("invalid latest_block_height: %w", err)
+	}
+
+	blockTime, err := time.Parse(time.RFC3339Nano, String(syncInfo, "latest_block_time"))
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("invalid latest_block_time: %w", err)
+	}
+
+	return height, blockTime, nil
+}
+
+// BlockGas is the tx count and gas totals of one block.
+type BlockGas struct {
+	Height    int64 `json:"height"`
+	Txs       int   `json:"txs"`
+	GasWanted int64 `json:"gasWanted"`
+	GasUsed   int64 `json:"gasUsed"`
+}
+
+// BlockResults sums the gas of every tx in a `query block-results` response.
+// The txs_results key is absent for empty blocks.
+func BlockResults(output string) (BlockGas, error) {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return BlockGas{}, OutputError("block results", err, output)
+	}
+
+	height, err := strconv.ParseInt(String(responseData, "height"), 10, 64)
+	if err != nil {
+		return BlockGas{}, fmt.Errorf("invalid block results height: %w", err)
+	}
+
+	block := BlockGas{Height: height}
+	txResults, _ := responseData["txs_results"].([]interface{})
+	for _, item := range txResults {
+		txResult, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		wanted, _ := strconv.ParseInt(String(txResult, "gas_wanted"), 10, 64)
+		used, _ := strconv.ParseInt(String(txResult, "gas_used"), 10, 64)
+		block.Txs++
+		block.GasWanted += wanted
+		block.GasUsed += used
+	}
+	return block, nil
+}
+
+// ConsensusMaxGas reads block.max_gas from `query consensus params`. It
+// returns 0 when the chain sets no block gas limit (max_gas of -1).
+func ConsensusMaxGas(output string) (int64, error) {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return 0, OutputError("consensus params", err, output)
+	}
+	if params, ok := responseData["params"].(map[string]interface{}); ok {
+		responseData = params
+	}
+	block, ok := responseData["block"].(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("block params not found in consensus params")
+	}
+	maxGas, err := strconv.ParseInt(String(block, "max_gas"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid max_gas: %w", err)
+	}
+	if maxGas < 0 {
+		return 0, nil
+	}
+	return maxGas, nil
+}
@@ -0,0 +1,127 @@
+package parse
+
+import "testing"
+
+func TestBlockResultsSumsTxGas(t *testing.T) {
+	block, err := BlockResults(`{"height":"42","txs_results":[
+		{"code":0,"gas_wanted":"200000","gas_used":"150000"},
+		{"code":5,"gas_wanted":"100000","gas_used":"40000"}
+	]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (BlockGas{Height: 42, Txs: 2, GasWanted: 300000, GasUsed: 190000}); block != want {
+		t.Errorf("block = %+v, want %+v", block, want)
+	}
+
+	empty, err := BlockResults(`{"height":"43","txs_results":null}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (BlockGas{Height: 43}); empty != want {
+		t.Errorf("empty block = %+v, want %+v", empty, want)
+	}
+
+	if _, err := BlockResults(`{"txs_results":[]}`); err == nil {
+		t.Error("expected an error without a height")
+	}
+}
+
+func TestConsensusMaxGas(t *testing.T) {
+	for _, tc := range []struct {
+		name, output string
+		want         int64
+	}{
+		{"nested", `{"params":{"block":{"max_bytes":"22020096","max_gas":"10000000"}}}`, 10000000},
+		{"flat", `{"block":{"max_gas":"500"}}`, 500},
+		{"unlimited", `{"params":{"block":{"max_gas":"-1"}}}`, 0},
+	} {
+		got, err := ConsensusMaxGas(tc.output)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		} else if got != tc.want {
+			t.Errorf("%s: got %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestStringNilIsEmpty(t *testing.T) {
+	m := map[string]interface{}{"winner": nil, "issue": "#1", "empty": ""}
+	for key, want := range map[string]string{"winner": "", "missing": "", "issue": "#1", "empty": ""} {
+		if got := String(m, key); got != want {
+			t.Errorf("String(%q) = %q, want %q", key, got, want)
+		}
+	}
+	if got := String(nil, "winner"); got != "" {
+		t.Errorf("String(nil map) = %q, want empty", got)
+	}
+}
+
+func TestStringFormatsNonStrings(t *testing.T) {
+	m := map[string]interface{}{"id": float64(7), "jailed": true}
+	if got := String(m, "id"); got != "7" {
+		t.Errorf("String(id) = %q, want 7", got)
+	}
+	if got := String(m, "jailed"); got != "true" {
+		t.Errorf("String(jailed) = %q, want true", got)
+	}
+}
+
+func TestAuctions(t *testing.T) {
+	auctions := Auctions([]map[string]interface{}{
+		{"id": "3", "issue": "#12", "description": "fix it", "status": " OPEN ", "winner": nil, "creator": "cosmos1a"},
+		{"id": float64(4), "status": "Closed", "winner": "cosmos1b"},
+		{"id": "not a number"},
+	})
+	want := []Auction{
+		{ID: 3, Issue: "#12", Description: "fix it", Status: "open", Creator: "cosmos1a"},
+		{ID: 4, Status: "closed", Winner: "cosmos1b"},
+		{},
+	}
+	if len(auctions) != len(want) {
+		t.Fatalf("auctions = %+v, want %+v", auctions, want)
+	}
+	for i := range want {
+		if auctions[i] != want[i] {
+			t.Errorf("auctions[%d] = %+v, want %+v", i, auctions[i], want[i])
+		}
+	}
+}
+
+func TestBids(t *testing.T) {
+	bids := Bids([]map[string]interface{}{
+		{"auctionId": "3", "amount": "50token", "description": "quick fix", "creator": "cosmos1a", "bidder": "cosmos1b"},
+		{"auctionId": float64(4), "amount": "7stake", "description": nil},
+	})
+	want := []Bid{
+		{AuctionID: 3, Amount: "50token", Description: "quick fix", Creator: "cosmos1a", Bidder: "cosmos1b"},
+		{AuctionID: 4, Amount: "7stake"},
+	}
+	if len(bids) != len(want) {
+		t.Fatalf("bids = %+v, want %+v", bids, want)
+	}
+	for i := range want {
+		if bids[i] != want[i] {
+			t.Errorf("bids[%d] = %+v, want %+v", i, bids[i], want[i])
+		}
+	}
+}
+
+func TestTxSearch(t *testing.T) {
+	txs, pageTotal, err := TxSearch(`{"page_total":"3","txs":[{"txhash":"AAA"},"not a tx",{"txhash":"BBB"}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pageTotal != 3 || len(txs) != 2 || txs[0]["txhash"] != "AAA" || txs[1]["txhash"] != "BBB" {
+		t.Errorf("TxSearch = %v, %d, want txs AAA and BBB over 3 pages", txs, pageTotal)
+	}
+
+	txs, pageTotal, err = TxSearch(`{"page_total":"0","txs":null}`)
+	if err != nil || txs == nil || len(txs) != 0 || pageTotal != 0 {
+		t.Errorf("TxSearch without txs = %v, %d, %v, want an empty list", txs, pageTotal, err)
+	}
+
+	if _, _, err := TxSearch("not json"); err == nil {
+		t.Error("expected an error for unparseable output")
+	}
+}
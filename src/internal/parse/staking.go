@@ -0,0 +1,122 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Delegation is an entry of `query staking delegations`, with the balance as a coin string.
+type Delegation struct {
+	Validator string `json:"validator"`
+	Shares    string `json:"shares"`
+	Balance   string `json:"balance"`
+}
+
+// ValidatorInfo is a validator as shown by `query staking validator(s)`.
+type ValidatorInfo struct {
+	OperatorAddress         string `json:"operatorAddress"`
+	Moniker                 string `json:"moniker"`
+	Status                  string `json:"status"`
+	Jailed                  bool   `json:"jailed"`
+	Tokens                  string `json:"tokens"`
+	CommissionRate          string `json:"commissionRate"`
+	CommissionMaxRate       string `json:"commissionMaxRate"`
+	CommissionMaxChangeRate string `json:"commissionMaxChangeRate"`
+	MinSelfDelegation       string `json:"minSelfDelegation"`
+	ConsensusPubKey         string `json:"-"`
+}
+
+// Delegations converts `query staking delegations` entries.
+func Delegations(rawData []map[string]interface{}) []Delegation {
+	var delegations []Delegation
+	for _, raw := range rawData {
+		delegation, _ := raw["delegation"].(map[string]interface{})
+		balance, _ := raw["balance"].(map[string]interface{})
+
+		var balanceStr string
+		if balance != nil {
+			balanceStr = String(balance, "amount") + String(balance, "denom")
+		}
+
+		delegations = append(delegations, Delegation{
+			Validator: String(delegation, "validator_address"),
+			Shares:    String(delegation, "shares"),
+			Balance:   balanceStr,
+		})
+	}
+	return delegations
+}
+
+// Validator reads a `query staking validator` response, which is either
+// flat or nested under "validator" depending on the SDK version.
+func Validator(output string) (ValidatorInfo, error) {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return ValidatorInfo{}, OutputError("validator data", err, output)
+	}
+
+	validator := responseData
+	if nested, ok := responseData["validator"].(map[string]interface{}); ok {
+		validator = nested
+	}
+	return ValidatorFromMap(validator), nil
+}
+
+// ValidatorFromMap converts one validator object, as returned by both
+// `query staking validator` and the `query staking validators` list.
+func ValidatorFromMap(validator map[string]interface{}) ValidatorInfo {
+	description, _ := validator["description"].(map[string]interface{})
+	jailed, _ := validator["jailed"].(bool)
+	commission, _ := validator["commission"].(map[string]interface{})
+	rates, _ := commission["commission_rates"].(map[string]interface{})
+
+	var consensusPubKey string
+	if pubKey, ok := validator["consensus_pubkey"]; ok && pubKey != nil {
+		encoded, _ := json.Marshal(pubKey)
+		consensusPubKey = string(encoded)
+	}
+
+	return ValidatorInfo{
+		OperatorAddress:         String(validator, "operator_address"),
+		Moniker:                 String(description, "moniker"),
+		Status:                  String(validator, "status"),
+		Jailed:                  jailed,
+		Tokens:                  String(validator, "tokens"),
+		CommissionRate:          DecString(String(rates, "rate")),
+		CommissionMaxRate:       DecString(String(rates, "max_rate")),
+		CommissionMaxChangeRate: DecString(String(rates, "max_change_rate")),
+		MinSelfDelegation:       String(validator, "min_self_delegation"),
+		ConsensusPubKey:         consensusPubKey,
+	}
+}
+
+// ValidatorUptime computes the fraction of the slashing window a validator
+// signed from `query slashing signing-info` and `query slashing params` output.
+func ValidatorUptime(signingInfoOutput, slashingParamsOutput string) (string, error) {
+	var signingInfo map[string]interface{}
+	if err := json.Unmarshal([]byte(signingInfoOutput), &signingInfo); err != nil {
+		return "", OutputError("signing info", err, signingInfoOutput)
+	}
+	if nested, ok := signingInfo["val_signing_info"].(map[string]interface{}); ok {
+		signingInfo = nested
+	}
+
+	slashingParams, err := ModuleParams(slashingParamsOutput)
+	if err != nil {
+		return "", err
+	}
+
+	missed, err := strconv.ParseInt(String(signingInfo, "missed_blocks_counter"), 10, 64)
+	if err != nil {
+		missed = 0
+	}
+	window, err := strconv.ParseInt(String(slashingParams, "signed_blocks_window"), 10, 64)
+	if err != nil || window <= 0 {
+		return "", fmt.Errorf("slashing params have no signed_blocks_window")
+	}
+
+	uptime := new(big.Rat).SetFrac64(window-missed, window)
+	return uptime.FloatString(4), nil
+}
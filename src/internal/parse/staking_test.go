@@ -0,0 +1,46 @@
+package parse
+
+import "testing"
+
+func TestDelegations(t *testing.T) {
+	delegations := Delegations([]map[string]interface{}{{
+		"delegation": map[string]interface{}{"validator_address": "cosmosvaloper1a", "shares": "100.000000000000000000"},
+		"balance":    map[string]interface{}{"denom": "stake", "amount": "100"},
+	}})
+	if len(delegations) != 1 {
+		t.Fatalf("got %d delegations, want 1", len(delegations))
+	}
+	if got := delegations[0]; got.Validator != "cosmosvaloper1a" || got.Balance != "100stake" {
+		t.Errorf("delegation = %+v", got)
+	}
+}
+
+func TestValidatorFlatOrNested(t *testing.T) {
+	validator := `{"operator_address":"cosmosvaloper1a","description":{"moniker":"node0"},"jailed":true,` +
+		`"commission":{"commission_rates":{"rate":"0.100000000000000000"}}}`
+	for _, output := range []string{validator, `{"validator":` + validator + `}`} {
+		info, err := Validator(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.OperatorAddress != "cosmosvaloper1a" || info.Moniker != "node0" || !info.Jailed || info.CommissionRate != "0.1" {
+			t.Errorf("Validator(%s) = %+v", output, info)
+		}
+	}
+	if _, err := Validator("not json"); err == nil {
+		t.Error("expected an error for unparseable output")
+	}
+}
+
+func TestValidatorUptime(t *testing.T) {
+	uptime, err := ValidatorUptime(`{"val_signing_info":{"missed_blocks_counter":"25"}}`, `{"params":{"signed_blocks_window":"100"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uptime != "0.7500" {
+		t.Errorf("uptime = %s, want 0.7500", uptime)
+	}
+	if _, err := ValidatorUptime(`{}`, `{"params":{}}`); err == nil {
+		t.Error("expected an error without a signed_blocks_window")
+	}
+}
@@ -0,0 +1,136 @@
+package parse
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FeegrantAllowance is a fee allowance from `query feegrant grants-by-grantee`.
+type FeegrantAllowance struct {
+	Granter    string    `json:"granter"`
+	Grantee    string    `json:"grantee"`
+	Type       string    `json:"type"`
+	SpendLimit []Balance `json:"spendLimit,omitempty"`
+	Expiration string    `json:"expiration,omitempty"`
+}
+
+// AccountSequence reads the sequence from `query auth account` output,
+// which is either flat or nested under account.value depending on the SDK version.
+func AccountSequence(output string) (uint64, error) {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return 0, OutputError("account data", err, output)
+	}
+
+	account := responseData
+	if nested, ok := responseData["account"].(map[string]interface{}); ok {
+		account = nested
+		if value, ok := nested["value"].(map[string]interface{}); ok {
+			account = value
+		}
+	}
+
+	sequence := String(account, "sequence")
+	if sequence == "" {
+		// A fresh account omits the sequence field entirely.
+		return 0, nil
+	}
+
+	return strconv.ParseUint(sequence, 10, 64)
+}
+
+// TxCode returns the ABCI code from a broadcast tx response, or 0 when absent.
+func TxCode(output string) int {
+	var txData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &txData); err != nil {
+		return 0
+	}
+	code, _ := strconv.Atoi(String(txData, "code"))
+	return code
+}
+
+// TxFee returns the auth_info.fee object of a tx, or nil.
+func TxFee(tx map[string]interface{}) map[string]interface{} {
+	authInfo, ok := tx["auth_info"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	fee, _ := authInfo["fee"].(map[string]interface{})
+	return fee
+}
+
+// FeeCoins returns the coins in a fee's amount list.
+func FeeCoins(fee map[string]interface{}) []Balance {
+	var coins []Balance
+	amounts, _ := fee["amount"].([]interface{})
+	for _, raw := range amounts {
+		if coin, ok := raw.(map[string]interface{}); ok {
+			coins = append(coins, Balance{Denom: String(coin, "denom"), Amount: String(coin, "amount")})
+		}
+	}
+	return coins
+}
+
+// SumTxFees totals the fees paid across tx search results, per denom.
+// Amounts that don't parse are skipped.
+func SumTxFees(txResponses []map[string]interface{}) map[string]string {
+	totals := make(map[string]*big.Int)
+	for _, txResponse := range txResponses {
+		tx, _ := txResponse["tx"].(map[string]interface{})
+		for _, coin := range FeeCoins(TxFee(tx)) {
+			value, err := Amount(coin.Amount)
+			if err != nil {
+				continue
+			}
+			if totals[coin.Denom] == nil {
+				totals[coin.Denom] = new(big.Int)
+			}
+			totals[coin.Denom].Add(totals[coin.Denom], value)
+		}
+	}
+
+	totalsByDenom := make(map[string]string)
+	for denom, total := range totals {
+		totalsByDenom[denom] = total.String()
+	}
+	return totalsByDenom
+}
+
+// FeegrantAllowances converts `query feegrant grants-by-grantee` entries,
+// dropping allowances that expired before now. Periodic and allowed-msg
+// allowances are unwrapped to the basic allowance holding the spend limit.
+func FeegrantAllowances(rawData []map[string]interface{}, now time.Time) []FeegrantAllowance {
+	var allowances []FeegrantAllowance
+	for _, raw := range rawData {
+		allowance, _ := raw["allowance"].(map[string]interface{})
+		entry := FeegrantAllowance{
+			Granter: String(raw, "granter"),
+			Grantee: String(raw, "grantee"),
+			Type:    strings.TrimPrefix(String(allowance, "@type"), "/cosmos.feegrant.v1beta1."),
+		}
+
+		basic := allowance
+		for depth := 0; depth < 3 && basic != nil; depth++ {
+			if inner, ok := basic["allowance"].(map[string]interface{}); ok {
+				basic = inner
+			} else if inner, ok := basic["basic"].(map[string]interface{}); ok {
+				basic = inner
+			} else {
+				break
+			}
+		}
+		entry.SpendLimit = FeeCoins(map[string]interface{}{"amount": basic["spend_limit"]})
+		entry.Expiration = String(basic, "expiration")
+
+		if entry.Expiration != "" {
+			if expires, err := time.Parse(time.RFC3339Nano, entry.Expiration); err == nil && !expires.After(now) {
+				continue
+			}
+		}
+		allowances = append(allowances, entry)
+	}
+	return allowances
+}
@@ -0,0 +1,68 @@
+package parse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccountSequence(t *testing.T) {
+	for _, tc := range []struct {
+		name, output string
+		want         uint64
+	}{
+		{"flat", `{"sequence":"7"}`, 7},
+		{"nested", `{"account":{"value":{"sequence":"7"}}}`, 7},
+		{"fresh account", `{"account":{"value":{}}}`, 0},
+	} {
+		got, err := AccountSequence(tc.output)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		} else if got != tc.want {
+			t.Errorf("%s: got %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestTxCode(t *testing.T) {
+	if code := TxCode(`{"code":5,"txhash":"AB"}`); code != 5 {
+		t.Errorf("code = %d, want 5", code)
+	}
+	if code := TxCode("not json"); code != 0 {
+		t.Errorf("code = %d for unparseable output, want 0", code)
+	}
+}
+
+func TestSumTxFees(t *testing.T) {
+	tx := func(amount string) map[string]interface{} {
+		return map[string]interface{}{"tx": map[string]interface{}{"auth_info": map[string]interface{}{"fee": map[string]interface{}{
+			"amount": []interface{}{map[string]interface{}{"denom": "stake", "amount": amount}},
+		}}}}
+	}
+	totals := SumTxFees([]map[string]interface{}{tx("9223372036854775807"), tx("1"), tx("bad"), {}})
+	if len(totals) != 1 || totals["stake"] != "9223372036854775808" {
+		t.Errorf("totals = %v", totals)
+	}
+}
+
+func TestFeegrantAllowancesUnwrapsAndDropsExpired(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	allowances := FeegrantAllowances([]map[string]interface{}{
+		{"granter": "a", "grantee": "b", "allowance": map[string]interface{}{
+			"@type": "/cosmos.feegrant.v1beta1.AllowedMsgAllowance",
+			"allowance": map[string]interface{}{
+				"@type": "/cosmos.feegrant.v1beta1.PeriodicAllowance",
+				"basic": map[string]interface{}{"spend_limit": []interface{}{map[string]interface{}{"denom": "stake", "amount": "10"}}},
+			},
+		}},
+		{"granter": "c", "grantee": "b", "allowance": map[string]interface{}{
+			"@type": "/cosmos.feegrant.v1beta1.BasicAllowance", "expiration": "2025-12-31T00:00:00Z",
+		}},
+	}, now)
+	if len(allowances) != 1 {
+		t.Fatalf("got %d allowances, want the unexpired one: %+v", len(allowances), allowances)
+	}
+	got := allowances[0]
+	if got.Granter != "a" || got.Type != "AllowedMsgAllowance" || len(got.SpendLimit) != 1 || got.SpendLimit[0].Amount != "10" {
+		t.Errorf("allowance = %+v", got)
+	}
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testMnemonic = "abandon ability able about above absent absorb abstract absurd abuse access accident"
+
+// captureLog collects log output for the rest of the test.
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(previous) })
+	return &buf
+}
+
+func TestCreateKeyDoesNotLogMnemonic(t *testing.T) {
+	logs := captureLog(t)
+	runner := newFakeRunner().on("keys add", `{"name":"alice","address":"`+testAlice+`","mnemonic":"`+testMnemonic+`"}`)
+	ctx := withCommandRunner(context.Background(), runner)
+
+	address, err := createKey(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != testAlice {
+		t.Errorf("address = %s, want %s", address, testAlice)
+	}
+	if strings.Contains(logs.String(), "abandon") {
+		t.Errorf("mnemonic written to the log:\n%s", logs.String())
+	}
+}
+
+func TestCreateKeyIsNotRetried(t *testing.T) {
+	captureLog(t)
+	runner := newFakeRunner().fail("keys add", "Error: post failed: connection refused")
+	ctx := withCommandRunner(context.Background(), runner)
+
+	if _, err := createKey(ctx, "alice"); err == nil {
+		t.Fatal("expected createKey to fail")
+	}
+	if calls := len(runner.callsTo("keys add")); calls != 1 {
+		t.Errorf("ran %d times, want 1", calls)
+	}
+}
+
+func TestCreateKeyParseErrorOmitsOutput(t *testing.T) {
+	captureLog(t)
+	runner := newFakeRunner().on("keys add", "- name: alice\n  mnemonic: "+testMnemonic)
+	ctx := withCommandRunner(context.Background(), runner)
+
+	_, err := createKey(ctx, "alice")
+	if err == nil {
+		t.Fatal("expected a parse error")
+	}
+	if strings.Contains(err.Error(), "abandon") {
+		t.Errorf("parse error quotes the mnemonic: %v", err)
+	}
+}
+
+func TestKeyringPassphraseIsPipedViaStdin(t *testing.T) {
+	restoreConfig(t)
+	flag.Set("keyring-backend", "file")
+	const passphrase = "correct horse battery staple"
+	t.Setenv(keyringPassphraseEnv, passphrase)
+	logs := captureLog(t)
+
+	runner := newFakeRunner().
+		on("keys list", `[{"name":"alice","address":"`+testAlice+`"}]`).
+		on("tx bank send", `{"code":0,"txhash":"A"}`)
+	if text, isError := callTool(t, runner, getKeysHandler, GetKeysParams{Operation: "list"}); isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	if text, isError := callTool(t, runner, payHandler, PayParams{From: testAlice, To: testBob, Amount: "1token"}); isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+
+	for i, call := range runner.calls {
+		if strings.Contains(strings.Join(call, " "), passphrase) {
+			t.Errorf("passphrase passed as an argument: %v", call)
+		}
+		if call[0] == "keys" || call[0] == "tx" {
+			if want := passphrase + "\n" + passphrase + "\n"; runner.stdins[i] != want {
+				t.Errorf("stdin for %v = %q, want the passphrase", call, runner.stdins[i])
+			}
+		}
+	}
+	if strings.Contains(logs.String(), passphrase) {
+		t.Errorf("passphrase written to the log:\n%s", logs.String())
+	}
+}
+
+func TestKeyringPassphraseFromFile(t *testing.T) {
+	restoreConfig(t)
+	t.Setenv(keyringPassphraseEnv, "")
+	path := filepath.Join(t.TempDir(), "passphrase")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	flag.Set("keyring-backend", "os")
+	flag.Set("keyring-passphrase-file", path)
+
+	input, err := keyringInput(context.Background(), []string{"keys", "list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input != "from-file\nfrom-file\n" {
+		t.Errorf("input = %q, want the file's passphrase twice", input)
+	}
+	if input, _ := keyringInput(context.Background(), []string{"query", "bank", "balances", testAlice}); input != "" {
+		t.Errorf("query input = %q, want nothing piped to queries", input)
+	}
+
+	flag.Set("keyring-backend", "test")
+	if input, _ := keyringInput(context.Background(), []string{"keys", "list"}); input != "" {
+		t.Errorf("test backend input = %q, want no passphrase", input)
+	}
+}
+
+const testMnemonic24 = testMnemonic + " acid acoustic acquire across act action actor actress actual adapt add addict"
+
+func TestGenerateMnemonicReturnsUnloggedMnemonic(t *testing.T) {
+	for name, response := range map[string]fakeResponse{
+		"stdout": {stdout: testMnemonic24 + "\n"},
+		"stderr": {stderr: "**Important** write this mnemonic phrase in a safe place.\n\n" + testMnemonic24 + "\n"},
+	} {
+		logs := captureLog(t)
+		runner := newFakeRunner()
+		runner.responses["keys mnemonic"] = response
+
+		text, isError := callTool(t, runner, generateMnemonicHandler, GenerateMnemonicParams{})
+		if isError {
+			t.Fatalf("%s: unexpected error result: %s", name, text)
+		}
+		var result struct {
+			Details struct {
+				Mnemonic  string `json:"mnemonic"`
+				Words     int    `json:"words"`
+				Sensitive bool   `json:"sensitive"`
+			} `json:"details"`
+		}
+		if err := json.Unmarshal([]byte(text), &result); err != nil {
+			t.Fatalf("%s: response is not JSON: %v\n%s", name, err, text)
+		}
+		if words := strings.Fields(result.Details.Mnemonic); len(words) != 24 || result.Details.Words != 24 || !result.Details.Sensitive {
+			t.Errorf("%s: details = %+v, want a sensitive 24-word mnemonic", name, result.Details)
+		}
+		if strings.Contains(logs.String(), "acoustic") {
+			t.Errorf("%s: mnemonic written to the log:\n%s", name, logs.String())
+		}
+	}
+}
+
+func TestGenerateMnemonicRejectsOtherOutput(t *testing.T) {
+	captureLog(t)
+	runner := newFakeRunner().on("keys mnemonic", "Error: something else entirely")
+	if text, _ := callTool(t, runner, generateMnemonicHandler, GenerateMnemonicParams{}); !strings.HasPrefix(text, "Error generating mnemonic") {
+		t.Errorf("result = %s, want an error", text)
+	}
+}
+
+func TestImportKeyPipesMnemonicViaStdin(t *testing.T) {
+	logs := captureLog(t)
+	runner := newFakeRunner().
+		fail("keys show", "Error: bob.info: key not found").
+		on("keys add", `{"name":"bob","type":"local","address":"`+testBob+`","pubkey":""}`)
+
+	text, isError := callTool(t, runner, importKeyHandler, ImportKeyParams{KeyName: "bob", Mnemonic: "  " + strings.ReplaceAll(testMnemonic24, " acid ", "\nacid ")})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	if !strings.Contains(text, testBob) {
+		t.Errorf("result = %s, want the imported address", text)
+	}
+
+	for i, call := range runner.calls {
+		if call[0] != "keys" || call[1] != "add" {
+			continue
+		}
+		if strings.Contains(strings.Join(call, " "), "abandon") {
+			t.Errorf("mnemonic passed as an argument: %v", call)
+		}
+		if argValue(call, "add") != "bob" || !slices.Contains(call, "--recover") {
+			t.Errorf("keys add args = %v, want bob --recover", call)
+		}
+		if runner.stdins[i] != testMnemonic24+"\n" {
+			t.Errorf("stdin = %q, want the normalised mnemonic", runner.stdins[i])
+		}
+	}
+	if calls := runner.callsTo("keys add"); len(calls) != 1 {
+		t.Errorf("keys add calls = %v, want 1", calls)
+	}
+	if strings.Contains(logs.String(), "abandon") {
+		t.Errorf("mnemonic written to the log:\n%s", logs.String())
+	}
+}
+
+func TestImportKeyRefusesExistingName(t *testing.T) {
+	captureLog(t)
+	runner := newFakeRunner().on("keys show", `{"name":"alice","address":"`+testAlice+`"}`)
+
+	text, isError := callTool(t, runner, importKeyHandler, ImportKeyParams{KeyName: "alice", Mnemonic: testMnemonic})
+	if !isError || !hasFieldError(fieldErrors(t, text), "keyName", codeAlreadyExists) {
+		t.Errorf("result = %s, want an already_exists error", text)
+	}
+	if calls := runner.callsTo("keys add"); len(calls) != 0 {
+		t.Errorf("ran keys add for an existing key: %v", calls)
+	}
+}
+
+func TestFindKeysMatchesOnlyNamePrefix(t *testing.T) {
+	runner := newFakeRunner().on("keys list", `[
+		{"name":"validator","address":"`+testAlice+`"},
+		{"name":"alice-2","address":"`+testBob+`"},
+		{"name":"Alice","address":"`+testAlice+`"},
+		{"name":"alice","address":"`+testAlice+`"},
+		{"name":"malice","address":"`+testBob+`"},
+		{"name":"alice-10","address":"`+testBob+`"},
+		{"name":"al","address":"`+testBob+`"}
+	]`)
+
+	for _, tc := range []struct {
+		prefix string
+		want   []string
+	}{
+		{"alice", []string{"alice", "alice-10", "alice-2"}},
+		{" alice- ", []string{"alice-10", "alice-2"}},
+		{"al", []string{"al", "alice", "alice-10", "alice-2"}},
+		{"Alice", []string{"Alice"}},
+		{"bob", []string{}},
+	} {
+		text, isError := callTool(t, runner, findKeysHandler, FindKeysParams{Prefix: tc.prefix})
+		var response KeySummaryResponse
+		if err := json.Unmarshal([]byte(text), &response); err != nil || isError {
+			t.Fatalf("%q: response = %s", tc.prefix, text)
+		}
+		names := []string{}
+		for _, key := range response.Details.Keys {
+			names = append(names, key.Name)
+		}
+		if !slices.Equal(names, tc.want) {
+			t.Errorf("prefix %q matched %v, want %v", tc.prefix, names, tc.want)
+		}
+	}
+}
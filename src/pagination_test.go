@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withPaging sets -page-limit and -page-delay for the rest of the test.
+func withPaging(t *testing.T, limit int, delay time.Duration) {
+	t.Helper()
+	previousLimit, previousDelay := *pageLimit, *pageDelay
+	*pageLimit, *pageDelay = limit, delay
+	t.Cleanup(func() { *pageLimit, *pageDelay = previousLimit, previousDelay })
+}
+
+// pageOffset returns the --page-offset of a list query.
+func pageOffset(t *testing.T, args []string) int {
+	t.Helper()
+	for i, arg := range args {
+		if arg == "--page-offset" && i+1 < len(args) {
+			offset, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				t.Fatalf("bad --page-offset %q", args[i+1])
+			}
+			return offset
+		}
+	}
+	t.Fatalf("no --page-offset in %v", args)
+	return 0
+}
+
+// denomOwnersFixture answers denom-owners with the given number of token
+// holders, *pageLimit per page.
+func denomOwnersFixture(t *testing.T, holders int) func(int, []string) (string, error) {
+	return func(call int, args []string) (string, error) {
+		offset := pageOffset(t, args)
+		var entries []string
+		for i := offset; i < min(offset+*pageLimit, holders); i++ {
+			entries = append(entries, fmt.Sprintf(`{"address":"holder%d","balance":{"denom":"token","amount":"%d"}}`, i, i+1))
+		}
+		return `{"denom_owners":[` + strings.Join(entries, ",") + `]}`, nil
+	}
+}
+
+func TestFetchDenomOwnersCollectsEveryPage(t *testing.T) {
+	withPaging(t, 2, 0)
+	runner := newFakeRunner().onCall("query bank denom-owners", denomOwnersFixture(t, 5))
+	ctx := withCommandRunner(context.Background(), runner)
+
+	owners, err := fetchDenomOwners(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(owners) != 5 {
+		t.Fatalf("got %d owners, want 5", len(owners))
+	}
+	for i, owner := range owners {
+		if owner.Address != fmt.Sprintf("holder%d", i) {
+			t.Errorf("owners[%d] = %s, want holder%d", i, owner.Address, i)
+		}
+	}
+}
+
+func TestFetchPaginatedDataReportsTruncation(t *testing.T) {
+	withPaging(t, 2, 0)
+	runner := newFakeRunner().onCall("query bank denom-owners", denomOwnersFixture(t, maxPages*2+1))
+	ctx := withCommandRunner(context.Background(), runner)
+
+	if _, err := fetchDenomOwners(ctx); err == nil || !strings.Contains(err.Error(), "truncated") {
+		t.Fatalf("err = %v, want a truncation error", err)
+	}
+}
+
+func TestFetchPaginatedDataAcceptsExactlyMaxPages(t *testing.T) {
+	withPaging(t, 2, 0)
+	runner := newFakeRunner().onCall("query bank denom-owners", denomOwnersFixture(t, maxPages*2))
+	ctx := withCommandRunner(context.Background(), runner)
+
+	owners, err := fetchDenomOwners(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(owners) != maxPages*2 {
+		t.Errorf("got %d owners, want %d", len(owners), maxPages*2)
+	}
+}
+
+func TestFetchPaginatedDataSkipsDelayAfterLastPage(t *testing.T) {
+	withPaging(t, 2, time.Hour)
+	runner := newFakeRunner().onCall("query bank denom-owners", denomOwnersFixture(t, 1))
+	ctx := withCommandRunner(context.Background(), runner)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := fetchDenomOwners(ctx)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("fetch waited out -page-delay after the last page")
+	}
+}
+
+func TestFetchPaginatedDataStopsWhenCancelled(t *testing.T) {
+	withPaging(t, 2, time.Hour)
+	runner := newFakeRunner().onCall("query bank denom-owners", denomOwnersFixture(t, 10))
+	ctx, cancel := context.WithCancel(withCommandRunner(context.Background(), runner))
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if _, err := fetchDenomOwners(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if calls := len(runner.callsTo("query bank denom-owners")); calls != 1 {
+		t.Errorf("ran %d pages, want 1", calls)
+	}
+}
+
+func TestValidateFlagsRejectsNegativePageDelay(t *testing.T) {
+	withPaging(t, *pageLimit, -time.Second)
+	if err := validateFlags(); err == nil || !strings.Contains(err.Error(), "-page-delay") {
+		t.Fatalf("err = %v, want a -page-delay error", err)
+	}
+}
@@ -0,0 +1,432 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if err := validateFlags(); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid default flags: %v\n", err)
+		os.Exit(1)
+	}
+	swechaindCmd = "swechaind"
+	// Tests that care about liveness set their own threshold and status output.
+	*staleBlockThreshold = 0
+	os.Exit(m.Run())
+}
+
+// fakeRunner is a CommandRunner that answers swechaind commands from canned
+// output instead of running anything. Responses are keyed by the command's
+// leading words, e.g. "query bank balances", and the longest matching key
+// wins. Every call is recorded so tests can check the arguments.
+type fakeRunner struct {
+	mu        sync.Mutex
+	responses map[string]fakeResponse
+	calls     [][]string
+	stdins    []string
+}
+
+type fakeResponse struct {
+	stdout string
+	stderr string
+	err    error
+	// respond, when set, computes the response from the call number of this
+	// command (starting at 1) and its arguments.
+	respond func(call int, args []string) (string, error)
+}
+
+func newFakeRunner() *fakeRunner {
+	return &fakeRunner{responses: make(map[string]fakeResponse)}
+}
+
+// on registers stdout for commands starting with command.
+func (f *fakeRunner) on(command, stdout string) *fakeRunner {
+	f.responses[command] = fakeResponse{stdout: stdout}
+	return f
+}
+
+// fail makes commands starting with command fail with stderr as their output.
+func (f *fakeRunner) fail(command, stderr string) *fakeRunner {
+	f.responses[command] = fakeResponse{stderr: stderr, err: errors.New("exit status 1")}
+	return f
+}
+
+// onCall registers a response computed per call.
+func (f *fakeRunner) onCall(command string, respond func(call int, args []string) (string, error)) *fakeRunner {
+	f.responses[command] = fakeResponse{respond: respond}
+	return f
+}
+
+func (f *fakeRunner) Run(ctx context.Context, stdin, name string, args ...string) (string, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, args)
+	f.stdins = append(f.stdins, stdin)
+
+	key := ""
+	for command := range f.responses {
+		if strings.HasPrefix(strings.Join(args, " ")+" ", command+" ") && len(command) > len(key) {
+			key = command
+		}
+	}
+	response, ok := f.responses[key]
+	if !ok {
+		return "", "", &CommandError{Kind: CommandFailed, Err: errors.New("exit status 1"), Stderr: fmt.Sprintf("Error: unknown command %q", strings.Join(args, " "))}
+	}
+	if response.respond != nil {
+		calls := 0
+		for _, call := range f.calls {
+			if strings.HasPrefix(strings.Join(call, " ")+" ", key+" ") {
+				calls++
+			}
+		}
+		stdout, err := response.respond(calls, args)
+		if err != nil {
+			return stdout, "", &CommandError{Kind: CommandFailed, Err: errors.New("exit status 1"), Stdout: stdout, Stderr: err.Error()}
+		}
+		return stdout, "", nil
+	}
+	var cmdErr *CommandError
+	if errors.As(response.err, &cmdErr) {
+		return response.stdout, response.stderr, cmdErr
+	}
+	if response.err != nil {
+		return response.stdout, response.stderr, &CommandError{Kind: CommandFailed, Err: response.err, Stdout: response.stdout, Stderr: response.stderr}
+	}
+	return response.stdout, response.stderr, nil
+}
+
+// callsTo returns the recorded calls starting with command.
+func (f *fakeRunner) callsTo(command string) [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls [][]string
+	for _, call := range f.calls {
+		if strings.HasPrefix(strings.Join(call, " ")+" ", command+" ") {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// callTool runs h with runner injected, the way the server's middleware does.
+func callTool[In any](t *testing.T, runner CommandRunner, h mcp.ToolHandlerFor[In, any], args In) (string, bool) {
+	t.Helper()
+	ctx := withCommandRunner(context.Background(), runner)
+	result, err := h(ctx, nil, &mcp.CallToolParamsFor[In]{Arguments: args})
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if len(result.Content) == 0 {
+		t.Fatalf("handler returned no content")
+	}
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("handler returned %T, want *mcp.TextContent", result.Content[0])
+	}
+	return text.Text, result.IsError
+}
+
+const (
+	testAlice          = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	testBob            = "cosmos1qgpqyqszqgpqyqszqgpqyqszqgpqyqszrh8mx2"
+	testValidator      = "cosmosvaloper1qyqszqgpqyqszqgpqyqszqgpqyqszqgph84tp0"
+	testOtherValidator = "cosmosvaloper1qgpqyqszqgpqyqszqgpqyqszqgpqyqszxrnw2e"
+)
+
+func TestGetBalanceHandlerUsesInjectedRunner(t *testing.T) {
+	runner := newFakeRunner().on("query bank balances", `{"balances":[{"denom":"token","amount":"1000"}]}`)
+
+	text, isError := callTool(t, runner, getBalanceHandler, GetBalanceParams{Address: testAlice})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	if !strings.Contains(text, "has 1000 token") {
+		t.Errorf("summary missing balance: %s", text)
+	}
+
+	calls := runner.callsTo("query bank balances")
+	if len(calls) != 1 || calls[0][3] != testAlice {
+		t.Errorf("calls = %v, want one balances query for %s", calls, testAlice)
+	}
+}
+
+func TestRunCommandDoesNotRetryPermanentFailures(t *testing.T) {
+	runner := newFakeRunner().fail("query issuemarket show-auction", "Error: rpc error: code = NotFound desc = not found")
+	ctx := withCommandRunner(context.Background(), runner)
+
+	if _, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "issuemarket", "show-auction", "7")...); !isNotFoundError(err) {
+		t.Fatalf("err = %v, want a not-found error", err)
+	}
+	if calls := len(runner.callsTo("query issuemarket show-auction")); calls != 1 {
+		t.Errorf("ran %d times, want 1", calls)
+	}
+}
+
+func TestCheckSubcommandAllowed(t *testing.T) {
+	for _, args := range [][]string{
+		{"status"},
+		{"query", "bank", "balances", testAlice, "--output", "json"},
+		{"tx", "staking", "redelegate", testValidator, testOtherValidator, "1stake"},
+	} {
+		if err := checkSubcommandAllowed(args); err != nil {
+			t.Errorf("checkSubcommandAllowed(%q) = %v, want allowed", args, err)
+		}
+	}
+	for _, args := range [][]string{
+		nil,
+		{"keys"},
+		{"keys", "delete", "alice"},
+		{"keys", "export", "alice"},
+		{"query", "bank"},
+		{"query", "bank", "balances-all", testAlice},
+		{"--home", "/tmp", "status"},
+		{"tx", "bank", "multi-send"},
+	} {
+		if err := checkSubcommandAllowed(args); err == nil {
+			t.Errorf("checkSubcommandAllowed(%q) allowed an off-list subcommand", args)
+		}
+	}
+}
+
+func TestRunCommandRefusesOffListSubcommands(t *testing.T) {
+	runner := newFakeRunner().on("keys delete", "").on("keys export", "armored key")
+	ctx := withCommandRunner(context.Background(), runner)
+
+	if _, err := runCommand(ctx, swechaindCmd, "keys", "delete", "alice", "--yes"); err == nil || !strings.Contains(err.Error(), "not on the allow-list") {
+		t.Errorf("runCommand err = %v, want an allow-list refusal", err)
+	}
+	if _, _, err := runSecretCommand(ctx, "", swechaindCmd, "keys", "export", "alice"); err == nil || !strings.Contains(err.Error(), "not on the allow-list") {
+		t.Errorf("runSecretCommand err = %v, want an allow-list refusal", err)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("ran %v despite the allow-list", runner.calls)
+	}
+}
+
+func TestRunSecretCommandUsesInjectedRunner(t *testing.T) {
+	runner := newFakeRunner()
+	runner.responses["keys mnemonic"] = fakeResponse{stderr: "word word"}
+	ctx := withCommandRunner(context.Background(), runner)
+
+	_, stderr, err := runSecretCommand(ctx, "secret\n", swechaindCmd, "keys", "mnemonic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stderr != "word word" {
+		t.Errorf("stderr = %q", stderr)
+	}
+	if runner.stdins[0] != "secret\n" {
+		t.Errorf("stdin = %q, want the secret input", runner.stdins[0])
+	}
+}
+
+func TestRunCommandDoesNotRetryTxCommands(t *testing.T) {
+	runner := newFakeRunner().fail("tx bank send", "Error: post failed: connection refused")
+	ctx := withCommandRunner(context.Background(), runner)
+
+	if _, err := runCommand(ctx, swechaindCmd, "tx", "bank", "send", testAlice, testBob, "1token"); err == nil {
+		t.Fatal("expected the send to fail")
+	}
+	if calls := len(runner.callsTo("tx bank send")); calls != 1 {
+		t.Errorf("ran %d times, want 1", calls)
+	}
+}
+
+func TestRunTxReportsTimeoutAsUnknownOutcome(t *testing.T) {
+	runner := newFakeRunner()
+	runner.responses["tx bank send"] = fakeResponse{err: &CommandError{Kind: CommandTimedOut, Err: context.DeadlineExceeded}}
+	ctx := withCommandRunner(context.Background(), runner)
+
+	_, err := runTx(ctx, []string{"tx", "bank", "send", testAlice, testBob, "1token"})
+	if err == nil || !strings.Contains(err.Error(), "outcome unknown") {
+		t.Fatalf("err = %v, want an unknown-outcome error", err)
+	}
+	if calls := len(runner.callsTo("tx bank send")); calls != 1 {
+		t.Errorf("ran %d times, want 1", calls)
+	}
+}
+
+func TestErrorForLogOmitsCommandOutput(t *testing.T) {
+	cmdErr := &CommandError{Kind: CommandFailed, Err: errors.New("exit status 1"), Stdout: strings.Repeat("x", 10000), Stderr: "Error: boom"}
+	err := fmt.Errorf("failed to query rewards: %w", cmdErr)
+
+	got := errorForLog(err)
+	if want := "failed to query rewards: command failed: exit status 1"; got != want {
+		t.Errorf("errorForLog = %q, want %q", got, want)
+	}
+}
+
+// withRetryLimits sets -max-retry-duration and -retry-jitter for the rest of the test.
+func withRetryLimits(t *testing.T, maxDuration, jitter time.Duration) {
+	t.Helper()
+	previousDuration, previousJitter := *maxRetryDuration, *retryJitter
+	*maxRetryDuration, *retryJitter = maxDuration, jitter
+	t.Cleanup(func() { *maxRetryDuration, *retryJitter = previousDuration, previousJitter })
+}
+
+func TestRunCommandRespectsMaxRetryDuration(t *testing.T) {
+	for _, tc := range []struct {
+		cap   time.Duration
+		calls int
+	}{
+		// The first backoff (1s) already exceeds the cap.
+		{500 * time.Millisecond, 1},
+		// One 1s backoff fits; the next (2s) would not.
+		{1500 * time.Millisecond, 2},
+	} {
+		withRetryLimits(t, tc.cap, 0)
+		runner := newFakeRunner().fail("query bank balances", "Error: post failed: connection refused")
+		ctx := withCommandRunner(context.Background(), runner)
+
+		start := time.Now()
+		if _, err := runCommand(ctx, swechaindCmd, "query", "bank", "balances", testAlice); err == nil {
+			t.Fatal("expected the query to fail")
+		}
+		if elapsed := time.Since(start); elapsed > tc.cap {
+			t.Errorf("cap %s: retried for %s", tc.cap, elapsed)
+		}
+		if calls := len(runner.callsTo("query bank balances")); calls != tc.calls {
+			t.Errorf("cap %s: ran %d times, want %d", tc.cap, calls, tc.calls)
+		}
+	}
+}
+
+func TestRetryBackoffJitterIsBounded(t *testing.T) {
+	withRetryLimits(t, 0, 250*time.Millisecond)
+	for i := 0; i < 100; i++ {
+		if backoff := retryBackoff(1); backoff < 2*time.Second || backoff >= 2*time.Second+250*time.Millisecond {
+			t.Fatalf("retryBackoff(1) = %s, want 2s plus under 250ms of jitter", backoff)
+		}
+	}
+}
+
+// withSwechaindPath resolves swechaind through -swechaind-path for one test,
+// restoring the previous resolution afterwards.
+func withSwechaindPath(t *testing.T, path string) {
+	t.Helper()
+	previousPath, previousCmd, previousErr := *swechaindPath, swechaindCmd, swechaindErr
+	t.Cleanup(func() {
+		*swechaindPath, swechaindCmd, swechaindErr = previousPath, previousCmd, previousErr
+	})
+	*swechaindPath, swechaindErr = path, nil
+	resolveSwechaind()
+}
+
+func TestInvalidSwechaindPathFailsCallsInsteadOfExiting(t *testing.T) {
+	notExecutable := filepath.Join(t.TempDir(), "swechaind")
+	if err := os.WriteFile(notExecutable, []byte("#!/bin/sh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{filepath.Join(t.TempDir(), "missing"), notExecutable, t.TempDir()} {
+		withSwechaindPath(t, path)
+		runner := newFakeRunner().on("query bank balances", `{"balances":[]}`)
+
+		text, isError := callTool(t, runner, requireSwechaind(getBalanceHandler), GetBalanceParams{Address: testAlice})
+		var result struct {
+			Error   string `json:"error"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(text), &result); err != nil || !isError || result.Error != "swechaind_not_found" || !strings.Contains(result.Message, path) {
+			t.Errorf("%s: result = %s, want a swechaind_not_found error naming the path", path, text)
+		}
+		if len(runner.calls) != 0 {
+			t.Errorf("%s: ran %v without a swechaind binary", path, runner.calls)
+		}
+	}
+}
+
+func TestSwechaindPathOverrideIsRun(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "my-swechaind")
+	argsFile := filepath.Join(dir, "args")
+	body := "#!/bin/sh\necho \"$@\" > " + argsFile + "\necho '{\"balances\":[{\"denom\":\"token\",\"amount\":\"7\"}],\"pagination\":{}}'\n"
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	withSwechaindPath(t, script)
+	if swechaindCmd != script || swechaindErr != nil {
+		t.Fatalf("swechaindCmd = %q (err %v), want the override %s", swechaindCmd, swechaindErr, script)
+	}
+
+	text, _ := callTool(t, execRunner{}, requireSwechaind(getBalanceHandler), GetBalanceParams{Address: testAlice, Denom: "token"})
+	if !strings.Contains(text, "has 7 token") {
+		t.Errorf("result = %s, want the override's balance", text)
+	}
+	args, err := os.ReadFile(argsFile)
+	if err != nil || !strings.HasPrefix(string(args), "query bank balances "+testAlice) {
+		t.Errorf("override ran with %q (%v), want a balances query", args, err)
+	}
+}
+
+func TestLargeOutputIsTruncatedInLogButReturnedInFull(t *testing.T) {
+	previous := *logMaxBytes
+	*logMaxBytes = 1024
+	t.Cleanup(func() { *logMaxBytes = previous })
+	logs := captureLog(t)
+
+	large := `{"balances":[],"padding":"` + strings.Repeat("x", 100000) + `"}`
+	runner := newFakeRunner().on("query bank balances", large)
+	output, err := runCommand(withCommandRunner(context.Background(), runner), swechaindCmd, "query", "bank", "balances", testAlice)
+	if err != nil || output != large {
+		t.Fatalf("runCommand returned %d bytes (err %v), want all %d", len(output), err, len(large))
+	}
+
+	logged := logs.String()
+	if strings.Contains(logged, strings.Repeat("x", 2000)) {
+		t.Errorf("log holds the full output (%d bytes)", len(logged))
+	}
+	if want := fmt.Sprintf("... (%d bytes truncated)", len(large)-1024); !strings.Contains(logged, want) {
+		t.Errorf("log = %q, want the output cut at 1024 bytes with %q", logged, want)
+	}
+}
+
+func TestRunCommandDistinguishesNotExecutableFromFailed(t *testing.T) {
+	dir := t.TempDir()
+	notExecutable := filepath.Join(dir, "not-executable")
+	if err := os.WriteFile(notExecutable, []byte("#!/bin/sh\necho '{}'\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	failing := filepath.Join(dir, "failing")
+	if err := os.WriteFile(failing, []byte("#!/bin/sh\necho partial\necho 'Error: rpc error: code = InvalidArgument desc = bad' >&2\nexit 3\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ctx := withCommandRunner(context.Background(), execRunner{})
+
+	for _, tc := range []struct {
+		name, path string
+		kind       CommandErrorKind
+	}{
+		{"missing", filepath.Join(dir, "missing"), CommandNotExecutable},
+		{"not executable", notExecutable, CommandNotExecutable},
+		{"exits non-zero", failing, CommandFailed},
+	} {
+		_, err := runCommand(ctx, tc.path, "query", "bank", "balances", testAlice)
+		var cmdErr *CommandError
+		if !errors.As(err, &cmdErr) || cmdErr.Kind != tc.kind {
+			t.Errorf("%s: err = %v, want a %s CommandError", tc.name, err, tc.kind)
+			continue
+		}
+		if tc.kind == CommandFailed {
+			var exitErr *exec.ExitError
+			if !errors.As(cmdErr.Err, &exitErr) || exitErr.ExitCode() != 3 || !strings.Contains(cmdErr.Stderr, "InvalidArgument") || strings.TrimSpace(cmdErr.Stdout) != "partial" {
+				t.Errorf("%s: err = %#v, want exit code 3 with the script's output", tc.name, cmdErr)
+			}
+		}
+	}
+}
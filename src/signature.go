@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"swechain-mcp-server/src/internal/parse"
+)
+
+// Signatures use the same scheme as the keyring's secp256k1 keys: the signed
+// bytes are hashed with SHA-256, and the signature is the 64-byte big-endian
+// r || s with s in the lower half of the curve order.
+
+const secp256k1SignatureSize = 64
+
+// verifySecp256k1 reports whether sig is a valid signature over message by the
+// compressed or uncompressed public key pubKey.
+func verifySecp256k1(pubKey, message, sig []byte) (bool, error) {
+	key, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return false, fmt.Errorf("invalid secp256k1 public key: %w", err)
+	}
+	if len(sig) != secp256k1SignatureSize {
+		return false, fmt.Errorf("signature must be %d bytes (got %d)", secp256k1SignatureSize, len(sig))
+	}
+
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:]) || r.IsZero() || s.IsZero() {
+		return false, nil
+	}
+	if s.IsOverHalfOrder() {
+		// Malleable high-S form; the SDK rejects it too.
+		return false, nil
+	}
+
+	hash := sha256.Sum256(message)
+	return ecdsa.NewSignature(&r, &s).Verify(hash[:], key), nil
+}
+
+// parsePubKey extracts the raw key bytes from a pubkey as printed by the CLI:
+// either an object {"@type": ..., "key": "<base64>"} or that object encoded
+// as a JSON string, as `keys show` does.
+func parsePubKey(v interface{}) ([]byte, error) {
+	if encoded, ok := v.(string); ok {
+		var decoded map[string]interface{}
+		if err := json.Unmarshal([]byte(encoded), &decoded); err != nil {
+			return nil, fmt.Errorf("failed to parse pubkey %q: %w", encoded, err)
+		}
+		v = decoded
+	}
+
+	pubKey, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("pubkey not found")
+	}
+	if keyType := parse.String(pubKey, "@type"); keyType != "" && keyType != "/cosmos.crypto.secp256k1.PubKey" {
+		return nil, fmt.Errorf("unsupported pubkey type %s", keyType)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(parse.String(pubKey, "key"))
+	if err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("pubkey has no valid base64 key")
+	}
+	return key, nil
+}
+
+// ADR-036 off-chain signing: what gets signed is not the message itself but
+// an amino-JSON StdSignDoc that wraps it in a MsgSignData, with an empty chain
+// id, zero account number and sequence, and no fee. No chain accepts that doc
+// as a transaction, so a signature made for a message can't be replayed as one.
+const (
+	adr036AminoType = "sign/MsgSignData"
+	// adr036TypeURL only has to get the message through `tx sign`'s decoder;
+	// the amino-JSON signature commits to adr036AminoType.
+	adr036TypeURL = "/cosmos.msg.v1.MsgSignData"
+)
+
+// adr036SignDoc returns the sorted amino JSON that signer signs for message.
+func adr036SignDoc(signer string, message []byte) []byte {
+	doc := map[string]interface{}{
+		"account_number": "0",
+		"chain_id":       "",
+		"fee":            map[string]interface{}{"amount": []interface{}{}, "gas": "0"},
+		"memo":           "",
+		"msgs": []interface{}{map[string]interface{}{
+			"type": adr036AminoType,
+			"value": map[string]interface{}{
+				"data":   base64.StdEncoding.EncodeToString(message),
+				"signer": signer,
+			},
+		}},
+		"sequence": "0",
+	}
+	// encoding/json sorts map keys, which is the canonical form amino JSON signs.
+	data, _ := json.Marshal(doc)
+	return data
+}
+
+// adr036UnsignedTx returns the unsigned tx `tx sign` turns into adr036SignDoc.
+func adr036UnsignedTx(signer string, message []byte) []byte {
+	tx := map[string]interface{}{
+		"body": map[string]interface{}{
+			"messages": []interface{}{map[string]interface{}{
+				"@type":  adr036TypeURL,
+				"signer": signer,
+				"data":   base64.StdEncoding.EncodeToString(message),
+			}},
+			"memo":                           "",
+			"timeout_height":                 "0",
+			"extension_options":              []interface{}{},
+			"non_critical_extension_options": []interface{}{},
+		},
+		"auth_info": map[string]interface{}{
+			"signer_infos": []interface{}{},
+			"fee":          map[string]interface{}{"amount": []interface{}{}, "gas_limit": "0", "payer": "", "granter": ""},
+		},
+		"signatures": []interface{}{},
+	}
+	data, _ := json.Marshal(tx)
+	return data
+}
+
+// signADR036 has swechaind sign message for signer, the address of keyName,
+// as an ADR-036 document. The key never leaves the keyring.
+func signADR036(ctx context.Context, keyName, signer string, message []byte) ([]byte, []byte, error) {
+	txFile, err := os.CreateTemp("", "swechain-sign-*.json")
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating sign doc file: %w", err)
+	}
+	defer os.Remove(txFile.Name())
+
+	_, err = txFile.Write(adr036UnsignedTx(signer, message))
+	if closeErr := txFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("writing sign doc file: %w", err)
+	}
+
+	cfg := configFrom(ctx)
+	args := []string{"tx", "sign", txFile.Name(),
+		"--from", keyName,
+		"--offline",
+		"--sign-mode", "amino-json",
+		"--account-number", "0",
+		"--sequence", "0",
+		"--chain-id", "",
+		"--keyring-backend", cfg.keyringBackend,
+		"--output", "json",
+	}
+	if cfg.home != "" {
+		args = append(args, "--home", cfg.home)
+	}
+
+	input, err := keyringInput(ctx, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	stdout, _, err := runSecretCommand(ctx, input, swechaindCmd, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign with key %s: %w", keyName, err)
+	}
+	return parseSignedTx(stdout)
+}
+
+// parseSignedTx reads the first signature and its signer's public key from
+// `tx sign` output.
+func parseSignedTx(output string) ([]byte, []byte, error) {
+	var signed struct {
+		AuthInfo struct {
+			SignerInfos []struct {
+				PublicKey interface{} `json:"public_key"`
+			} `json:"signer_infos"`
+		} `json:"auth_info"`
+		Signatures []string `json:"signatures"`
+	}
+	if err := json.Unmarshal([]byte(output), &signed); err != nil {
+		return nil, nil, parse.OutputError("signed tx", err, output)
+	}
+	if len(signed.Signatures) == 0 || len(signed.AuthInfo.SignerInfos) == 0 {
+		return nil, nil, fmt.Errorf("signed tx has no signature")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signed.Signatures[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("signed tx signature is not base64")
+	}
+	pubKey, err := parsePubKey(signed.AuthInfo.SignerInfos[0].PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, pubKey, nil
+}
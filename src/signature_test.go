@@ -0,0 +1,95 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// signingRunner fakes a keyring holding one key, named "alice", whose
+// `tx sign` signs the amino JSON of the MsgSignData in the given file.
+func signingRunner(t *testing.T) *fakeRunner {
+	t.Helper()
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := base64.StdEncoding.EncodeToString(key.PubKey().SerializeCompressed())
+	pubKeyJSON := fmt.Sprintf(`{"@type":"/cosmos.crypto.secp256k1.PubKey","key":"%s"}`, pubKey)
+	keyInfo, _ := json.Marshal(map[string]string{"name": "alice", "address": testAlice, "pubkey": pubKeyJSON})
+
+	return newFakeRunner().
+		on("keys show", string(keyInfo)).
+		onCall("tx sign", func(call int, args []string) (string, error) {
+			data, err := os.ReadFile(args[2])
+			if err != nil {
+				return "", err
+			}
+			var tx struct {
+				Body struct {
+					Messages []struct {
+						Type   string `json:"@type"`
+						Signer string `json:"signer"`
+						Data   string `json:"data"`
+					} `json:"messages"`
+				} `json:"body"`
+			}
+			if err := json.Unmarshal(data, &tx); err != nil || len(tx.Body.Messages) != 1 || tx.Body.Messages[0].Type != adr036TypeURL {
+				return "", fmt.Errorf("not a MsgSignData tx: %s", data)
+			}
+			message, _ := base64.StdEncoding.DecodeString(tx.Body.Messages[0].Data)
+
+			hash := sha256.Sum256(adr036SignDoc(tx.Body.Messages[0].Signer, message))
+			compact := ecdsa.SignCompact(key, hash[:], true)
+			signed, _ := json.Marshal(map[string]interface{}{
+				"body": tx.Body,
+				"auth_info": map[string]interface{}{
+					"signer_infos": []interface{}{map[string]interface{}{
+						"public_key": map[string]string{"@type": "/cosmos.crypto.secp256k1.PubKey", "key": pubKey},
+					}},
+				},
+				"signatures": []string{base64.StdEncoding.EncodeToString(compact[1:])},
+			})
+			return string(signed), nil
+		})
+}
+
+func TestSignMessageRoundTripsThroughVerify(t *testing.T) {
+	runner := signingRunner(t)
+
+	text, isError := callTool(t, runner, signMessageHandler, SignMessageParams{KeyName: "alice", Message: "hello"})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	var signed struct {
+		Details struct {
+			Signature string `json:"signature"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(text), &signed); err != nil || signed.Details.Signature == "" {
+		t.Fatalf("no signature in response: %s", text)
+	}
+	if calls := runner.callsTo("keys export"); len(calls) != 0 {
+		t.Errorf("exported the key: %v", calls)
+	}
+	if calls := runner.callsTo("tx sign"); len(calls) != 1 || argValue(calls[0], "--sign-mode") != "amino-json" {
+		t.Errorf("tx sign calls = %v, want one amino-json sign", calls)
+	}
+
+	text, isError = callTool(t, runner, verifySignatureHandler, VerifySignatureParams{KeyName: "alice", Message: "hello", Signature: signed.Details.Signature})
+	if isError || !strings.Contains(text, "Signature is valid") {
+		t.Errorf("signature did not verify: %s", text)
+	}
+
+	text, _ = callTool(t, runner, verifySignatureHandler, VerifySignatureParams{KeyName: "alice", Message: "hellO", Signature: signed.Details.Signature})
+	if !strings.Contains(text, "NOT valid") {
+		t.Errorf("signature verified for a different message: %s", text)
+	}
+}
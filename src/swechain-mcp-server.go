@@ -3,51 +3,137 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"math"
+	"math/big"
+	mathrand "math/rand/v2"
 	"os"
 	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
+	"unicode"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"swechain-mcp-server/src/internal/parse"
 )
 
 const (
 	commandTimeout = 30 * time.Second
-	pageLimit      = 50
 	maxPages       = 10 // Reduced to prevent runaway queries
-	requestDelay   = 500 * time.Millisecond
 	maxRetries     = 3
-)
 
-var swechaindCmd string
+	maxTestAccounts = 20
 
-// Core data structures
-type Key struct {
-	Name    string `json:"name"`
-	Address string `json:"address"`
-}
+	defaultWaitTimeout = 60 * time.Second
+	maxWaitTimeout     = 10 * time.Minute
+	waitPollInterval   = 2 * time.Second
 
-type Auction struct {
-	ID          int    `json:"id,string"`
-	Issue       string `json:"issue"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	Winner      string `json:"winner"`
-	Creator     string `json:"creator"`
-}
+	defaultWatchPolls = 30
+	maxWatchInterval  = 60 * time.Second
 
-type Bid struct {
-	AuctionID   int    `json:"auctionId,string"`
-	Amount      string `json:"amount"`
-	Description string `json:"description"`
-	Creator     string `json:"creator"`
-	Bidder      string `json:"bidder"`
-}
+	blockHeightCacheTTL = time.Second
+	chainInfoCacheTTL   = 10 * time.Minute
+
+	maxListLimit = 500
+
+	defaultRecentAuctions = 10
+	maxRecentAuctions     = 100
+
+	defaultCongestionBlocks = 5
+	maxCongestionBlocks     = 20
+
+	// Without a block gas limit, congestion is judged against these averages.
+	congestionMediumGas = 10_000_000
+	congestionHighGas   = 30_000_000
+
+	maxTxRangeBlocks = 1000
+
+	maxBatchAddresses   = 100
+	batchBalanceWorkers = 8
+
+	maxBatchAuctions    = 50
+	batchAuctionWorkers = 8
+
+	maxFeeMultiplier = 10
+
+	// Default amounts, in -default-denom.
+	defaultBidAmount  = "100"
+	defaultFeeAmount  = "200"
+	defaultFundAmount = "1000"
+
+	serverVersion = "1.0.0"
+)
+
+var (
+	swechaindCmd string
+	swechaindErr error
+)
+
+var sequenceMismatchPattern = regexp.MustCompile(`account sequence mismatch, expected (\d+), got (\d+)`)
+
+var denomPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9/:._-]{2,127}$`)
+
+var msgTypePattern = regexp.MustCompile(`^/[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*\.Msg[A-Za-z0-9]+$`)
+
+var (
+	swechaindPath       = flag.String("swechaind-path", "", "Path to the swechaind binary (defaults to a PATH lookup)")
+	nodeURL             = flag.String("node", "", "Tendermint RPC endpoint passed to swechaind as --node (defaults to swechaind's own setting)")
+	homeDir             = flag.String("home", "", "swechaind home directory passed as --home (defaults to swechaind's own setting)")
+	keyringBackend      = flag.String("keyring-backend", "test", "Keyring backend used for keys and signing")
+	keyringPassFile     = flag.String("keyring-passphrase-file", "", "File holding the passphrase for the file and os keyring backends; it is fed to swechaind on stdin (SWECHAIN_KEYRING_PASSPHRASE takes precedence)")
+	chainID             = flag.String("chain-id", "swechain", "Chain ID used for transactions")
+	mainnetChainIDs     = flag.String("mainnet-chain-ids", "swechain-1", "Comma-separated chain IDs treated as mainnet (test-only tools refuse to run against them)")
+	defaultFees         = flag.String("fees", "", "Default fees for transactions, e.g. 200token (defaults to 200 of -default-denom); tx tools accept a per-call 'fees' override")
+	broadcastMode       = flag.String("broadcast-mode", "sync", "Broadcast mode for transactions: sync, async, or block (block waits for the tx to be committed)")
+	staleBlockThreshold = flag.Duration("stale-block-threshold", 2*time.Minute, "Refuse transactions when the latest block is older than this (0 disables the check)")
+	pageLimit           = flag.Int("page-limit", 50, "Number of results requested per page for paginated queries (1-1000)")
+	pageDelay           = flag.Duration("page-delay", 500*time.Millisecond, "Delay between paginated query requests")
+	defaultDenom        = flag.String("default-denom", "token", "Default denom used when a tool's denom parameter is omitted")
+	participantsMode    = flag.String("participants", "denom-owners", "How auction listings collect participants: denom-owners (holders of the default denom) or involved (full balances of addresses in the listed auctions)")
+	auctionStatuses     = flag.String("auction-statuses", "open,closed,cancelled", "Comma-separated auction status values accepted by open-auction and close-auction")
+	readCacheTTL        = flag.Duration("read-cache-ttl", 0, "Serve repeated identical read tool calls from memory for this long (0 disables caching)")
+	idempotencyTTL      = flag.Duration("idempotency-ttl", 10*time.Minute, "How long tx tool results are remembered by idempotencyKey")
+	maxRetryDuration    = flag.Duration("max-retry-duration", 45*time.Second, "Stop retrying a failed swechaind command once this much time has passed since its first attempt (0 disables the cap)")
+	retryJitter         = flag.Duration("retry-jitter", 0, "Add a random delay of up to this much to each retry backoff, so parallel calls don't retry in lockstep")
+	logMaxBytes         = flag.Int("log-max-bytes", 1024, "Maximum bytes of command output written to the log (0 disables truncation)")
+	auditLogPath        = flag.String("audit-log", "", "Append a JSON line per mutating tool call (time, tool, signer, txhash or error) to this file (empty disables auditing)")
+	jsonCase            = flag.String("json-case", "camel", "Key style of tool responses: camel (auctionId) or snake (auction_id); 'fields' paths are always camelCase")
+	compactJSON         = flag.Bool("compact-json", false, "Emit tool responses as compact JSON instead of indented JSON to save tokens")
+	extraArgs           = flag.String("extra-args", "", "Extra flags appended to every swechaind invocation, separated by spaces or commas (quotes group a value containing either)")
+)
+
+// extraArgList holds -extra-args split into individual arguments by validateFlags.
+var extraArgList []string
+
+// Core data structures. The types filled in by the parse package are aliased
+// so the rest of this file can keep using the short names.
+type (
+	Key     = parse.Key
+	Auction = parse.Auction
+	Bid     = parse.Bid
+	Balance = parse.Balance
+
+	Delegation        = parse.Delegation
+	ValidatorInfo     = parse.ValidatorInfo
+	SendEnabledEntry  = parse.SendEnabledEntry
+	FeegrantAllowance = parse.FeegrantAllowance
+)
 
 type DenomOwner struct {
 	Address string `json:"address"`
@@ -57,11 +143,6 @@ type DenomOwner struct {
 	} `json:"balance"`
 }
 
-type Balance struct {
-	Denom  string `json:"denom"`
-	Amount string `json:"amount"`
-}
-
 // Enhanced response structures
 type AuctionSummaryResponse struct {
 	Summary string `json:"summary"`
@@ -75,6 +156,7 @@ type AuctionDetail struct {
 	AuctionID        int         `json:"auctionId"`
 	Issue            string      `json:"issue"`
 	Creator          string      `json:"creator"`
+	CreatorName      string      `json:"creatorName,omitempty"`
 	Description      string      `json:"description"`
 	Status           string      `json:"status"`
 	Winner           string      `json:"winner"`
@@ -89,9 +171,233 @@ type BidDetail struct {
 }
 
 type ParticipantDetail struct {
-	Name    string `json:"name"`
-	Address string `json:"address"`
-	Balance string `json:"balance"`
+	Name     string    `json:"name"`
+	Address  string    `json:"address"`
+	Balance  string    `json:"balance"`
+	Balances []Balance `json:"balances,omitempty"`
+}
+
+type BidderAuctionBids struct {
+	AuctionID int         `json:"auctionId"`
+	Status    string      `json:"status"`
+	Bids      []BidDetail `json:"bids"`
+}
+
+type BidsByBidderResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Bidder        string              `json:"bidder"`
+		TotalBids     int                 `json:"totalBids"`
+		TotalsByDenom map[string]string   `json:"totalsByDenom"`
+		Auctions      []BidderAuctionBids `json:"auctions"`
+	} `json:"details"`
+}
+
+type TxSummary struct {
+	TxHash      string `json:"txhash"`
+	Height      string `json:"height"`
+	Timestamp   string `json:"timestamp"`
+	MessageType string `json:"messageType"`
+}
+
+type AuctionActivityResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		AuctionID        int      `json:"auctionId"`
+		BidCount         int      `json:"bidCount"`
+		Amounts          []string `json:"amounts"`
+		Trend            string   `json:"trend"`
+		LastBidAt        string   `json:"lastBidAt,omitempty"`
+		TimeSinceLastBid string   `json:"timeSinceLastBid,omitempty"`
+	} `json:"details"`
+}
+
+type LeaderboardEntry struct {
+	Rank        int    `json:"rank"`
+	Bidder      string `json:"bidder"`
+	Amount      string `json:"amount"`
+	Description string `json:"description"`
+	IsLeader    bool   `json:"isLeader"`
+}
+
+type AuctionLeaderboardResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		AuctionID int                `json:"auctionId"`
+		Entries   []LeaderboardEntry `json:"entries"`
+	} `json:"details"`
+}
+
+type MyAuctionEntry struct {
+	AuctionID        int    `json:"auctionId"`
+	Issue            string `json:"issue"`
+	Status           string `json:"status"`
+	IsCreator        bool   `json:"isCreator"`
+	IsHighBidder     bool   `json:"isHighBidder"`
+	CurrentBidAmount string `json:"currentBidAmount"`
+	HighBidder       string `json:"highBidder,omitempty"`
+}
+
+type MyAuctionsResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		KeyName  string           `json:"keyName"`
+		Address  string           `json:"address"`
+		Created  int              `json:"created"`
+		Leading  int              `json:"leading"`
+		Auctions []MyAuctionEntry `json:"auctions"`
+	} `json:"details"`
+}
+
+type DecodedTxResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Messages     []map[string]interface{} `json:"messages"`
+		MessageTypes []string                 `json:"messageTypes"`
+		Fee          string                   `json:"fee"`
+		GasLimit     string                   `json:"gasLimit"`
+		Memo         string                   `json:"memo"`
+		Signers      []string                 `json:"signers"`
+		Signatures   int                      `json:"signatures"`
+	} `json:"details"`
+}
+
+type CreatorCapacityResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		AuctionID          int       `json:"auctionId"`
+		Creator            string    `json:"creator"`
+		SpendableBalances  []Balance `json:"spendableBalances"`
+		CurrentBidAmount   string    `json:"currentBidAmount"`
+		CanCoverCurrentBid bool      `json:"canCoverCurrentBid"`
+	} `json:"details"`
+}
+
+type ClosedAuctionEntry struct {
+	AuctionID     int    `json:"auctionId"`
+	Issue         string `json:"issue"`
+	Creator       string `json:"creator"`
+	Winner        string `json:"winner"`
+	WinningAmount string `json:"winningAmount"`
+	BidCount      int    `json:"bidCount"`
+}
+
+type ClosedAuctionsResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Auctions []ClosedAuctionEntry `json:"auctions"`
+	} `json:"details"`
+}
+
+type SendEnabledResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		DefaultSendEnabled bool               `json:"defaultSendEnabled"`
+		Denoms             []SendEnabledEntry `json:"denoms"`
+	} `json:"details"`
+}
+
+type AuctionsWonResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Winner        string               `json:"winner"`
+		TotalsByDenom map[string]string    `json:"totalsByDenom"`
+		Auctions      []ClosedAuctionEntry `json:"auctions"`
+	} `json:"details"`
+}
+
+type TVLAuctionsResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		OpenAuctions     int               `json:"openAuctions"`
+		AuctionsWithBids int               `json:"auctionsWithBids"`
+		TotalsByDenom    map[string]string `json:"totalsByDenom"`
+	} `json:"details"`
+}
+
+type ValidatorDetailsResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		ValidatorInfo
+		SelfDelegation string `json:"selfDelegation,omitempty"`
+		Uptime         string `json:"uptime,omitempty"`
+	} `json:"details"`
+}
+
+type StakingRatioResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		BondDenom        string `json:"bondDenom"`
+		BondedTokens     string `json:"bondedTokens"`
+		TotalSupply      string `json:"totalSupply"`
+		BondedRatio      string `json:"bondedRatio"`
+		BondedValidators int    `json:"bondedValidators"`
+	} `json:"details"`
+}
+
+// InflationResponse reports the mint module's current inflation. Available
+// is false, with the query error as Reason, on chains without the module.
+type InflationResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Available        bool   `json:"available"`
+		Reason           string `json:"reason,omitempty"`
+		Inflation        string `json:"inflation,omitempty"`
+		AnnualProvisions string `json:"annualProvisions,omitempty"`
+		MintDenom        string `json:"mintDenom,omitempty"`
+	} `json:"details"`
+}
+
+type ChainInfoResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		ChainID         string                 `json:"chainId"`
+		LatestHeight    int64                  `json:"latestHeight"`
+		LatestBlockTime string                 `json:"latestBlockTime"`
+		BondDenom       string                 `json:"bondDenom,omitempty"`
+		Staking         map[string]interface{} `json:"staking,omitempty"`
+		Mint            map[string]interface{} `json:"mint,omitempty"`
+	} `json:"details"`
+}
+
+type NetworkCongestionResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Congestion     string           `json:"congestion"`
+		AvgGasUsed     int64            `json:"avgGasUsed"`
+		AvgTxs         float64          `json:"avgTxs"`
+		MaxBlockGas    int64            `json:"maxBlockGas,omitempty"`
+		GasUtilization float64          `json:"gasUtilization,omitempty"`
+		Blocks         []parse.BlockGas `json:"blocks"`
+	} `json:"details"`
+}
+
+type TxsBySenderResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Sender string      `json:"sender"`
+		Txs    []TxSummary `json:"txs"`
+	} `json:"details"`
+}
+
+type TxsInRangeResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		MinHeight int64       `json:"minHeight"`
+		MaxHeight int64       `json:"maxHeight"`
+		Txs       []TxSummary `json:"txs"`
+	} `json:"details"`
+}
+
+type DescribeServerResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Version        string `json:"version"`
+		SwechaindPath  string `json:"swechaindPath"`
+		SwechaindFound bool   `json:"swechaindFound"`
+		SwechaindError string `json:"swechaindError,omitempty"`
+		ChainID        string `json:"chainId"`
+	} `json:"details"`
 }
 
 type KeySummaryResponse struct {
@@ -112,1063 +418,7667 @@ type BlockchainStatusResponse struct {
 	} `json:"details"`
 }
 
+// BalanceResponse is the get-balance response. AccountExists is only set when
+// the address holds nothing, to tell a drained account from an unused address.
 type BalanceResponse struct {
 	Summary string `json:"summary"`
 	Details struct {
-		Address  string    `json:"address"`
-		Balances []Balance `json:"balances"`
+		Address       string    `json:"address"`
+		Balances      []Balance `json:"balances"`
+		AccountExists *bool     `json:"accountExists,omitempty"`
+	} `json:"details"`
+}
+
+type KeyAddressResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		KeyName string `json:"keyName"`
+		Address string `json:"address"`
+	} `json:"details"`
+}
+
+type AuctionBidsResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Bids []Bid `json:"bids"`
 	} `json:"details"`
 }
 
-// Parameter structures - all with required fields for proper schema generation
+// Parameter structures - fields without omitempty are marked required in the
+// generated input schema; jsonschema tags become property descriptions.
 type GetAddressForKeyParams struct {
 	KeyName string `json:"keyName"`
+	Fields  string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. address (optional)"`
 }
 
 type GetBalanceParams struct {
 	Address string `json:"address"`
+	Denom   string `json:"denom,omitempty" jsonschema:"only return the balance of this denom, e.g. token (optional)"`
+	Fields  string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. balances.denom,balances.amount (optional)"`
+}
+
+type BatchBalancesParams struct {
+	Addresses []string `json:"addresses" jsonschema:"cosmos addresses to look up (at most 100)"`
+	Fields    string   `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. balances.address (optional)"`
+}
+
+type AuctionExistsParams struct {
+	AuctionId string `json:"auctionId" jsonschema:"numeric ID of the auction to check (required)"`
+}
+
+type GetAuctionsParams struct {
+	AuctionIds []string `json:"auctionIds" jsonschema:"numeric auction IDs to look up (at most 50)"`
+	Fields     string   `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. auctions.auction.status (optional)"`
 }
 
 type QueryOpenAuctionsParams struct {
 	Operation string `json:"operation"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"maximum auctions to return (optional, capped at 500)"`
+	Offset    int    `json:"offset,omitempty" jsonschema:"number of auctions to skip (optional)"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. auctions.auctionId,auctions.status (optional)"`
+}
+
+type RecentAuctionsParams struct {
+	Count  int    `json:"count,omitempty" jsonschema:"number of most recently created auctions to return (optional, default 10, capped at 100)"`
+	Fields string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. auctions.auctionId,auctions.status (optional)"`
 }
 
 type QueryAllAuctionsParams struct {
 	Operation string `json:"operation"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"maximum auctions to return (optional, capped at 500)"`
+	Offset    int    `json:"offset,omitempty" jsonschema:"number of auctions to skip (optional)"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. auctions.auctionId,auctions.status (optional)"`
 }
 
 type QueryBidsForAuctionParams struct {
 	AuctionId string `json:"auctionId"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. bids.bidder,bids.amount (optional)"`
 }
 
-type GetBlockchainStatusParams struct {
-	Operation string `json:"operation"`
+type CountBidsParams struct {
+	AuctionId string `json:"auctionId"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. count (optional)"`
 }
 
-type GetKeysParams struct {
-	Operation string `json:"operation"`
+type AuctionCountParams struct {
+	Fields string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. count (optional)"`
 }
 
-type OpenAuctionParams struct {
-	Issue       string `json:"issue"`
-	Description string `json:"description"`
-	Status      string `json:"status,omitempty"`
-	Winner      string `json:"winner,omitempty"`
-	From        string `json:"from"`
+type BidsByBidderParams struct {
+	Address string `json:"address"`
+	Fields  string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. auctions.auctionId,auctions.status (optional)"`
 }
 
-type CreateBidParams struct {
-	AuctionId   string `json:"auctionId"`
-	Bidder      string `json:"bidder"`
-	Amount      string `json:"amount,omitempty"`
-	Description string `json:"description,omitempty"`
-	From        string `json:"from"`
+type AuctionActivityParams struct {
+	AuctionId string `json:"auctionId"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. bidCount,trend (optional)"`
 }
 
-type PayParams struct {
-	From   string `json:"from"`
-	To     string `json:"to"`
-	Amount string `json:"amount"`
+type StakingRatioParams struct {
+	Fields string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. bondedRatio (optional)"`
 }
 
-type CloseAuctionParams struct {
-	AuctionId   string `json:"auctionId"`
-	Status      string `json:"status"`
-	Issue       string `json:"issue"`
-	Description string `json:"description"`
-	Winner      string `json:"winner"`
-	From        string `json:"from"`
+type InflationParams struct {
+	Fields string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. inflation (optional)"`
 }
 
-type CreateAndFundAddressParams struct {
-	KeyName       string `json:"keyName"`
-	FunderAddress string `json:"funderAddress"`
-	Amount        string `json:"amount,omitempty"`
+type QuerySupplyParams struct {
+	Denom  string `json:"denom,omitempty"`
+	Fields string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. amount (optional)"`
 }
 
-func init() {
-	log.SetOutput(os.Stdout)
+type IsValidatorParams struct {
+	Address string `json:"address"`
+	Fields  string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. isValidator,moniker (optional)"`
+}
 
-	cmd, err := exec.LookPath("swechaind")
-	if err != nil {
-		log.Fatal("swechaind not found in PATH")
-	}
-	swechaindCmd = cmd
-	log.Printf("Found swechaind at: %s", swechaindCmd)
+type IsValidatorResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Address        string `json:"address"`
+		ValoperAddress string `json:"valoperAddress"`
+		IsValidator    bool   `json:"isValidator"`
+		Moniker        string `json:"moniker,omitempty"`
+		Status         string `json:"status,omitempty"`
+		Jailed         bool   `json:"jailed,omitempty"`
+	} `json:"details"`
 }
 
-// Enhanced command execution with retry logic
-func runCommand(name string, arg ...string) (string, error) {
-	var lastErr error
+type GetSequenceParams struct {
+	Address string `json:"address"`
+	Fields  string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. sequence (optional)"`
+}
 
-	for i := 0; i < maxRetries; i++ {
-		ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+type GetIssuemarketParamsParams struct {
+	Operation string `json:"operation"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. params (optional)"`
+}
 
-		cmd := exec.CommandContext(ctx, name, arg...)
-		var stdout, stderr bytes.Buffer
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
+type AuctionLeaderboardParams struct {
+	AuctionId string `json:"auctionId"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. entries.rank,entries.bidder,entries.amount (optional)"`
+}
 
-		if i == 0 {
-			log.Printf("Executing command: %s %v", name, arg)
-		} else {
-			log.Printf("Retry %d: Executing command: %s %v", i+1, name, arg)
-		}
+type MyAuctionsParams struct {
+	KeyName string `json:"keyName" jsonschema:"name of the local key whose auctions to list (required)"`
+	Fields  string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. auctions.auctionId,auctions.status (optional)"`
+}
 
-		err := cmd.Run()
-		cancel()
+type WaitForAuctionStatusParams struct {
+	AuctionId string `json:"auctionId" jsonschema:"numeric ID of the auction to watch (required)"`
+	Status    string `json:"status" jsonschema:"status to wait for, such as closed (required)"`
+	Timeout   int    `json:"timeout,omitempty" jsonschema:"maximum seconds to wait (optional, default 60, max 600)"`
+}
 
-		if err == nil {
-			result := strings.TrimSpace(stdout.String())
-			log.Printf("Command succeeded: %s", result)
-			return result, nil
-		}
+type WatchAuctionBidsParams struct {
+	AuctionId    string `json:"auctionId" jsonschema:"numeric ID of the auction to watch (required)"`
+	PollInterval int    `json:"pollInterval,omitempty" jsonschema:"seconds between polls (optional, default 2, max 60)"`
+	MaxPolls     int    `json:"maxPolls,omitempty" jsonschema:"maximum number of polls before giving up (optional, default 30; the whole watch is capped at 600 seconds)"`
+}
 
-		lastErr = fmt.Errorf("command failed: %v\nSTDOUT: %s\nSTDERR: %s",
-			err, stdout.String(), stderr.String())
+type DecodeTxParams struct {
+	Tx     string `json:"tx" jsonschema:"tx to decode, either base64-encoded bytes or tx JSON (required)"`
+	Fields string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. messageTypes,fee (optional)"`
+}
 
-		if i < maxRetries-1 {
-			time.Sleep(time.Duration(i+1) * time.Second) // Exponential backoff
-		}
-	}
+type CreatorCapacityParams struct {
+	AuctionId string `json:"auctionId" jsonschema:"numeric ID of the auction whose creator to check (required)"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. currentBidAmount,canCoverCurrentBid (optional)"`
+}
 
-	log.Printf("Command failed after %d retries: %v", maxRetries, lastErr)
-	return "", lastErr
+type ClosedAuctionsParams struct {
+	Operation string `json:"operation"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. auctions.auctionId,auctions.winningAmount (optional)"`
 }
 
-// Enhanced address validation
-func isValidCosmosAddress(addr string) bool {
-	addr = strings.TrimSpace(addr)
-	return strings.HasPrefix(addr, "cosmos1") && len(addr) >= 39 && len(addr) <= 45
+type SendEnabledParams struct {
+	Operation string `json:"operation"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. denoms.denom,denoms.enabled (optional)"`
 }
 
-func main() {
-	flag.Parse()
+type FeesSpentParams struct {
+	Address string `json:"address"`
+	Fields  string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. txCount,totalsByDenom (optional)"`
+}
 
-	server := mcp.NewServer(&mcp.Implementation{
-		Name:    "swechain-mcp-server",
-		Version: "1.0.0",
-	}, nil)
+type TVLAuctionsParams struct {
+	Fields string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. totalsByDenom (optional)"`
+}
 
-	// Register enhanced tools with consistent descriptions
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "get-address-for-key",
-		Description: "Get the cosmos address for a specific key name. Required parameter: keyName (string).",
-	}, getAddressForKeyHandler)
+type AuctionsWonParams struct {
+	Address string `json:"address"`
+	Fields  string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. totalsByDenom,auctions.auctionId (optional)"`
+}
 
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "get-balance",
-		Description: "Get token balance for a specific cosmos address. Required parameter: address (string).",
-	}, getBalanceHandler)
+type MinGasPricesParams struct {
+	Fields string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. minimumGasPrices (optional)"`
+}
 
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "query-open-auctions",
-		Description: "Get all open auctions with detailed bid information and participants. Required parameter: operation (use 'list').",
-	}, queryOpenAuctionsHandler)
+type CommunityPoolParams struct {
+	Operation string `json:"operation"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. pool.denom,pool.amount (optional)"`
+}
 
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "query-all-auctions",
-		Description: "Get all auctions (open and closed) with detailed information. Required parameter: operation (use 'list').",
-	}, queryAllAuctionsHandler)
+type ValidatorDetailsParams struct {
+	Validator string `json:"validator" jsonschema:"validator operator address (cosmosvaloper1...) (required)"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. moniker,uptime (optional)"`
+}
 
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "query-bids-for-auction",
-		Description: "Get bids for a specific auction or all bids. Required parameter: auctionId (string - use specific ID or 'all').",
-	}, queryBidsForAuctionHandler)
+type TxsInRangeParams struct {
+	MinHeight int64  `json:"minHeight" jsonschema:"lowest block height to include (required)"`
+	MaxHeight int64  `json:"maxHeight" jsonschema:"highest block height to include; the range may span at most 1000 blocks (required)"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. txs.txhash,txs.height (optional)"`
+}
 
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "get-blockchain-status",
-		Description: "Get overall blockchain statistics. Required parameter: operation (use 'status').",
-	}, getBlockchainStatusHandler)
+type TxsBySenderParams struct {
+	Address string `json:"address"`
+	Limit   int    `json:"limit,omitempty" jsonschema:"maximum transactions to return (optional, capped at 500)"`
+	Offset  int    `json:"offset,omitempty" jsonschema:"number of transactions to skip (optional)"`
+	Fields  string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. txs.txhash,txs.messageType (optional)"`
+}
 
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "get-keys",
-		Description: "Get all keys in the keyring with addresses. Required parameter: operation (use 'list').",
-	}, getKeysHandler)
+type GetBlockchainStatusParams struct {
+	Operation string `json:"operation"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. openAuctions,totalBids (optional)"`
+}
 
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "open-auction",
-		Description: "Create a new auction. Required: issue, description, from. Optional: status, winner.",
-	}, openAuctionHandler)
+type BlockHeightParams struct{}
 
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "create-bid",
-		Description: "Place a bid on an auction. Required: auctionId, bidder, from. Optional: amount, description.",
-	}, createBidHandler)
+type GenerateMnemonicParams struct{}
 
-	mcp.AddTool(server, &mcp.Tool{
+type ImportKeyParams struct {
+	KeyName  string `json:"keyName" jsonschema:"name for the new local key; must not already exist (required)"`
+	Mnemonic string `json:"mnemonic" jsonschema:"BIP39 mnemonic of 12 to 24 words to recover the key from (required, never logged)"`
+}
+
+type NetworkCongestionParams struct {
+	Blocks int    `json:"blocks,omitempty" jsonschema:"number of recent blocks to sample (optional, default 5, max 20)"`
+	Fields string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. congestion,avgGasUsed (optional)"`
+}
+
+type ChainInfoParams struct {
+	Fields string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. chainId,staking.bond_denom (optional)"`
+}
+
+type DescribeServerParams struct {
+	Operation string `json:"operation"`
+}
+
+type GetKeysParams struct {
+	Operation string `json:"operation"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. keys.name (optional)"`
+}
+
+type VerifySignatureParams struct {
+	KeyName   string `json:"keyName,omitempty" jsonschema:"local key whose public key to verify against (keyName or address required)"`
+	Address   string `json:"address,omitempty" jsonschema:"cosmos address whose public key to verify against; it must be in the keyring or have sent a tx (keyName or address required)"`
+	Message   string `json:"message" jsonschema:"the signed message (required)"`
+	Signature string `json:"signature" jsonschema:"base64-encoded 64-byte secp256k1 ADR-036 signature, as returned by sign-message (required)"`
+}
+
+type SignMessageParams struct {
+	KeyName string `json:"keyName" jsonschema:"local key to sign with (required)"`
+	Message string `json:"message" jsonschema:"message to sign (required)"`
+}
+
+type FindKeysParams struct {
+	Prefix string `json:"prefix" jsonschema:"beginning of the key names to match (required)"`
+	Fields string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. keys.name,keys.address (optional)"`
+}
+
+type OpenAuctionParams struct {
+	Issue          string `json:"issue" jsonschema:"issue reference the auction is for (required)"`
+	Description    string `json:"description" jsonschema:"description of the work being auctioned (required)"`
+	Status         string `json:"status,omitempty" jsonschema:"initial auction status (optional, defaults to open)"`
+	Winner         string `json:"winner,omitempty" jsonschema:"winner address (optional)"`
+	From           string `json:"from" jsonschema:"cosmos address signing the transaction (required)"`
+	FeeGranter     string `json:"feeGranter,omitempty" jsonschema:"cosmos address whose fee allowance pays the fees (optional)"`
+	Fees           string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose        bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type CreateBidParams struct {
+	AuctionId      string `json:"auctionId" jsonschema:"numeric ID of the auction to bid on (required)"`
+	Bidder         string `json:"bidder" jsonschema:"cosmos address of the bidder (required)"`
+	Amount         string `json:"amount,omitempty" jsonschema:"bid amount such as 100token, or +50token to bid 50 above the current high bid (optional, defaults to 100 of the default denom)"`
+	Description    string `json:"description,omitempty" jsonschema:"bid description (optional)"`
+	From           string `json:"from" jsonschema:"cosmos address signing the transaction (required)"`
+	FeeGranter     string `json:"feeGranter,omitempty" jsonschema:"cosmos address whose fee allowance pays the fees (optional)"`
+	Fees           string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose        bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type PayParams struct {
+	From             string `json:"from" jsonschema:"cosmos address sending the tokens (required)"`
+	To               string `json:"to" jsonschema:"cosmos address receiving the tokens (required)"`
+	Amount           string `json:"amount" jsonschema:"amount to send such as 100token; a bare number uses the default denom (required)"`
+	AllowSelf        bool   `json:"allowSelf,omitempty" jsonschema:"allow from and to to be the same address, e.g. to bump the account sequence (optional)"`
+	CheckSendEnabled bool   `json:"checkSendEnabled,omitempty" jsonschema:"verify the denom is send-enabled before broadcasting (optional)"`
+	FeeGranter       string `json:"feeGranter,omitempty" jsonschema:"cosmos address whose fee allowance pays the fees (optional)"`
+	Fees             string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose          bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey   string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type CloseAuctionParams struct {
+	AuctionId      string `json:"auctionId" jsonschema:"numeric ID of the auction to update (required)"`
+	Status         string `json:"status" jsonschema:"new auction status (required)"`
+	Issue          string `json:"issue" jsonschema:"issue reference the auction is for (required)"`
+	Description    string `json:"description" jsonschema:"description of the work being auctioned (required)"`
+	Winner         string `json:"winner" jsonschema:"winner address (required)"`
+	From           string `json:"from" jsonschema:"cosmos address signing the transaction (required)"`
+	FeeGranter     string `json:"feeGranter,omitempty" jsonschema:"cosmos address whose fee allowance pays the fees (optional)"`
+	Fees           string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose        bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type CancelAuctionParams struct {
+	AuctionId      string `json:"auctionId" jsonschema:"numeric ID of the open auction to cancel (required)"`
+	From           string `json:"from" jsonschema:"cosmos address of the auction creator, signing the transaction (required)"`
+	FeeGranter     string `json:"feeGranter,omitempty" jsonschema:"cosmos address whose fee allowance pays the fees (optional)"`
+	Fees           string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose        bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type SetupTestAccountsParams struct {
+	FunderAddress  string `json:"funderAddress"`
+	Count          int    `json:"count"`
+	Amount         string `json:"amount,omitempty"`
+	Fees           string `json:"fees,omitempty"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type TestAccountResult struct {
+	KeyName string `json:"keyName"`
+	Address string `json:"address,omitempty"`
+	TxHash  string `json:"txhash,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type SetupTestAccountsResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Accounts []TestAccountResult `json:"accounts"`
+	} `json:"details"`
+}
+
+type CancelUnbondingParams struct {
+	Delegator      string `json:"delegator" jsonschema:"cosmos address of the delegator signing the transaction (required)"`
+	Validator      string `json:"validator" jsonschema:"validator operator address (cosmosvaloper1...) (required)"`
+	Amount         string `json:"amount" jsonschema:"unbonding amount to cancel such as 100stake (required)"`
+	CreationHeight string `json:"creationHeight" jsonschema:"block height at which the unbonding was created (required)"`
+	FeeGranter     string `json:"feeGranter,omitempty" jsonschema:"cosmos address whose fee allowance pays the fees (optional)"`
+	Fees           string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose        bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type RedelegateParams struct {
+	Delegator      string `json:"delegator" jsonschema:"cosmos address of the delegator signing the transaction (required)"`
+	SrcValidator   string `json:"srcValidator" jsonschema:"validator operator address to move stake from (required)"`
+	DstValidator   string `json:"dstValidator" jsonschema:"validator operator address to move stake to (required)"`
+	Amount         string `json:"amount" jsonschema:"amount to redelegate such as 100stake (required)"`
+	FeeGranter     string `json:"feeGranter,omitempty" jsonschema:"cosmos address whose fee allowance pays the fees (optional)"`
+	Fees           string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose        bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type DelegationsResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Delegator   string       `json:"delegator"`
+		Delegations []Delegation `json:"delegations"`
+	} `json:"details"`
+}
+
+type UnbondingEntry struct {
+	Validator      string `json:"validator"`
+	Balance        string `json:"balance"`
+	CreationHeight string `json:"creationHeight"`
+	CompletionTime string `json:"completionTime"`
+}
+
+type PortfolioParams struct {
+	Address string `json:"address" jsonschema:"cosmos address to summarize (required)"`
+	Fields  string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. totalsByDenom,rewards (optional)"`
+}
+
+// DenomHoldings breaks down what an address holds of one denom. Rewards are
+// DecCoins, so Rewards and Total may have a fractional part.
+type DenomHoldings struct {
+	Liquid    string `json:"liquid"`
+	Delegated string `json:"delegated"`
+	Unbonding string `json:"unbonding"`
+	Rewards   string `json:"rewards"`
+	Total     string `json:"total"`
+}
+
+type PortfolioResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Address       string                   `json:"address"`
+		Balances      []Balance                `json:"balances"`
+		Delegations   []Delegation             `json:"delegations"`
+		Unbonding     []UnbondingEntry         `json:"unbonding"`
+		Rewards       []Balance                `json:"rewards"`
+		TotalsByDenom map[string]DenomHoldings `json:"totalsByDenom"`
+		Errors        map[string]string        `json:"errors,omitempty"`
+	} `json:"details"`
+}
+
+type FeegrantsResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Grantee    string              `json:"grantee"`
+		Allowances []FeegrantAllowance `json:"allowances"`
+	} `json:"details"`
+}
+
+type QueryFeegrantsParams struct {
+	Grantee string `json:"grantee" jsonschema:"cosmos address that received the allowances (required)"`
+	Fields  string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. allowances.granter,allowances.spendLimit (optional)"`
+}
+
+type QueryDelegationsParams struct {
+	Delegator string `json:"delegator"`
+	Fields    string `json:"fields,omitempty" jsonschema:"comma-separated field paths within details to keep, e.g. delegations.validator,delegations.balance (optional)"`
+}
+
+type GrantAuthzParams struct {
+	Granter        string `json:"granter" jsonschema:"cosmos address granting the authorization and signing the transaction (required)"`
+	Grantee        string `json:"grantee" jsonschema:"cosmos address receiving the authorization (required)"`
+	MsgType        string `json:"msgType" jsonschema:"message type URL to authorize such as /cosmos.bank.v1beta1.MsgSend (required)"`
+	Expiration     string `json:"expiration,omitempty" jsonschema:"expiration as a unix timestamp in seconds (optional)"`
+	FeeGranter     string `json:"feeGranter,omitempty" jsonschema:"cosmos address whose fee allowance pays the fees (optional)"`
+	Fees           string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose        bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type RevokeAuthzParams struct {
+	Granter        string `json:"granter" jsonschema:"cosmos address that granted the authorization and signs the transaction (required)"`
+	Grantee        string `json:"grantee" jsonschema:"cosmos address whose authorization is revoked (required)"`
+	MsgType        string `json:"msgType" jsonschema:"message type URL of the authorization such as /cosmos.bank.v1beta1.MsgSend (required)"`
+	FeeGranter     string `json:"feeGranter,omitempty" jsonschema:"cosmos address whose fee allowance pays the fees (optional)"`
+	Fees           string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose        bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type GrantFeegrantParams struct {
+	Granter        string `json:"granter" jsonschema:"cosmos address whose balance pays the grantee's fees; signs the transaction (required)"`
+	Grantee        string `json:"grantee" jsonschema:"cosmos address allowed to spend the allowance on fees (required)"`
+	SpendLimit     string `json:"spendLimit,omitempty" jsonschema:"maximum total fees the grantee may spend such as 1000token (optional, unlimited when omitted)"`
+	Expiration     string `json:"expiration,omitempty" jsonschema:"RFC 3339 time the allowance expires such as 2026-12-31T00:00:00Z (optional)"`
+	Fees           string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose        bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type RevokeFeegrantParams struct {
+	Granter        string `json:"granter" jsonschema:"cosmos address that granted the allowance and signs the transaction (required)"`
+	Grantee        string `json:"grantee" jsonschema:"cosmos address whose allowance is revoked (required)"`
+	Fees           string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose        bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type ExecAuthzParams struct {
+	From           string `json:"from" jsonschema:"grantee cosmos address executing the messages (required)"`
+	TxJSON         string `json:"txJson" jsonschema:"unsigned tx JSON containing the granter's messages, as produced with --generate-only (required)"`
+	FeeGranter     string `json:"feeGranter,omitempty" jsonschema:"cosmos address whose fee allowance pays the fees (optional)"`
+	Fees           string `json:"fees,omitempty" jsonschema:"fee override such as 200token (optional)"`
+	Verbose        bool   `json:"verbose,omitempty" jsonschema:"include the full list of tx events in the response (optional)"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type TxResultResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		TxHash string    `json:"txhash"`
+		Code   int       `json:"code"`
+		Height string    `json:"height,omitempty"`
+		Action string    `json:"action,omitempty"`
+		Sender string    `json:"sender,omitempty"`
+		RawLog string    `json:"rawLog,omitempty"`
+		Events []TxEvent `json:"events,omitempty"`
+	} `json:"details"`
+}
+
+// TxEvent is one event emitted by a tx, with its attributes decoded.
+type TxEvent struct {
+	Type       string             `json:"type"`
+	Attributes []TxEventAttribute `json:"attributes"`
+}
+
+type TxEventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type BumpFeeResubmitParams struct {
+	Tool                string                 `json:"tool" jsonschema:"tx tool to rerun: pay, create-bid, open-auction or close-auction (required)"`
+	Arguments           map[string]interface{} `json:"arguments" jsonschema:"the original tool arguments (required)"`
+	FeeMultiplier       float64                `json:"feeMultiplier" jsonschema:"factor to raise the original fees by, greater than 1 and at most 10 (required)"`
+	TxHash              string                 `json:"txHash" jsonschema:"hash of the original tx; resubmission is refused unless it is on chain with a non-zero code and matches tool and arguments (required)"`
+	RejectedAtBroadcast bool                   `json:"rejectedAtBroadcast,omitempty" jsonschema:"set when the original broadcast itself returned a non-zero code, so the tx never reached a block and txHash may be unknown to the chain (optional)"`
+	IdempotencyKey      string                 `json:"idempotencyKey,omitempty" jsonschema:"key identifying this request; repeats within the TTL return the first result without resubmitting (optional)"`
+}
+
+type CreateAndFundAddressParams struct {
+	KeyName       string `json:"keyName"`
+	FunderAddress string `json:"funderAddress"`
+	Amount        string `json:"amount,omitempty"`
+}
+
+// idempotencyKey accessors let withIdempotency read the key from any tx params type.
+func (p OpenAuctionParams) idempotencyKey() string       { return p.IdempotencyKey }
+func (p CreateBidParams) idempotencyKey() string         { return p.IdempotencyKey }
+func (p PayParams) idempotencyKey() string               { return p.IdempotencyKey }
+func (p CloseAuctionParams) idempotencyKey() string      { return p.IdempotencyKey }
+func (p CancelAuctionParams) idempotencyKey() string     { return p.IdempotencyKey }
+func (p SetupTestAccountsParams) idempotencyKey() string { return p.IdempotencyKey }
+func (p CancelUnbondingParams) idempotencyKey() string   { return p.IdempotencyKey }
+func (p RedelegateParams) idempotencyKey() string        { return p.IdempotencyKey }
+func (p GrantAuthzParams) idempotencyKey() string        { return p.IdempotencyKey }
+func (p RevokeAuthzParams) idempotencyKey() string       { return p.IdempotencyKey }
+func (p ExecAuthzParams) idempotencyKey() string         { return p.IdempotencyKey }
+func (p GrantFeegrantParams) idempotencyKey() string     { return p.IdempotencyKey }
+func (p RevokeFeegrantParams) idempotencyKey() string    { return p.IdempotencyKey }
+func (p BumpFeeResubmitParams) idempotencyKey() string   { return p.IdempotencyKey }
+
+func (p OpenAuctionParams) signer() string       { return p.From }
+func (p CreateBidParams) signer() string         { return p.From }
+func (p PayParams) signer() string               { return p.From }
+func (p CloseAuctionParams) signer() string      { return p.From }
+func (p CancelAuctionParams) signer() string     { return p.From }
+func (p SetupTestAccountsParams) signer() string { return p.FunderAddress }
+func (p CancelUnbondingParams) signer() string   { return p.Delegator }
+func (p RedelegateParams) signer() string        { return p.Delegator }
+func (p GrantAuthzParams) signer() string        { return p.Granter }
+func (p RevokeAuthzParams) signer() string       { return p.Granter }
+func (p ExecAuthzParams) signer() string         { return p.From }
+func (p GrantFeegrantParams) signer() string     { return p.Granter }
+func (p RevokeFeegrantParams) signer() string    { return p.Granter }
+func (p BumpFeeResubmitParams) signer() string {
+	signer, _ := p.Arguments["from"].(string)
+	return signer
+}
+
+// The key tools are audited too; they sign or create keys rather than txs, so
+// the key name stands in for the signer.
+func (p SignMessageParams) signer() string      { return p.KeyName }
+func (p ImportKeyParams) signer() string        { return p.KeyName }
+func (p GenerateMnemonicParams) signer() string { return "" }
+
+func init() {
+	log.SetOutput(os.Stdout)
+}
+
+var validBroadcastModes = map[string]bool{"sync": true, "async": true, "block": true}
+
+func validateFlags() error {
+	if *participantsMode != "denom-owners" && *participantsMode != "involved" {
+		return fmt.Errorf("-participants must be denom-owners or involved (got %q)", *participantsMode)
+	}
+	if *pageLimit < 1 || *pageLimit > 1000 {
+		return fmt.Errorf("-page-limit must be between 1 and 1000 (got %d)", *pageLimit)
+	}
+	if *pageDelay < 0 {
+		return fmt.Errorf("-page-delay must not be negative (got %s)", *pageDelay)
+	}
+	if !denomPattern.MatchString(*defaultDenom) {
+		return fmt.Errorf("-default-denom must be a valid denom such as token (got %q)", *defaultDenom)
+	}
+	feesDerived = *defaultFees == ""
+	if feesDerived {
+		*defaultFees = defaultFeeAmount + *defaultDenom
+	}
+	if _, _, ok := parse.SplitCoin(*defaultFees); !ok {
+		return fmt.Errorf("-fees must be a coin amount such as 200token (got %q)", *defaultFees)
+	}
+	if *jsonCase != "camel" && *jsonCase != "snake" {
+		return fmt.Errorf("-json-case must be camel or snake (got %q)", *jsonCase)
+	}
+	if !validBroadcastModes[*broadcastMode] {
+		return fmt.Errorf("-broadcast-mode must be one of sync, async, block (got %q)", *broadcastMode)
+	}
+	if *maxRetryDuration < 0 {
+		return fmt.Errorf("-max-retry-duration must not be negative (got %s)", *maxRetryDuration)
+	}
+	if *retryJitter < 0 {
+		return fmt.Errorf("-retry-jitter must not be negative (got %s)", *retryJitter)
+	}
+	if *keyringPassFile != "" {
+		if _, err := os.Stat(*keyringPassFile); err != nil {
+			return fmt.Errorf("-keyring-passphrase-file: %v", err)
+		}
+	}
+	args, err := splitExtraArgs(*extraArgs)
+	if err != nil {
+		return fmt.Errorf("-extra-args: %v", err)
+	}
+	extraArgList = args
+	return nil
+}
+
+// envFlags maps the environment variables read at startup and on SIGHUP to
+// the flags they set. Flags given on the command line win at startup; a reload
+// applies whatever the environment holds at that moment.
+var envFlags = []struct{ env, flag string }{
+	{"SWECHAIN_NODE", "node"},
+	{"SWECHAIN_HOME", "home"},
+	{"SWECHAIN_KEYRING_BACKEND", "keyring-backend"},
+	{"SWECHAIN_KEYRING_PASSPHRASE_FILE", "keyring-passphrase-file"},
+	{"SWECHAIN_CHAIN_ID", "chain-id"},
+	{"SWECHAIN_FEES", "fees"},
+	{"SWECHAIN_DENOM", "default-denom"},
+	{"SWECHAIN_BROADCAST_MODE", "broadcast-mode"},
+	{"SWECHAIN_EXTRA_ARGS", "extra-args"},
+}
+
+// configMu guards the flag values that reloadConfig may change. Tool calls
+// hold it only long enough to take a config snapshot, so a reload never waits
+// on a long-running call and no call sees a half-applied configuration.
+var configMu sync.RWMutex
+
+// feesDerived records that -fees was not given and was derived from
+// -default-denom, so a reload that changes the denom derives it again.
+var feesDerived bool
+
+// config is a snapshot of the settings reloadConfig may change.
+type config struct {
+	node            string
+	home            string
+	keyringBackend  string
+	keyringPassFile string
+	chainID         string
+	fees            string
+	denom           string
+	broadcastMode   string
+	extraArgs       []string
+}
+
+// loadConfig snapshots the current settings under configMu.
+func loadConfig() config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return config{
+		node:            *nodeURL,
+		home:            *homeDir,
+		keyringBackend:  *keyringBackend,
+		keyringPassFile: *keyringPassFile,
+		chainID:         *chainID,
+		fees:            *defaultFees,
+		denom:           *defaultDenom,
+		broadcastMode:   *broadcastMode,
+		extraArgs:       extraArgList,
+	}
+}
+
+type configKey struct{}
+
+// withConfig returns a copy of ctx carrying cfg for configFrom.
+func withConfig(ctx context.Context, cfg config) context.Context {
+	return context.WithValue(ctx, configKey{}, cfg)
+}
+
+// configFrom returns the snapshot taken for the calling request, or the
+// current settings outside a tool call.
+func configFrom(ctx context.Context) config {
+	if cfg, ok := ctx.Value(configKey{}).(config); ok {
+		return cfg
+	}
+	return loadConfig()
+}
+
+// applyEnvFlags sets flags from envFlags and returns the names of those whose
+// value changed. With skipExplicit, flags set on the command line are left alone.
+func applyEnvFlags(skipExplicit bool) ([]string, error) {
+	explicit := make(map[string]bool)
+	if skipExplicit {
+		flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	}
+
+	var changed []string
+	for _, ef := range envFlags {
+		value, ok := os.LookupEnv(ef.env)
+		if !ok || explicit[ef.flag] {
+			continue
+		}
+		if flag.Lookup(ef.flag).Value.String() == value {
+			continue
+		}
+		if err := flag.Set(ef.flag, value); err != nil {
+			return changed, fmt.Errorf("%s: %v", ef.env, err)
+		}
+		changed = append(changed, ef.flag)
+	}
+	return changed, nil
+}
+
+// reloadConfig re-reads envFlags and swaps them in; calls already running keep
+// the snapshot they started with. A -fees derived from -default-denom is
+// derived again. If the new values fail validateFlags the previous ones are kept.
+func reloadConfig() {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	previous := make(map[string]string, len(envFlags))
+	for _, ef := range envFlags {
+		previous[ef.flag] = flag.Lookup(ef.flag).Value.String()
+	}
+	previousExtraArgs := extraArgList
+	previousFeesDerived := feesDerived
+
+	changed, err := applyEnvFlags(false)
+	if err == nil && feesDerived && !slices.Contains(changed, "fees") {
+		*defaultFees = ""
+	}
+	if err == nil {
+		err = validateFlags()
+	}
+	if err != nil {
+		for name, value := range previous {
+			flag.Set(name, value)
+		}
+		extraArgList = previousExtraArgs
+		feesDerived = previousFeesDerived
+		log.Printf("ERROR: Configuration reload rejected, keeping previous settings: %v", err)
+		return
+	}
+	if *defaultFees != previous["fees"] && !slices.Contains(changed, "fees") {
+		changed = append(changed, "fees")
+	}
+	if len(changed) == 0 {
+		log.Printf("INFO: Configuration reloaded, no settings changed")
+		return
+	}
+
+	readCache.Lock()
+	readCache.entries = make(map[string]readCacheEntry)
+	readCache.Unlock()
+
+	chainInfoCache.Lock()
+	chainInfoCache.fetchedAt = time.Time{}
+	chainInfoCache.Unlock()
+
+	for _, name := range changed {
+		log.Printf("INFO: Configuration reloaded: -%s changed from %q to %q", name, previous[name], flag.Lookup(name).Value.String())
+	}
+}
+
+// watchReloadSignal reloads the configuration each time the process receives SIGHUP.
+func watchReloadSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		for range signals {
+			log.Printf("INFO: Received SIGHUP, reloading configuration")
+			reloadConfig()
+		}
+	}()
+}
+
+// shellMetacharacters are rejected in -extra-args. Commands are run without a
+// shell so they would be passed through literally, which is rarely what an
+// operator writing them intended.
+const shellMetacharacters = ";|&$`<>(){}\\\n"
+
+// splitExtraArgs splits s on spaces and commas, keeping quoted sections
+// together. Unterminated quotes and shell metacharacters are errors.
+func splitExtraArgs(s string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == ',':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			if strings.ContainsRune(shellMetacharacters, r) {
+				return nil, fmt.Errorf("shell metacharacter %q is not allowed", r)
+			}
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// resolveSwechaind locates the swechaind binary. A missing binary is recorded
+// rather than fatal so the server can still start and report it to clients.
+func resolveSwechaind() {
+	if *swechaindPath != "" {
+		if err := checkExecutable(*swechaindPath); err != nil {
+			swechaindErr = fmt.Errorf("swechaind binary not found: %w", err)
+			log.Printf("WARNING: %v", swechaindErr)
+			return
+		}
+		swechaindCmd = *swechaindPath
+		log.Printf("Using swechaind override at: %s", swechaindCmd)
+		return
+	}
+
+	cmd, err := exec.LookPath("swechaind")
+	if err != nil {
+		swechaindErr = fmt.Errorf("swechaind binary not found: %w", err)
+		log.Printf("WARNING: %v", swechaindErr)
+		return
+	}
+	swechaindCmd = cmd
+	log.Printf("Found swechaind at: %s", swechaindCmd)
+}
+
+func checkExecutable(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	if info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("%s is not executable", path)
+	}
+	return nil
+}
+
+// requireSwechaind wraps a handler so it fails with a structured error when
+// the swechaind binary could not be resolved at startup.
+func requireSwechaind[In any](h mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[any], error) {
+		if swechaindErr != nil {
+			return structuredErrorResult("swechaind_not_found", swechaindErr.Error()), nil
+		}
+		return h(ctx, sess, params)
+	}
+}
+
+type idempotent interface {
+	idempotencyKey() string
+}
+
+type idempotencyEntry struct {
+	done    chan struct{}
+	result  *mcp.CallToolResultFor[any]
+	err     error
+	expires time.Time
+}
+
+// idempotencyCache remembers tx tool results by idempotency key so a retried
+// call returns the original result instead of broadcasting again.
+type idempotencyCache struct {
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+}
+
+var txIdempotency = &idempotencyCache{entries: make(map[string]*idempotencyEntry)}
+
+// do runs fn once per key within ttl. Concurrent callers with the same key wait
+// for the first call and share its result. The bool reports a cache hit.
+func (c *idempotencyCache) do(key string, ttl time.Duration, fn func() (*mcp.CallToolResultFor[any], error)) (*mcp.CallToolResultFor[any], bool, error) {
+	c.mu.Lock()
+	now := time.Now()
+	for k, e := range c.entries {
+		if !e.expires.IsZero() && now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	if e, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		<-e.done
+		return e.result, true, e.err
+	}
+	e := &idempotencyEntry{done: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	e.result, e.err = fn()
+
+	c.mu.Lock()
+	if e.err != nil || isErrorResult(e.result) {
+		// Only a successful broadcast is pinned; after a failure the next
+		// call with the same key tries again.
+		delete(c.entries, key)
+	} else {
+		e.expires = time.Now().Add(ttl)
+	}
+	c.mu.Unlock()
+	close(e.done)
+
+	return e.result, false, e.err
+}
+
+// withIdempotency wraps a tx handler so calls carrying an idempotencyKey are
+// executed at most once per -idempotency-ttl.
+func withIdempotency[In idempotent](tool string, h mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[any], error) {
+		key := strings.TrimSpace(params.Arguments.idempotencyKey())
+		if key == "" {
+			return h(ctx, sess, params)
+		}
+
+		result, cached, err := txIdempotency.do(tool+"/"+key, *idempotencyTTL, func() (*mcp.CallToolResultFor[any], error) {
+			return h(ctx, sess, params)
+		})
+		if cached {
+			logf(ctx, "INFO: Returning cached result for %s idempotency key %q", tool, key)
+		}
+		return result, err
+	}
+}
+
+// signed is implemented by tx tool params to name the signing account.
+type signed interface {
+	signer() string
+}
+
+// AuditEntry is one line of the -audit-log file. It records who signed what and
+// the outcome, never tool arguments, so secrets can't leak into the log.
+type AuditEntry struct {
+	Time   string `json:"time"`
+	Tool   string `json:"tool"`
+	From   string `json:"from"`
+	TxHash string `json:"txhash,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+const maxAuditErrorBytes = 256
+
+var auditLogMu sync.Mutex
+
+// withAudit wraps a tx or key handler so each call is appended to -audit-log.
+// It sits inside withIdempotency, so a replayed idempotent result isn't
+// logged as a second broadcast.
+func withAudit[In signed](tool string, h mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[any], error) {
+		result, err := h(ctx, sess, params)
+		if *auditLogPath == "" {
+			return result, err
+		}
+
+		entry := AuditEntry{
+			Time: time.Now().UTC().Format(time.RFC3339),
+			Tool: tool,
+			From: strings.TrimSpace(params.Arguments.signer()),
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.TxHash, entry.Error = auditOutcome(result)
+		}
+		if len(entry.Error) > maxAuditErrorBytes {
+			entry.Error = entry.Error[:maxAuditErrorBytes] + "..."
+		}
+		if werr := appendAuditEntry(*auditLogPath, entry); werr != nil {
+			logf(ctx, "WARNING: failed to write audit log entry for %s: %v", tool, werr)
+		}
+		return result, err
+	}
+}
+
+// auditOutcome extracts the txhash from a tool result, and for a failed call
+// (see isErrorResult) the compact JSON or first line of its error text. A
+// successful result's text is never recorded: for the key tools it can hold a
+// mnemonic.
+func auditOutcome(result *mcp.CallToolResultFor[any]) (string, string) {
+	if result == nil || len(result.Content) == 0 {
+		return "", ""
+	}
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		return "", ""
+	}
+
+	var hash string
+	var generic map[string]interface{}
+	isJSON := json.Unmarshal([]byte(text.Text), &generic) == nil
+	if isJSON {
+		hash = parse.String(generic, "txhash")
+		if details, ok := generic["details"].(map[string]interface{}); ok && hash == "" {
+			hash = parse.String(details, "txhash")
+		}
+	}
+	if !isErrorResult(result) {
+		return hash, ""
+	}
+
+	if isJSON {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, []byte(text.Text)); err == nil {
+			return hash, compact.String()
+		}
+	}
+	firstLine, _, _ := strings.Cut(strings.TrimSpace(text.Text), "\n")
+	return hash, firstLine
+}
+
+func appendAuditEntry(path string, entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	auditLogMu.Lock()
+	defer auditLogMu.Unlock()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// pageWindow is a validated limit/offset pair for list tools. A zero limit
+// means no limit.
+type pageWindow struct {
+	limit   int
+	offset  int
+	clamped bool
+}
+
+// newPageWindow rejects negative values and caps limit at maxListLimit.
+func newPageWindow(limit, offset int) (pageWindow, error) {
+	if limit < 0 {
+		return pageWindow{}, &FieldError{Field: "limit", Code: codeOutOfRange, Message: fmt.Sprintf("'limit' must not be negative (got %d)", limit)}
+	}
+	if offset < 0 {
+		return pageWindow{}, &FieldError{Field: "offset", Code: codeOutOfRange, Message: fmt.Sprintf("'offset' must not be negative (got %d)", offset)}
+	}
+	w := pageWindow{limit: limit, offset: offset}
+	if w.limit > maxListLimit {
+		w.limit = maxListLimit
+		w.clamped = true
+	}
+	return w, nil
+}
+
+// pageSlice returns the items selected by w.
+func pageSlice[T any](items []T, w pageWindow) []T {
+	if w.offset >= len(items) {
+		return items[:0]
+	}
+	items = items[w.offset:]
+	if w.limit > 0 && w.limit < len(items) {
+		items = items[:w.limit]
+	}
+	return items
+}
+
+// details describes the window for a response's details, or nil when the
+// caller didn't ask for pagination.
+func (w pageWindow) details(total int) map[string]interface{} {
+	if w.limit == 0 && w.offset == 0 {
+		return nil
+	}
+	details := map[string]interface{}{
+		"total":  total,
+		"limit":  w.limit,
+		"offset": w.offset,
+	}
+	if w.clamped {
+		details["note"] = fmt.Sprintf("limit was capped at %d", maxListLimit)
+	}
+	return details
+}
+
+// accountLock serialises txs signed by one address. The buffered channel is a
+// semaphore a waiter can give up on, and refs counts holders and waiters so
+// the entry can be dropped once nobody uses it.
+type accountLock struct {
+	sem  chan struct{}
+	refs int
+}
+
+// accountLocks holds the lock of every signing address with a tx in flight.
+var (
+	accountLocksMu sync.Mutex
+	accountLocks   = make(map[string]*accountLock)
+)
+
+// lockAccount waits for the lock on address and returns its release func, or
+// ctx's error if ctx is done first.
+func lockAccount(ctx context.Context, address string) (func(), error) {
+	accountLocksMu.Lock()
+	lock, ok := accountLocks[address]
+	if !ok {
+		lock = &accountLock{sem: make(chan struct{}, 1)}
+		accountLocks[address] = lock
+	}
+	lock.refs++
+	accountLocksMu.Unlock()
+
+	unref := func() {
+		accountLocksMu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(accountLocks, address)
+		}
+		accountLocksMu.Unlock()
+	}
+
+	select {
+	case lock.sem <- struct{}{}:
+		return func() {
+			<-lock.sem
+			unref()
+		}, nil
+	case <-ctx.Done():
+		unref()
+		return nil, ctx.Err()
+	}
+}
+
+// withAccountLock wraps a tx handler so calls signed by the same account run
+// one at a time, keeping their sequence numbers from interleaving. Calls from
+// different accounts still run in parallel, and a call whose ctx is cancelled
+// stops waiting.
+func withAccountLock[In signed](h mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[any], error) {
+		from := strings.TrimSpace(params.Arguments.signer())
+		if from == "" {
+			return h(ctx, sess, params)
+		}
+
+		unlock, err := lockAccount(ctx, from)
+		if err != nil {
+			return structuredErrorResult("cancelled", fmt.Sprintf("gave up waiting for another tx from %s to finish: %v", from, err)), nil
+		}
+		defer unlock()
+		return h(ctx, sess, params)
+	}
+}
+
+// Validation error codes. They are stable so clients can branch on them;
+// the accompanying message is for humans and may change.
+const (
+	codeMissingField     = "missing_field"
+	codeInvalidAddress   = "invalid_address"
+	codeInvalidCoin      = "invalid_coin"
+	codeInvalidAuctionID = "invalid_auction_id"
+	codeInvalidDenom     = "invalid_denom"
+	codeInvalidEncoding  = "invalid_encoding"
+	codeInvalidTime      = "invalid_time"
+	codeInvalidText      = "invalid_text"
+	codeOutOfRange       = "out_of_range"
+	codeNotFound         = "not_found"
+	codeAlreadyExists    = "already_exists"
+	codeInvalidValue     = "invalid_value"
+	codeUnauthorized     = "unauthorized"
+)
+
+// FieldError is one failed check on a tool parameter. It doubles as an error
+// so helpers such as resolveFees can report which field was wrong.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string { return e.Message }
+
+// validationErrors collects every failed check on a call so they can be
+// reported together instead of one per round trip.
+type validationErrors []FieldError
+
+func (v *validationErrors) add(field, code, message string) {
+	*v = append(*v, FieldError{Field: field, Code: code, Message: message})
+}
+
+// require records a missing_field error when a required value is empty.
+func (v *validationErrors) require(field, value string) {
+	if value == "" {
+		v.add(field, codeMissingField, fmt.Sprintf("'%s' parameter is required.", field))
+	}
+}
+
+// checkAddress records an error when a required cosmos address is empty or malformed.
+func (v *validationErrors) checkAddress(field, address string) {
+	if address == "" {
+		v.add(field, codeMissingField, fmt.Sprintf("'%s' parameter is required.", field))
+	} else if !isValidCosmosAddress(address) {
+		v.add(field, codeInvalidAddress, fmt.Sprintf("'%s' must be a valid cosmos address (cosmos1...).", field))
+	}
+}
+
+// checkCoin records an invalid_coin error when a non-empty amount is neither a
+// coin such as 100token nor a bare whole number, which takes the default denom.
+func (v *validationErrors) checkCoin(field, amount string) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" {
+		return
+	}
+	if _, err := parse.Amount(amount); err == nil {
+		return
+	}
+	if _, _, err := parse.CoinAmount(amount); err != nil {
+		v.add(field, codeInvalidCoin, fmt.Sprintf("'%s' must be a coin amount such as 100token or a whole number (got %q).", field, amount))
+	}
+}
+
+// checkText records an error when a free-text field contains control
+// characters other than tab. They would be stored on chain verbatim and can
+// break line-oriented or JSON consumers of the CLI output.
+func (v *validationErrors) checkText(field, value string) {
+	for _, r := range value {
+		if r != '\t' && unicode.IsControl(r) {
+			v.add(field, codeInvalidText, fmt.Sprintf("'%s' must not contain control characters (found %U).", field, r))
+			return
+		}
+	}
+}
+
+// addError records err under field, keeping the field and code of a
+// *FieldError and using code for anything else.
+func (v *validationErrors) addError(field, code string, err error) {
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		*v = append(*v, *fieldErr)
+		return
+	}
+	v.add(field, code, err.Error())
+}
+
+// validationErrorResult renders collected validation errors as {"errors": [...]}.
+func validationErrorResult(errs validationErrors) *mcp.CallToolResultFor[any] {
+	response := map[string]interface{}{
+		"errors": errs,
+	}
+	result := encodeJSON(response)
+	return &mcp.CallToolResultFor[any]{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}
+}
+
+// fieldErrorResult reports a single failed parameter check.
+func fieldErrorResult(field, code, message string) *mcp.CallToolResultFor[any] {
+	return validationErrorResult(validationErrors{{Field: field, Code: code, Message: message}})
+}
+
+// paramErrorResult reports err from a parameter helper such as newPageWindow.
+// Errors that aren't a *FieldError are reported as invalid_value with no field.
+func paramErrorResult(err error) *mcp.CallToolResultFor[any] {
+	var errs validationErrors
+	errs.addError("", codeInvalidValue, err)
+	return validationErrorResult(errs)
+}
+
+// isErrorResult reports whether result reports a failure: one marked IsError,
+// a JSON body with top-level "error" or "errors", or the plain "Error ..." and
+// "Failed ..." text that handlers return for runtime failures.
+func isErrorResult(result *mcp.CallToolResultFor[any]) bool {
+	if result == nil || result.IsError {
+		return true
+	}
+	if len(result.Content) == 0 {
+		return false
+	}
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		return false
+	}
+
+	var generic map[string]interface{}
+	if err := json.Unmarshal([]byte(text.Text), &generic); err == nil {
+		_, hasError := generic["error"]
+		_, hasErrors := generic["errors"]
+		return hasError || hasErrors
+	}
+	trimmed := strings.TrimSpace(text.Text)
+	return strings.HasPrefix(trimmed, "Error") || strings.HasPrefix(trimmed, "Failed")
+}
+
+func structuredErrorResult(code, message string) *mcp.CallToolResultFor[any] {
+	response := map[string]interface{}{
+		"error":   code,
+		"message": message,
+	}
+	result := encodeJSON(response)
+	return &mcp.CallToolResultFor[any]{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}
+}
+
+// CommandErrorKind distinguishes a command that could not be started from one
+// that ran and exited unsuccessfully.
+type CommandErrorKind string
+
+const (
+	// CommandNotExecutable means the binary is missing or not executable: a
+	// server misconfiguration rather than a chain error.
+	CommandNotExecutable CommandErrorKind = "not_executable"
+	// CommandFailed means the command ran but exited non-zero.
+	CommandFailed CommandErrorKind = "failed"
+	// CommandTimedOut means the command was killed after commandTimeout. A
+	// timed-out tx command may still have been broadcast.
+	CommandTimedOut CommandErrorKind = "timed_out"
+)
+
+// CommandError is returned by runCommand.
+type CommandError struct {
+	Kind   CommandErrorKind
+	Err    error
+	Stdout string
+	Stderr string
+}
+
+func (e *CommandError) Error() string {
+	if e.Kind == CommandNotExecutable {
+		return fmt.Sprintf("command not executable: %v", e.Err)
+	}
+	if e.Kind == CommandTimedOut {
+		return fmt.Sprintf("command timed out after %s: %v\nSTDOUT: %s\nSTDERR: %s", commandTimeout, e.Err, e.Stdout, e.Stderr)
+	}
+	return fmt.Sprintf("command failed: %v\nSTDOUT: %s\nSTDERR: %s", e.Err, e.Stdout, e.Stderr)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// classifyCommandError maps an error from cmd.Run to a CommandErrorKind.
+// *exec.Error and *os.PathError come from failing to start the process
+// (ENOENT, EACCES); anything else, including *exec.ExitError, means it ran.
+func classifyCommandError(err error) CommandErrorKind {
+	var execErr *exec.Error
+	var pathErr *os.PathError
+	if errors.As(err, &execErr) || errors.As(err, &pathErr) {
+		return CommandNotExecutable
+	}
+	return CommandFailed
+}
+
+// transientFailurePatterns match command output for failures that may succeed
+// on retry: the node being unreachable, overloaded or timing out, the
+// equivalents of HTTP 5xx and gRPC Unavailable.
+var transientFailurePatterns = []string{
+	"connection refused",
+	"connection reset",
+	"no such host",
+	"i/o timeout",
+	"timed out",
+	"deadline exceeded",
+	"code = unavailable",
+	"code = resourceexhausted",
+	"code = aborted",
+	"code = internal",
+	"unexpected eof",
+	"broken pipe",
+	"too many requests",
+	"service unavailable",
+	"bad gateway",
+	"gateway timeout",
+	"internal server error",
+	"post failed",
+}
+
+// isTransientFailure reports whether a failed command's output points to a
+// transient node or network problem worth retrying. A command killed by
+// commandTimeout is handled by runCommand before this is consulted.
+func isTransientFailure(output string) bool {
+	output = strings.ToLower(output)
+	for _, pattern := range transientFailurePatterns {
+		if strings.Contains(output, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCommandTimedOut reports whether err came from a command killed by commandTimeout.
+func isCommandTimedOut(err error) bool {
+	var cmdErr *CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Kind == CommandTimedOut
+}
+
+// isRetryableCommand reports whether args may be rerun after a failure. Only
+// queries, keyring lookups and status are: rerunning a tx command that timed
+// out or lost its connection could broadcast the same transaction twice.
+func isRetryableCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "query", "keys", "status":
+		return true
+	}
+	return false
+}
+
+// isCommandNotExecutable reports whether err came from a command that could not be started.
+func isCommandNotExecutable(err error) bool {
+	var cmdErr *CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Kind == CommandNotExecutable
+}
+
+// allowedSubcommands lists every swechaind subcommand the server runs. Anything
+// else is refused before it is executed, so a bug that lets a tool argument
+// reach the front of the argument list can't run an arbitrary subcommand.
+var allowedSubcommands = [][]string{
+	{"status"},
+	{"keys", "add"},
+	{"keys", "list"},
+	{"keys", "mnemonic"},
+	{"keys", "show"},
+	{"query", "auth", "account"},
+	{"query", "bank", "balances"},
+	{"query", "bank", "denom-owners"},
+	{"query", "bank", "params"},
+	{"query", "bank", "send-enabled"},
+	{"query", "bank", "spendable-balances"},
+	{"query", "bank", "total"},
+	{"query", "block-results"},
+	{"query", "consensus", "params"},
+	{"query", "distribution", "community-pool"},
+	{"query", "distribution", "rewards"},
+	{"query", "feegrant", "grants-by-grantee"},
+	{"query", "issuemarket", "list-auction"},
+	{"query", "issuemarket", "list-bid"},
+	{"query", "issuemarket", "params"},
+	{"query", "issuemarket", "show-auction"},
+	{"query", "mint", "annual-provisions"},
+	{"query", "mint", "inflation"},
+	{"query", "mint", "params"},
+	{"query", "node", "config"},
+	{"query", "slashing", "params"},
+	{"query", "slashing", "signing-info"},
+	{"query", "staking", "delegation"},
+	{"query", "staking", "delegations"},
+	{"query", "staking", "params"},
+	{"query", "staking", "unbonding-delegations"},
+	{"query", "staking", "validator"},
+	{"query", "staking", "validators"},
+	{"query", "tx"},
+	{"query", "txs"},
+	{"tx", "authz", "exec"},
+	{"tx", "authz", "grant"},
+	{"tx", "authz", "revoke"},
+	{"tx", "bank", "send"},
+	{"tx", "decode"},
+	{"tx", "feegrant", "grant"},
+	{"tx", "feegrant", "revoke"},
+	{"tx", "issuemarket", "create-auction"},
+	{"tx", "issuemarket", "create-bid"},
+	{"tx", "issuemarket", "update-auction"},
+	{"tx", "sign"},
+	{"tx", "staking", "cancel-unbond"},
+	{"tx", "staking", "redelegate"},
+}
+
+// checkSubcommandAllowed returns an error unless args starts with one of allowedSubcommands.
+func checkSubcommandAllowed(args []string) error {
+	for _, allowed := range allowedSubcommands {
+		if len(args) >= len(allowed) && slices.Equal(args[:len(allowed)], allowed) {
+			return nil
+		}
+	}
+	return fmt.Errorf("refusing to run swechaind subcommand %q: not on the allow-list", strings.Join(args[:min(len(args), 3)], " "))
+}
+
+// CommandRunner runs a single command, feeding it stdin, and returns its
+// stdout and stderr. A failed run should return a *CommandError so runCommand
+// can tell missing binaries and transient failures apart; any other error is
+// treated as CommandFailed.
+type CommandRunner interface {
+	Run(ctx context.Context, stdin, name string, args ...string) (string, string, error)
+}
+
+// execRunner is the CommandRunner that actually executes the command.
+type execRunner struct{}
+
+func (execRunner) Run(ctx context.Context, stdin, name string, args ...string) (string, string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	var stdout, stderr bytes.Buffer
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), stderr.String(), &CommandError{
+			Kind:   classifyCommandError(err),
+			Err:    err,
+			Stdout: stdout.String(),
+			Stderr: stderr.String(),
+		}
+	}
+	return stdout.String(), stderr.String(), nil
+}
+
+type commandRunnerKey struct{}
+
+// withCommandRunner returns a copy of ctx whose runCommand and
+// runSecretCommand calls go through runner.
+func withCommandRunner(ctx context.Context, runner CommandRunner) context.Context {
+	return context.WithValue(ctx, commandRunnerKey{}, runner)
+}
+
+// commandRunnerFrom returns the runner injected into ctx, or an execRunner
+// when there is none.
+func commandRunnerFrom(ctx context.Context) CommandRunner {
+	if runner, ok := ctx.Value(commandRunnerKey{}).(CommandRunner); ok {
+		return runner
+	}
+	return execRunner{}
+}
+
+// injectCommandRunner is receiving middleware that hands runner to every
+// request, so handlers never reach for a package-level runner.
+func injectCommandRunner(runner CommandRunner) mcp.Middleware[*mcp.ServerSession] {
+	return func(next mcp.MethodHandler[*mcp.ServerSession]) mcp.MethodHandler[*mcp.ServerSession] {
+		return func(ctx context.Context, sess *mcp.ServerSession, method string, params mcp.Params) (mcp.Result, error) {
+			return next(withCommandRunner(ctx, runner), sess, method, params)
+		}
+	}
+}
+
+// keyringPassphraseEnv names the environment variable holding the keyring
+// passphrase. It is read on every call rather than copied into a flag so the
+// passphrase never shows up in flag dumps or reload logs.
+const keyringPassphraseEnv = "SWECHAIN_KEYRING_PASSPHRASE"
+
+// keyringInput returns what to write to stdin so a keys or tx command can
+// unlock a file or os keyring, or "" when the command doesn't open the keyring
+// or no passphrase is configured. The passphrase is written twice because
+// swechaind asks for it a second time when it creates the keyring.
+func keyringInput(ctx context.Context, args []string) (string, error) {
+	if len(args) == 0 || (args[0] != "keys" && args[0] != "tx") {
+		return "", nil
+	}
+	passphrase, err := keyringPassphrase(ctx)
+	if err != nil || passphrase == "" {
+		return "", err
+	}
+	return strings.Repeat(passphrase+"\n", 2), nil
+}
+
+// keyringPassphrase returns the configured passphrase for a file or os
+// keyring, or "" for other backends or when none is configured.
+func keyringPassphrase(ctx context.Context) (string, error) {
+	cfg := configFrom(ctx)
+	if cfg.keyringBackend != "file" && cfg.keyringBackend != "os" {
+		return "", nil
+	}
+	if passphrase := os.Getenv(keyringPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	if cfg.keyringPassFile == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(cfg.keyringPassFile)
+	if err != nil {
+		return "", fmt.Errorf("reading keyring passphrase file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Enhanced command execution with retry logic. Log lines carry the request id
+// of ctx, and cancelling ctx stops the command and any further retries.
+func runCommand(ctx context.Context, name string, arg ...string) (string, error) {
+	if err := checkSubcommandAllowed(arg); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return "", err
+	}
+	stdin, err := keyringInput(ctx, arg)
+	if err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return "", err
+	}
+	if extra := configFrom(ctx).extraArgs; len(extra) > 0 {
+		arg = append(append([]string{}, arg...), extra...)
+	}
+
+	var lastErr *CommandError
+	start := time.Now()
+
+	for i := 0; i < maxRetries; i++ {
+		runCtx, cancel := context.WithTimeout(ctx, commandTimeout)
+
+		if i == 0 {
+			logf(ctx, "Executing command: %s %v", name, arg)
+		} else {
+			logf(ctx, "Retry %d: Executing command: %s %v", i+1, name, arg)
+		}
+
+		output, _, err := commandRunnerFrom(ctx).Run(runCtx, stdin, name, arg...)
+		timedOut := runCtx.Err() == context.DeadlineExceeded
+		cancel()
+
+		if err == nil {
+			result := strings.TrimSpace(output)
+			logf(ctx, "Command succeeded: %s", truncateForLog(result))
+			return result, nil
+		}
+
+		if !errors.As(err, &lastErr) {
+			lastErr = &CommandError{Kind: CommandFailed, Err: err, Stdout: output}
+		}
+		if timedOut && lastErr.Kind == CommandFailed {
+			lastErr.Kind = CommandTimedOut
+		}
+		if lastErr.Kind == CommandNotExecutable {
+			// Retrying won't make the binary appear.
+			logf(ctx, "Command could not be started: %v", lastErr.Err)
+			return "", lastErr
+		}
+		if ctx.Err() != nil {
+			logf(ctx, "Command abandoned: %v", ctx.Err())
+			return "", lastErr
+		}
+		if !isRetryableCommand(arg) {
+			logf(ctx, "Command failed and is not safe to rerun: %v\nSTDOUT: %s\nSTDERR: %s",
+				lastErr.Err, truncateForLog(lastErr.Stdout), truncateForLog(lastErr.Stderr))
+			return "", lastErr
+		}
+		if !timedOut && !isTransientFailure(lastErr.Stdout+"\n"+lastErr.Stderr) {
+			// Not-found, invalid-argument and similar failures come out the
+			// same on every attempt, so retrying only adds latency.
+			logf(ctx, "Command failed without a transient cause, not retrying: %v\nSTDOUT: %s\nSTDERR: %s",
+				lastErr.Err, truncateForLog(lastErr.Stdout), truncateForLog(lastErr.Stderr))
+			return "", lastErr
+		}
+
+		if i < maxRetries-1 {
+			backoff := retryBackoff(i)
+			if *maxRetryDuration > 0 && time.Since(start)+backoff > *maxRetryDuration {
+				logf(ctx, "Command failed and retrying would exceed -max-retry-duration %s, giving up: %v\nSTDOUT: %s\nSTDERR: %s",
+					*maxRetryDuration, lastErr.Err, truncateForLog(lastErr.Stdout), truncateForLog(lastErr.Stderr))
+				return "", lastErr
+			}
+			if !sleepContext(ctx, backoff) {
+				logf(ctx, "Command abandoned before retry: %v", ctx.Err())
+				return "", lastErr
+			}
+		}
+	}
+
+	logf(ctx, "Command failed after %d retries: %v\nSTDOUT: %s\nSTDERR: %s",
+		maxRetries, lastErr.Err, truncateForLog(lastErr.Stdout), truncateForLog(lastErr.Stderr))
+	return "", lastErr
+}
+
+// retryBackoff returns the delay before retry attempt+1: one second per
+// attempt so far, plus up to -retry-jitter of random delay.
+func retryBackoff(attempt int) time.Duration {
+	backoff := time.Duration(attempt+1) * time.Second
+	if *retryJitter > 0 {
+		backoff += mathrand.N(*retryJitter)
+	}
+	return backoff
+}
+
+// runSecretCommand runs a command whose input or output is sensitive, such as
+// a key export or import, feeding it input on stdin. Unlike runCommand it
+// never retries, never logs the command's output, and leaves any keyring
+// passphrase to the caller, since where it goes in input depends on when the
+// command opens the keyring.
+func runSecretCommand(ctx context.Context, input, name string, arg ...string) (string, string, error) {
+	if err := checkSubcommandAllowed(arg); err != nil {
+		logf(ctx, "ERROR: %v", err)
+		return "", "", err
+	}
+	if extra := configFrom(ctx).extraArgs; len(extra) > 0 {
+		arg = append(append([]string{}, arg...), extra...)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	logf(ctx, "Executing command (output not logged): %s %s", name, strings.Join(arg[:min(len(arg), 2)], " "))
+	stdout, stderr, err := commandRunnerFrom(ctx).Run(runCtx, input, name, arg...)
+	if err != nil {
+		// Don't echo the output: a partial export could still contain key material.
+		kind := CommandFailed
+		var cmdErr *CommandError
+		if errors.As(err, &cmdErr) {
+			kind, err = cmdErr.Kind, cmdErr.Err
+		}
+		return "", "", &CommandError{Kind: kind, Err: err}
+	}
+	return stdout, stderr, nil
+}
+
+// errorForLog renders err for a log line. runCommand has already logged a
+// failed command's output, truncated, so a *CommandError is shortened to its
+// kind and exit status rather than repeating the STDOUT/STDERR dump; anything
+// wrapped around it is kept. Other errors are capped with truncateForLog.
+func errorForLog(err error) string {
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		prefix := strings.TrimSuffix(err.Error(), cmdErr.Error())
+		return fmt.Sprintf("%scommand %s: %v", prefix, cmdErr.Kind, cmdErr.Err)
+	}
+	return truncateForLog(err.Error())
+}
+
+// truncateForLog caps command output written to the log; callers still get the full output.
+func truncateForLog(s string) string {
+	if *logMaxBytes <= 0 || len(s) <= *logMaxBytes {
+		return s
+	}
+	return fmt.Sprintf("%s... (%d bytes truncated)", s[:*logMaxBytes], len(s)-*logMaxBytes)
+}
+
+// runTx runs a tx command and, if it fails with an account sequence mismatch,
+// retries once with the sequence the chain reported as expected. Before
+// broadcasting it refuses when the chain looks stalled (see checkChainLiveness),
+// so a tool's own parameter checks still come first. A tx command
+// that timed out is never rerun: it may already have been broadcast, so the
+// outcome is reported as unknown instead.
+func runTx(ctx context.Context, args []string) (string, error) {
+	output, _, err := runTxAtSequence(ctx, args)
+	return output, err
+}
+
+// runTxAtSequence is runTx for callers that track the signer's sequence
+// themselves. It also returns the --sequence of the attempt whose result it
+// returns: the one in args, or the chain's expected sequence after a rerun.
+func runTxAtSequence(ctx context.Context, args []string) (string, uint64, error) {
+	sequence, _ := argSequence(args)
+	if *staleBlockThreshold > 0 {
+		if err := checkChainLiveness(ctx, *staleBlockThreshold); err != nil {
+			return "", sequence, err
+		}
+	}
+	output, err := runCommand(ctx, swechaindCmd, args...)
+	if isCommandTimedOut(err) {
+		return "", sequence, fmt.Errorf("transaction outcome unknown: the command timed out and may already have been broadcast, so it was not resubmitted; look the transaction up before sending it again: %w", err)
+	}
+
+	text := output
+	if err != nil {
+		text = err.Error()
+	} else if parse.TxCode(output) == 0 {
+		return output, sequence, nil
+	}
+
+	expected, ok := expectedSequence(text)
+	if !ok {
+		return output, sequence, err
+	}
+
+	logf(ctx, "INFO: Account sequence mismatch, retrying with --sequence %d", expected)
+	output, err = runCommand(ctx, swechaindCmd, withSequence(args, expected)...)
+	return output, expected, err
+}
+
+// argSequence returns the value of --sequence in tx args, if any.
+func argSequence(args []string) (uint64, bool) {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "--sequence" {
+			sequence, err := strconv.ParseUint(args[i+1], 10, 64)
+			return sequence, err == nil
+		}
+	}
+	return 0, false
+}
+
+// expectedSequence parses the expected sequence from a sequence-mismatch error.
+func expectedSequence(text string) (uint64, bool) {
+	match := sequenceMismatchPattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0, false
+	}
+	expected, err := strconv.ParseUint(match[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return expected, true
+}
+
+// withSequence returns a copy of args with --sequence set, replacing any existing value.
+func withSequence(args []string, sequence uint64) []string {
+	result := make([]string, 0, len(args)+2)
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--sequence" && i+1 < len(args) {
+			i++
+			continue
+		}
+		result = append(result, args[i])
+	}
+	return append(result, "--sequence", strconv.FormatUint(sequence, 10))
+}
+
+// buildTxArgs assembles a tx command with the flags shared by every transaction.
+func buildTxArgs(ctx context.Context, module, subcmd string, positional []string, from, fees string) []string {
+	cfg := configFrom(ctx)
+	args := []string{"tx", module, subcmd}
+	args = append(args, positional...)
+	args = append(args,
+		"--from", from,
+		"--keyring-backend", cfg.keyringBackend,
+		"--chain-id", cfg.chainID,
+		"--broadcast-mode", cfg.broadcastMode,
+		"--fees", fees,
+		"--yes",
+		"--output", "json",
+	)
+	return append(args, connectionFlags(ctx)...)
+}
+
+// resolveFeeGranter trims the optional feeGranter parameter, returning "" when
+// it is unset and a *FieldError when it isn't a cosmos address.
+func resolveFeeGranter(granter string) (string, error) {
+	granter = strings.TrimSpace(granter)
+	if granter != "" && !isValidCosmosAddress(granter) {
+		return "", &FieldError{Field: "feeGranter", Code: codeInvalidAddress, Message: fmt.Sprintf("'feeGranter' must be a valid cosmos address (cosmos1...) (got %q).", granter)}
+	}
+	return granter, nil
+}
+
+// withFeeGranter adds --fee-granter to tx args when granter is set, so the
+// fees are deducted from the granter's feegrant allowance instead of the signer.
+func withFeeGranter(args []string, granter string) []string {
+	if granter == "" {
+		return args
+	}
+	return append(args, "--fee-granter", granter)
+}
+
+// buildQueryArgs assembles a query command with the shared output and connection
+// flags. An empty subcmd is omitted for top-level queries such as "query txs".
+func buildQueryArgs(ctx context.Context, module, subcmd string, extra ...string) []string {
+	args := []string{"query", module}
+	if subcmd != "" {
+		args = append(args, subcmd)
+	}
+	args = append(args, extra...)
+	args = append(args,
+		"--keyring-backend", configFrom(ctx).keyringBackend,
+		"--output", "json",
+	)
+	return append(args, connectionFlags(ctx)...)
+}
+
+// buildKeysArgs assembles a keyring command. Keys commands are local, so only
+// --home is passed through, not --node.
+func buildKeysArgs(ctx context.Context, subcmd string, extra ...string) []string {
+	cfg := configFrom(ctx)
+	args := []string{"keys", subcmd}
+	args = append(args, extra...)
+	args = append(args,
+		"--keyring-backend", cfg.keyringBackend,
+		"--output", "json",
+	)
+	if cfg.home != "" {
+		args = append(args, "--home", cfg.home)
+	}
+	return args
+}
+
+// connectionFlags returns the optional --node and --home flags when configured.
+func connectionFlags(ctx context.Context) []string {
+	cfg := configFrom(ctx)
+	var args []string
+	if cfg.node != "" {
+		args = append(args, "--node", cfg.node)
+	}
+	if cfg.home != "" {
+		args = append(args, "--home", cfg.home)
+	}
+	return args
+}
+
+// Enhanced address validation
+func isValidCosmosAddress(addr string) bool {
+	addr = strings.TrimSpace(addr)
+	return strings.HasPrefix(addr, "cosmos1") && len(addr) >= 39 && len(addr) <= 45
+}
+
+func isValidValoperAddress(addr string) bool {
+	addr = strings.TrimSpace(addr)
+	return strings.HasPrefix(addr, "cosmosvaloper1") && len(addr) >= 46 && len(addr) <= 52
+}
+
+func main() {
+	flag.Parse()
+	if _, err := applyEnvFlags(true); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if err := validateFlags(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	resolveSwechaind()
+	watchReloadSignal()
+
+	server := mcp.NewServer(&mcp.Implementation{
+		Name:    "swechain-mcp-server",
+		Version: "1.0.0",
+	}, nil)
+	server.AddReceivingMiddleware(injectCommandRunner(execRunner{}))
+
+	// Register enhanced tools with consistent descriptions
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "describe-server",
+		Description: "Describe the server configuration, including the swechaind binary in use. Required parameter: operation (use 'describe').",
+	}, withRequestID("describe-server", describeServerHandler))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get-address-for-key",
+		Description: "Get the cosmos address for a specific key name. Required parameter: keyName (string).",
+	}, withRequestID("get-address-for-key", requireSwechaind(withReadCache("get-address-for-key", getAddressForKeyHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get-balance",
+		Description: "Get token balance for a specific cosmos address. Required parameter: address (string). Optional: denom to return only that denom's balance.",
+	}, withRequestID("get-balance", requireSwechaind(withReadCache("get-balance", getBalanceHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "auction-exists",
+		Description: "Check whether an auction ID exists, returning exists and, when it does, its status. Required parameter: auctionId.",
+	}, withRequestID("auction-exists", requireSwechaind(auctionExistsHandler)))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get-auctions",
+		Description: "Get several auctions by ID in one call. Missing auctions are reported per ID with found: false instead of failing the call. Required parameter: auctionIds (list of up to 50 numeric IDs).",
+	}, withRequestID("get-auctions", requireSwechaind(withReadCache("get-auctions", getAuctionsHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "batch-balances",
+		Description: "Get token balances for several cosmos addresses in one call. Required parameter: addresses (list of up to 100 addresses).",
+	}, withRequestID("batch-balances", requireSwechaind(withReadCache("batch-balances", batchBalancesHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "query-open-auctions",
+		Description: "Get all open auctions with detailed bid information and participants. Required parameter: operation (use 'list'). Optional: limit (max 500), offset.",
+	}, withRequestID("query-open-auctions", requireSwechaind(withReadCache("query-open-auctions", withEmptyList("auctions", queryOpenAuctionsHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "recent-auctions",
+		Description: "Get the most recently created auctions (highest IDs first) with their bids. Optional parameter: count (default 10, max 100).",
+	}, withRequestID("recent-auctions", requireSwechaind(withReadCache("recent-auctions", withEmptyList("auctions", recentAuctionsHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "query-all-auctions",
+		Description: "Get all auctions (open and closed) with detailed information. Required parameter: operation (use 'list'). Optional: limit (max 500), offset.",
+	}, withRequestID("query-all-auctions", requireSwechaind(withReadCache("query-all-auctions", withEmptyList("auctions", queryAllAuctionsHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "closed-auctions",
+		Description: "List closed auctions with their winners and winning amounts. Required parameter: operation (use 'list').",
+	}, withRequestID("closed-auctions", requireSwechaind(withReadCache("closed-auctions", withEmptyList("auctions", closedAuctionsHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "auctions-won",
+		Description: "List the auctions an address has won, with the winning amounts and per-denom totals. Required parameter: address (string).",
+	}, withRequestID("auctions-won", requireSwechaind(withReadCache("auctions-won", withEmptyList("auctions", auctionsWonHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "tvl-auctions",
+		Description: "Get the total value locked in open auctions: the sum of each open auction's current high bid, per denom, with the number of auctions contributing. No required parameters.",
+	}, withRequestID("tvl-auctions", requireSwechaind(withReadCache("tvl-auctions", tvlAuctionsHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "query-bids-for-auction",
+		Description: "Get bids for a specific auction or all bids. Required parameter: auctionId (string - use specific ID or 'all').",
+	}, withRequestID("query-bids-for-auction", requireSwechaind(withReadCache("query-bids-for-auction", withEmptyList("bids", queryBidsForAuctionHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "count-bids",
+		Description: "Get only the number of bids for a specific auction or all auctions. Required parameter: auctionId (string - use specific ID or 'all').",
+	}, withRequestID("count-bids", requireSwechaind(withReadCache("count-bids", countBidsHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "auction-count",
+		Description: "Get the total number of auctions from the module's stored count in a single query, falling back to paging through every auction when the node doesn't report it.",
+	}, withRequestID("auction-count", requireSwechaind(withReadCache("auction-count", auctionCountHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "bids-by-bidder",
+		Description: "Get all bids placed by an address, grouped by auction with each auction's status and per-denom totals. Required parameter: address (string).",
+	}, withRequestID("bids-by-bidder", requireSwechaind(withReadCache("bids-by-bidder", withEmptyList("auctions", bidsByBidderHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "auction-activity",
+		Description: "Get bid activity for an auction: bid count, time since the last bid, and the bid amount trend. Required parameter: auctionId (string).",
+	}, withRequestID("auction-activity", requireSwechaind(withReadCache("auction-activity", auctionActivityHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "staking-ratio",
+		Description: "Get the share of the bond denom's total supply that is bonded to active validators, as a percentage. No required parameters.",
+	}, withRequestID("staking-ratio", requireSwechaind(withReadCache("staking-ratio", stakingRatioHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "inflation",
+		Description: "Get the mint module's current inflation rate and annual provisions, or report them not available on chains without the mint module. No required parameters.",
+	}, withRequestID("inflation", requireSwechaind(withReadCache("inflation", inflationHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "query-supply",
+		Description: "Get the total supply of a denom. Optional parameter: denom (string, defaults to the -default-denom setting).",
+	}, withRequestID("query-supply", requireSwechaind(withReadCache("query-supply", querySupplyHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "send-enabled",
+		Description: "Get which denoms the bank module allows to be transferred, plus the default for unlisted denoms. Required parameter: operation (use 'list').",
+	}, withRequestID("send-enabled", requireSwechaind(withReadCache("send-enabled", sendEnabledHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "community-pool",
+		Description: "Get the distribution module's community pool balance, with decimal amounts. Required parameter: operation (use 'pool').",
+	}, withRequestID("community-pool", requireSwechaind(withReadCache("community-pool", communityPoolHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "min-gas-prices",
+		Description: "Get the minimum gas prices the node requires, so fees can be set high enough to be accepted. Reports available: false when the node doesn't expose them. No required parameters.",
+	}, withRequestID("min-gas-prices", requireSwechaind(withReadCache("min-gas-prices", minGasPricesHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "is-validator",
+		Description: "Check whether an account address operates a validator, returning its moniker and status. Required parameter: address (string).",
+	}, withRequestID("is-validator", requireSwechaind(withReadCache("is-validator", isValidatorHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "validator-details",
+		Description: "Get a validator's commission rates, self-delegation, jailed status and uptime when available. Required parameter: validator (cosmosvaloper1...).",
+	}, withRequestID("validator-details", requireSwechaind(withReadCache("validator-details", validatorDetailsHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get-sequence",
+		Description: "Get the current account sequence (nonce) for an address; never-funded accounts return 0 with exists=false. Required parameter: address (string).",
+	}, withRequestID("get-sequence", requireSwechaind(withReadCache("get-sequence", getSequenceHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "issuemarket-params",
+		Description: "Get the issuemarket module parameters (e.g. fees or minimum bids). Required parameter: operation (use 'params').",
+	}, withRequestID("issuemarket-params", requireSwechaind(withReadCache("issuemarket-params", issuemarketParamsHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "auction-leaderboard",
+		Description: "Rank the bids on an auction from highest to lowest amount, marking the current leader. Required parameter: auctionId (string).",
+	}, withRequestID("auction-leaderboard", requireSwechaind(withReadCache("auction-leaderboard", withEmptyList("entries", auctionLeaderboardHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "my-auctions",
+		Description: "List the auctions a local key created or currently leads as high bidder, to decide what to close or follow up on. Required parameter: keyName (string).",
+	}, withRequestID("my-auctions", requireSwechaind(withReadCache("my-auctions", withEmptyList("auctions", myAuctionsHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "wait-for-auction-status",
+		Description: "Poll an auction until it reaches a target status or the timeout elapses, returning its final state. Required: auctionId, status. Optional: timeout (seconds, max 600).",
+	}, withRequestID("wait-for-auction-status", requireSwechaind(waitForAuctionStatusHandler)))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "watch-auction-bids",
+		Description: "Poll an auction's bids and return as soon as new bids appear, or after maxPolls polls with none. Required: auctionId. Optional: pollInterval (seconds, max 60), maxPolls.",
+	}, withRequestID("watch-auction-bids", requireSwechaind(watchAuctionBidsHandler)))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "decode-tx",
+		Description: "Decode a base64 or JSON tx into its messages, fee, memo and signers. Required parameter: tx (string).",
+	}, withRequestID("decode-tx", requireSwechaind(decodeTxHandler)))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "creator-capacity",
+		Description: "Get an auction creator's spendable balance and whether it covers the current high bid, to gauge whether they can settle. Required parameter: auctionId (string).",
+	}, withRequestID("creator-capacity", requireSwechaind(withReadCache("creator-capacity", creatorCapacityHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "txs-by-sender",
+		Description: "List transactions sent by an address with hash, height, timestamp and first message type. Required parameter: address (string). Optional: limit (max 500), offset.",
+	}, withRequestID("txs-by-sender", requireSwechaind(withReadCache("txs-by-sender", withEmptyList("txs", txsBySenderHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "txs-in-range",
+		Description: "List transactions committed between two block heights (inclusive). Required parameters: minHeight, maxHeight (at most 1000 blocks apart).",
+	}, withRequestID("txs-in-range", requireSwechaind(withReadCache("txs-in-range", withEmptyList("txs", txsInRangeHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "fees-spent",
+		Description: "Sum the fees an address has paid across the transactions it sent, per denom. Required parameter: address (string).",
+	}, withRequestID("fees-spent", requireSwechaind(withReadCache("fees-spent", feesSpentHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get-blockchain-status",
+		Description: "Get overall blockchain statistics. Required parameter: operation (use 'status').",
+	}, withRequestID("get-blockchain-status", requireSwechaind(withReadCache("get-blockchain-status", getBlockchainStatusHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "block-height",
+		Description: "Get the latest block height and chain id as a lightweight liveness heartbeat. No parameters.",
+	}, withRequestID("block-height", requireSwechaind(blockHeightHandler)))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "network-congestion",
+		Description: "Estimate network congestion (low/medium/high) from gas used in the last few blocks, to help pick a fee level. Optional: blocks (default 5, max 20).",
+	}, withRequestID("network-congestion", requireSwechaind(withReadCache("network-congestion", networkCongestionHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "chain-info",
+		Description: "Get chain context in one call: chain id, latest block height and time, bond denom, and staking and mint (inflation) params when those modules are present. Cached for 10 minutes. No required parameters.",
+	}, withRequestID("chain-info", requireSwechaind(chainInfoHandler)))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get-keys",
+		Description: "Get all keys in the keyring with addresses. Required parameter: operation (use 'list').",
+	}, withRequestID("get-keys", requireSwechaind(withReadCache("get-keys", withEmptyList("keys", getKeysHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "verify-signature",
+		Description: "Verify a base64 secp256k1 ADR-036 signature over a message, as made by sign-message, against a key's public key. Required: message, signature, and keyName or address.",
+	}, withRequestID("verify-signature", requireSwechaind(verifySignatureHandler)))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "sign-message",
+		Description: "Sign a message off-chain with a local key as an ADR-036 MsgSignData document, returning a base64 secp256k1 signature that can't be replayed as a tx; the key never leaves the keyring and nothing is broadcast. Required: keyName, message.",
+	}, withRequestID("sign-message", requireSwechaind(withAudit("sign-message", signMessageHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "generate-mnemonic",
+		Description: "Generate a fresh 24-word BIP39 mnemonic without adding a key to the keyring. The response is sensitive: anyone holding the mnemonic controls keys derived from it. No required parameters.",
+	}, withRequestID("generate-mnemonic", requireSwechaind(withAudit("generate-mnemonic", generateMnemonicHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "import-key",
+		Description: "Add a key to the keyring recovered from a BIP39 mnemonic and return its address. The mnemonic is passed to swechaind on stdin and never logged. Required: keyName (must not already exist), mnemonic.",
+	}, withRequestID("import-key", requireSwechaind(withAudit("import-key", importKeyHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "find-keys",
+		Description: "Find keyring keys whose names start with a prefix, with their addresses. Required parameter: prefix (string).",
+	}, withRequestID("find-keys", requireSwechaind(withReadCache("find-keys", withEmptyList("keys", findKeysHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "open-auction",
+		Description: "Create a new auction. Required: issue, description, from. Optional: status, winner, fees.",
+	}, withRequestID("open-auction", requireSwechaind(withIdempotency("open-auction", withAudit("open-auction", withAccountLock(openAuctionHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create-bid",
+		Description: "Place a bid on an auction. Required: auctionId, bidder, from. Optional: amount, description, fees.",
+	}, withRequestID("create-bid", requireSwechaind(withIdempotency("create-bid", withAudit("create-bid", withAccountLock(createBidHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
 		Name:        "pay",
-		Description: "Send tokens between addresses. Required: from, to, amount (all must be valid).",
-	}, payHandler)
+		Description: "Send tokens between addresses. Required: from, to, amount (all must be valid). Optional: allowSelf, checkSendEnabled, fees.",
+	}, withRequestID("pay", requireSwechaind(withIdempotency("pay", withAudit("pay", withAccountLock(payHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "close-auction",
+		Description: "Close/update an auction. Required: auctionId, status, issue, description, winner, from. Optional: fees.",
+	}, withRequestID("close-auction", requireSwechaind(withIdempotency("close-auction", withAudit("close-auction", withAccountLock(closeAuctionHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "cancel-auction",
+		Description: "Cancel an open auction without picking a winner. Only the auction creator can cancel it. Required: auctionId, from. Optional: fees.",
+	}, withRequestID("cancel-auction", requireSwechaind(withIdempotency("cancel-auction", withAudit("cancel-auction", withAccountLock(cancelAuctionHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "bump-fee-resubmit",
+		Description: "Rerun a pay, create-bid, open-auction or close-auction call with its fees multiplied, e.g. after it failed for low fees. Required: tool, arguments, feeMultiplier, txHash. Optional: rejectedAtBroadcast. Refuses unless the original tx is on chain, failed and matches tool and arguments, or rejectedAtBroadcast confirms it never reached a block.",
+	}, withRequestID("bump-fee-resubmit", requireSwechaind(withIdempotency("bump-fee-resubmit", withAudit("bump-fee-resubmit", withAccountLock(bumpFeeResubmitHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "query-delegations",
+		Description: "Get all delegations for a delegator with validator, shares and balance. Required parameter: delegator (string).",
+	}, withRequestID("query-delegations", requireSwechaind(withReadCache("query-delegations", withEmptyList("delegations", queryDelegationsHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "portfolio",
+		Description: "Get everything an address holds in one view: liquid balances, delegations, unbonding delegations and pending staking rewards, with per-denom totals. Required parameter: address (string).",
+	}, withRequestID("portfolio", requireSwechaind(withReadCache("portfolio", portfolioHandler))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "query-feegrants",
+		Description: "List the active fee allowances granted to an address, with granter, spend limit and expiration. Required parameter: grantee (string).",
+	}, withRequestID("query-feegrants", requireSwechaind(withReadCache("query-feegrants", withEmptyList("allowances", queryFeegrantsHandler)))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "cancel-unbonding",
+		Description: "Cancel an unbonding delegation and return the tokens to the validator. Required: delegator, validator (cosmosvaloper1...), amount, creationHeight. Optional: fees, verbose.",
+	}, withRequestID("cancel-unbonding", requireSwechaind(withIdempotency("cancel-unbonding", withAudit("cancel-unbonding", withAccountLock(cancelUnbondingHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "redelegate",
+		Description: "Move delegated stake from one validator to another. Required: delegator, srcValidator, dstValidator (cosmosvaloper1...), amount. Optional: fees, verbose.",
+	}, withRequestID("redelegate", requireSwechaind(withIdempotency("redelegate", withAudit("redelegate", withAccountLock(redelegateHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "grant-authz",
+		Description: "Grant another address a generic authorization to execute a message type on your behalf. Required: granter, grantee, msgType. Optional: expiration, fees, verbose.",
+	}, withRequestID("grant-authz", requireSwechaind(withIdempotency("grant-authz", withAudit("grant-authz", withAccountLock(grantAuthzHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "revoke-authz",
+		Description: "Revoke an authorization previously granted for a message type. Required: granter, grantee, msgType. Optional: fees, verbose.",
+	}, withRequestID("revoke-authz", requireSwechaind(withIdempotency("revoke-authz", withAudit("revoke-authz", withAccountLock(revokeAuthzHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "grant-feegrant",
+		Description: "Grant another address a fee allowance so the granter pays its transaction fees. Required: granter, grantee. Optional: spendLimit, expiration (RFC 3339), fees, verbose. Tx tools accept feeGranter to use the allowance.",
+	}, withRequestID("grant-feegrant", requireSwechaind(withIdempotency("grant-feegrant", withAudit("grant-feegrant", withAccountLock(grantFeegrantHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "revoke-feegrant",
+		Description: "Revoke a fee allowance previously granted to an address. Required: granter, grantee. Optional: fees, verbose.",
+	}, withRequestID("revoke-feegrant", requireSwechaind(withIdempotency("revoke-feegrant", withAudit("revoke-feegrant", withAccountLock(revokeFeegrantHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "exec-authz",
+		Description: "Execute a granter's messages as a grantee under an existing authorization. Required: from (grantee), txJson (generated tx). Optional: fees, verbose.",
+	}, withRequestID("exec-authz", requireSwechaind(withIdempotency("exec-authz", withAudit("exec-authz", withAccountLock(execAuthzHandler))))))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "setup-test-accounts",
+		Description: "Create and fund several new test keys in one call (refused on mainnet). Required: funderAddress, count (1-20). Optional: amount, fees.",
+	}, withRequestID("setup-test-accounts", requireSwechaind(withIdempotency("setup-test-accounts", withAudit("setup-test-accounts", withAccountLock(setupTestAccountsHandler))))))
+
+	/*
+		mcp.AddTool(server, &mcp.Tool{
+			Name:        "create-and-fund-address",
+			Description: "Use only for new users. Create new key and fund it. Required: keyName, funderAddress. Optional: amount.",
+		}, requireSwechaind(createAndFundAddressHandler))
+	*/
+
+	log.Println("MCP server starting on stdio")
+	if err := server.Run(context.Background(), mcp.NewStdioTransport()); err != nil {
+		log.Printf("Server error: %v", err)
+	}
+	log.Println("MCP server stopped")
+}
+
+// Enhanced handlers with better error handling and validation
+
+// encodeJSON marshals v for a tool response, indented unless -compact-json is
+// set and with snake_case keys when -json-case is snake.
+func encodeJSON(v interface{}) []byte {
+	if *jsonCase == "snake" {
+		v = snakeCaseKeys(v)
+	}
+	var result []byte
+	if *compactJSON {
+		result, _ = json.Marshal(v)
+	} else {
+		result, _ = json.MarshalIndent(v, "", "  ")
+	}
+	return result
+}
+
+// identifierKeyPattern matches object keys that are field names rather than
+// data, such as denoms or addresses used as map keys.
+var identifierKeyPattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+// snakeCaseKeys returns v re-decoded as generic JSON with camelCase field
+// names rewritten to snake_case. Keys that aren't plain identifiers are kept,
+// so a denom like ibc/27394FB0... survives unchanged. Object keys come out
+// sorted, as for any map.
+func snakeCaseKeys(v interface{}) interface{} {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return v
+	}
+	return snakeCaseValue(generic)
+}
+
+func snakeCaseValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			if identifierKeyPattern.MatchString(key) {
+				key = toSnakeCase(key)
+			}
+			converted[key] = snakeCaseValue(item)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range value {
+			value[i] = snakeCaseValue(item)
+		}
+		return value
+	}
+	return v
+}
+
+// toSnakeCase converts a camelCase name to snake_case, keeping runs of
+// capitals together: txHash becomes tx_hash and feeGranterID fee_granter_id.
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			endOfRun := i > 0 && unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || endOfRun {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// marshalResponse renders a read tool response, pruning details down to the
+// requested comma-separated field paths when fields is non-empty.
+func marshalResponse(response interface{}, fields string) []byte {
+	raw, _ := json.Marshal(response)
+	var generic map[string]interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		result := encodeJSON(response)
+		return result
+	}
+
+	details, ok := generic["details"].(map[string]interface{})
+	if ok {
+		if paths := parseFieldPaths(fields); len(paths) > 0 {
+			details, _ = pruneFields(details, paths).(map[string]interface{})
+		}
+		generic["details"] = details
+	}
+
+	result := encodeJSON(generic)
+	return result
+}
+
+type readCacheEntry struct {
+	result    *mcp.CallToolResultFor[any]
+	fetchedAt time.Time
+}
+
+// maxReadCacheEntries caps the read cache so varying arguments can't grow it
+// without bound.
+const maxReadCacheEntries = 1000
+
+// readCache holds recent read tool results keyed by tool name and arguments.
+var readCache = struct {
+	sync.Mutex
+	entries map[string]readCacheEntry
+}{entries: make(map[string]readCacheEntry)}
+
+// withReadCache serves repeated read tool calls from memory for -read-cache-ttl,
+// marking cached responses with cached=true and their age. Every successful
+// result is stamped with fetchedAt, cached or not.
+func withReadCache[In any](tool string, h mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[any], error) {
+		if *readCacheTTL <= 0 {
+			result, err := h(ctx, sess, params)
+			if err != nil || isErrorResult(result) {
+				return result, err
+			}
+			return withFetchedAt(result, time.Now()), nil
+		}
+
+		args, _ := json.Marshal(params.Arguments)
+		key := tool + "/" + string(args)
+
+		readCache.Lock()
+		entry, ok := readCache.entries[key]
+		readCache.Unlock()
+
+		if ok {
+			age := time.Since(entry.fetchedAt)
+			if age < *readCacheTTL {
+				return withCacheMetadata(entry.result, age), nil
+			}
+		}
+
+		result, err := h(ctx, sess, params)
+		// Plain "Error ..." text results aren't IsError, so a transient failure
+		// would otherwise be served until the TTL expires.
+		if err != nil || isErrorResult(result) {
+			return result, err
+		}
+
+		fetchedAt := time.Now()
+		result = withFetchedAt(result, fetchedAt)
+		storeReadCache(key, readCacheEntry{result: result, fetchedAt: fetchedAt})
+		return result, nil
+	}
+}
+
+// storeReadCache adds entry under key, first dropping expired entries and,
+// if the cache is still full, the oldest one.
+func storeReadCache(key string, entry readCacheEntry) {
+	readCache.Lock()
+	defer readCache.Unlock()
+
+	if _, ok := readCache.entries[key]; !ok && len(readCache.entries) >= maxReadCacheEntries {
+		var oldestKey string
+		var oldest time.Time
+		for k, e := range readCache.entries {
+			if time.Since(e.fetchedAt) >= *readCacheTTL {
+				delete(readCache.entries, k)
+				continue
+			}
+			if oldestKey == "" || e.fetchedAt.Before(oldest) {
+				oldestKey, oldest = k, e.fetchedAt
+			}
+		}
+		if len(readCache.entries) >= maxReadCacheEntries {
+			delete(readCache.entries, oldestKey)
+		}
+	}
+	readCache.entries[key] = entry
+}
+
+// withFetchedAt stamps a JSON result's details with when it was fetched.
+func withFetchedAt(result *mcp.CallToolResultFor[any], fetchedAt time.Time) *mcp.CallToolResultFor[any] {
+	return withDetails(result, map[string]interface{}{
+		"fetchedAt": fetchedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// withCacheMetadata returns a copy of a cached result with cached=true and its
+// age added to the JSON details. Non-JSON results are returned unchanged.
+func withCacheMetadata(result *mcp.CallToolResultFor[any], age time.Duration) *mcp.CallToolResultFor[any] {
+	return withDetails(result, map[string]interface{}{
+		"cached": true,
+		"age":    age.Round(time.Microsecond).String(),
+	})
+}
+
+// withDetails returns a copy of result with extra keys set in its JSON details.
+// Results without a details object are returned unchanged.
+func withDetails(result *mcp.CallToolResultFor[any], extra map[string]interface{}) *mcp.CallToolResultFor[any] {
+	if result == nil || len(result.Content) != 1 || len(extra) == 0 {
+		return result
+	}
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		return result
+	}
+
+	var generic map[string]interface{}
+	if err := json.Unmarshal([]byte(text.Text), &generic); err != nil {
+		return result
+	}
+	details, ok := generic["details"].(map[string]interface{})
+	if !ok {
+		return result
+	}
+	for key, value := range extra {
+		details[key] = value
+	}
+
+	updated := encodeJSON(generic)
+	return &mcp.CallToolResultFor[any]{
+		IsError: result.IsError,
+		Content: []mcp.Content{&mcp.TextContent{Text: string(updated)}},
+	}
+}
+
+// withEmptyList marks results whose details[listKey] has no entries with a
+// top-level "empty": true and normalises the list to [], so an agent can tell
+// "nothing matched" apart from a failed query.
+func withEmptyList[In any](listKey string, h mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[any], error) {
+		result, err := h(ctx, sess, params)
+		if err != nil {
+			return result, err
+		}
+		return markEmptyList(result, listKey), nil
+	}
+}
+
+// markEmptyList applies the withEmptyList shape to a single result. Errors,
+// non-JSON results and results whose list was filtered out are left alone.
+func markEmptyList(result *mcp.CallToolResultFor[any], listKey string) *mcp.CallToolResultFor[any] {
+	if result == nil || result.IsError || len(result.Content) != 1 {
+		return result
+	}
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		return result
+	}
+
+	var generic map[string]interface{}
+	if err := json.Unmarshal([]byte(text.Text), &generic); err != nil {
+		return result
+	}
+	details, ok := generic["details"].(map[string]interface{})
+	if !ok {
+		return result
+	}
+	list, present := details[listKey]
+	if !present {
+		return result
+	}
+	if entries, ok := list.([]interface{}); list != nil && (!ok || len(entries) > 0) {
+		return result
+	}
+
+	details[listKey] = []interface{}{}
+	generic["empty"] = true
+
+	updated := encodeJSON(generic)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(updated)}},
+	}
+}
+
+type requestIDKey struct{}
+
+// newRequestID returns a short random id for correlating one tool call's logs and response.
+func newRequestID() string {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%08x", time.Now().UnixNano()&0xffffffff)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// withRequestID wraps a tool handler so each call gets a correlation id that
+// prefixes its handler logs and is returned as details.requestId.
+func withRequestID[In any](tool string, h mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[any], error) {
+		ctx = withConfig(ctx, loadConfig())
+
+		id := newRequestID()
+		ctx = context.WithValue(ctx, requestIDKey{}, id)
+
+		start := time.Now()
+		logf(ctx, "INFO: %s called", tool)
+		result, err := h(ctx, sess, params)
+		logf(ctx, "INFO: %s finished in %s", tool, time.Since(start).Round(time.Millisecond))
+
+		return withDetails(result, map[string]interface{}{"requestId": id}), err
+	}
+}
+
+// logf logs with the calling request's correlation id, when there is one.
+func logf(ctx context.Context, format string, args ...interface{}) {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		format = "[" + id + "] " + format
+	}
+	log.Printf(format, args...)
+}
+
+func parseFieldPaths(fields string) [][]string {
+	var paths [][]string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		paths = append(paths, strings.Split(field, "."))
+	}
+	return paths
+}
+
+// pruneFields keeps only the given paths in value. Arrays are transparent:
+// a path applies to every element.
+func pruneFields(value interface{}, paths [][]string) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		pruned := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			pruned = append(pruned, pruneFields(item, paths))
+		}
+		return pruned
+	case map[string]interface{}:
+		pruned := make(map[string]interface{})
+		for key, child := range v {
+			var tails [][]string
+			keepAll := false
+			for _, path := range paths {
+				if path[0] != key {
+					continue
+				}
+				if len(path) == 1 {
+					keepAll = true
+					break
+				}
+				tails = append(tails, path[1:])
+			}
+			switch {
+			case keepAll:
+				pruned[key] = child
+			case len(tails) > 0:
+				pruned[key] = pruneFields(child, tails)
+			}
+		}
+		return pruned
+	default:
+		return value
+	}
+}
+
+func describeServerHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[DescribeServerParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Describing server")
+
+	var response DescribeServerResponse
+	response.Details.Version = serverVersion
+	response.Details.SwechaindPath = swechaindCmd
+	response.Details.SwechaindFound = swechaindErr == nil
+	response.Details.ChainID = configFrom(ctx).chainID
+
+	if swechaindErr != nil {
+		response.Details.SwechaindError = swechaindErr.Error()
+		response.Summary = fmt.Sprintf("swechain-mcp-server %s: swechaind binary not found", serverVersion)
+	} else {
+		response.Summary = fmt.Sprintf("swechain-mcp-server %s using swechaind at %s (chain %s)", serverVersion, swechaindCmd, configFrom(ctx).chainID)
+	}
+
+	result := encodeJSON(response)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func getAddressForKeyHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetAddressForKeyParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Getting address for key: %s", params.Arguments.KeyName)
+
+	keyName := strings.TrimSpace(params.Arguments.KeyName)
+	if keyName == "" {
+		return fieldErrorResult("keyName", codeMissingField, "keyName parameter is required and cannot be empty."), nil
+	}
+
+	address, err := getAddressForKey(ctx, keyName)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error getting address for key %s: %v", keyName, err)}},
+		}, nil
+	}
+
+	var response KeyAddressResponse
+	response.Summary = fmt.Sprintf("Address for key '%s': %s", keyName, address)
+	response.Details.KeyName = keyName
+	response.Details.Address = address
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func getBalanceHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetBalanceParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Getting balance for address: %s", params.Arguments.Address)
+
+	address := strings.TrimSpace(params.Arguments.Address)
+	if address == "" {
+		return fieldErrorResult("address", codeMissingField, "address parameter is required and cannot be empty."), nil
+	}
+
+	if !isValidCosmosAddress(address) {
+		return fieldErrorResult("address", codeInvalidAddress, "address must be a valid cosmos address (cosmos1...)."), nil
+	}
+
+	denomFilter := strings.TrimSpace(params.Arguments.Denom)
+	if denomFilter != "" && !denomPattern.MatchString(denomFilter) {
+		return fieldErrorResult("denom", codeInvalidDenom, fmt.Sprintf("denom '%s' is not a valid denom.", denomFilter)), nil
+	}
+
+	balances, err := getBalanceForAddress(ctx, address)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error getting balance for address %s: %v", address, err)}},
+		}, nil
+	}
+
+	// An address that has never received funds has no account, and bank
+	// reports it with the same empty list as a drained one.
+	var accountExists *bool
+	if len(balances) == 0 {
+		exists, err := accountExistsOnChain(ctx, address)
+		if err != nil {
+			logf(ctx, "WARN: Could not check whether account %s exists: %v", address, errorForLog(err))
+		} else {
+			accountExists = &exists
+		}
+	}
+
+	if denomFilter != "" {
+		balances = filterBalancesByDenom(balances, denomFilter)
+	}
+
+	// Calculate total balance summary
+	var totalBalance string = "0"
+	var denom string = configFrom(ctx).denom
+	if denomFilter != "" {
+		denom = denomFilter
+	}
+
+	if len(balances) > 0 {
+		totalBalance = balances[0].Amount
+		denom = balances[0].Denom
+	}
+
+	var response BalanceResponse
+	response.Summary = fmt.Sprintf("Address %s has %s %s", address, totalBalance, denom)
+	switch {
+	case accountExists == nil:
+	case *accountExists:
+		response.Summary = fmt.Sprintf("Address %s has an account but holds no tokens", address)
+	default:
+		response.Summary = fmt.Sprintf("Address %s has never received funds and has no account on chain yet", address)
+	}
+	response.Details.Address = address
+	response.Details.Balances = balances
+	response.Details.AccountExists = accountExists
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// filterBalancesByDenom returns the balances whose denom is denom.
+func filterBalancesByDenom(balances []Balance, denom string) []Balance {
+	filtered := []Balance{}
+	for _, balance := range balances {
+		if balance.Denom == denom {
+			filtered = append(filtered, balance)
+		}
+	}
+	return filtered
+}
+
+// AddressBalances is one entry of a batch-balances response. Error is set
+// instead of Balances when the query for that address failed.
+type AddressBalances struct {
+	Address  string    `json:"address"`
+	Balances []Balance `json:"balances,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// forEachConcurrently calls fn for every index below n using at most workers
+// goroutines, and returns once all calls have finished.
+func forEachConcurrently(n, workers int, fn func(i int)) {
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// fetchBalancesConcurrently calls fetch for every address using at most
+// workers goroutines and returns the results in the order of addresses.
+func fetchBalancesConcurrently(ctx context.Context, addresses []string, workers int, fetch func(context.Context, string) ([]Balance, error)) []AddressBalances {
+	results := make([]AddressBalances, len(addresses))
+	forEachConcurrently(len(addresses), workers, func(i int) {
+		results[i].Address = addresses[i]
+		balances, err := fetch(ctx, addresses[i])
+		if err != nil {
+			results[i].Error = err.Error()
+			return
+		}
+		results[i].Balances = balances
+	})
+	return results
+}
+
+func batchBalancesHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[BatchBalancesParams]) (*mcp.CallToolResultFor[any], error) {
+	addresses := make([]string, len(params.Arguments.Addresses))
+	for i, address := range params.Arguments.Addresses {
+		addresses[i] = strings.TrimSpace(address)
+	}
+	logf(ctx, "INFO: Getting balances for %d addresses", len(addresses))
+
+	var errs validationErrors
+	if len(addresses) == 0 {
+		errs.add("addresses", codeMissingField, "'addresses' must contain at least one address.")
+	} else if len(addresses) > maxBatchAddresses {
+		errs.add("addresses", codeOutOfRange, fmt.Sprintf("'addresses' may contain at most %d addresses (got %d).", maxBatchAddresses, len(addresses)))
+	}
+	for i, address := range addresses {
+		errs.checkAddress(fmt.Sprintf("addresses[%d]", i), address)
+	}
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	results := fetchBalancesConcurrently(ctx, addresses, batchBalanceWorkers, getBalanceForAddress)
+
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+	summary := fmt.Sprintf("Fetched balances for %d addresses", len(results)-failed)
+	if failed > 0 {
+		summary += fmt.Sprintf(" (%d failed)", failed)
+	}
+
+	response := map[string]interface{}{
+		"summary": summary,
+		"details": map[string]interface{}{
+			"balances": results,
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+type AuctionExistsResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		AuctionID string `json:"auctionId"`
+		Exists    bool   `json:"exists"`
+		Status    string `json:"status,omitempty"`
+	} `json:"details"`
+}
+
+func auctionExistsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[AuctionExistsParams]) (*mcp.CallToolResultFor[any], error) {
+	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
+	logf(ctx, "INFO: Checking whether auction %s exists", auctionId)
+
+	if auctionId == "" {
+		return fieldErrorResult("auctionId", codeMissingField, "'auctionId' parameter is required."), nil
+	}
+	if _, err := strconv.ParseUint(auctionId, 10, 64); err != nil {
+		return fieldErrorResult("auctionId", codeInvalidAuctionID, "'auctionId' must be a valid number."), nil
+	}
+
+	var response AuctionExistsResponse
+	response.Details.AuctionID = auctionId
+
+	auction, err := getAuction(ctx, auctionId)
+	switch {
+	case err == nil:
+		response.Details.Exists = true
+		response.Details.Status = auction.Status
+		response.Summary = fmt.Sprintf("Auction %s exists with status %s", auctionId, auction.Status)
+	case isNotFoundError(err):
+		response.Summary = fmt.Sprintf("Auction %s does not exist", auctionId)
+	default:
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error fetching auction %s: %v", auctionId, err)}},
+		}, nil
+	}
+
+	result := marshalResponse(response, "")
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// AuctionLookup is one entry of a get-auctions response. Found is false with
+// no Error when the auction doesn't exist; Error is set when the query failed.
+type AuctionLookup struct {
+	AuctionID string   `json:"auctionId"`
+	Found     bool     `json:"found"`
+	Auction   *Auction `json:"auction,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+type GetAuctionsResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Auctions []AuctionLookup `json:"auctions"`
+	} `json:"details"`
+}
+
+// fetchAuctionsConcurrently calls fetch for every auction ID using at most
+// workers goroutines and returns the results in the order of ids.
+func fetchAuctionsConcurrently(ctx context.Context, ids []string, workers int, fetch func(context.Context, string) (Auction, error)) []AuctionLookup {
+	results := make([]AuctionLookup, len(ids))
+	forEachConcurrently(len(ids), workers, func(i int) {
+		results[i].AuctionID = ids[i]
+		auction, err := fetch(ctx, ids[i])
+		if err != nil {
+			if !isNotFoundError(err) {
+				results[i].Error = err.Error()
+			}
+			return
+		}
+		results[i].Found = true
+		results[i].Auction = &auction
+	})
+	return results
+}
+
+func getAuctionsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetAuctionsParams]) (*mcp.CallToolResultFor[any], error) {
+	ids := make([]string, len(params.Arguments.AuctionIds))
+	for i, id := range params.Arguments.AuctionIds {
+		ids[i] = strings.TrimSpace(id)
+	}
+	logf(ctx, "INFO: Getting %d auctions", len(ids))
+
+	var errs validationErrors
+	if len(ids) == 0 {
+		errs.add("auctionIds", codeMissingField, "'auctionIds' must contain at least one auction ID.")
+	} else if len(ids) > maxBatchAuctions {
+		errs.add("auctionIds", codeOutOfRange, fmt.Sprintf("'auctionIds' may contain at most %d IDs (got %d).", maxBatchAuctions, len(ids)))
+	}
+	for i, id := range ids {
+		if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+			errs.add(fmt.Sprintf("auctionIds[%d]", i), codeInvalidAuctionID, fmt.Sprintf("auction ID %q must be a valid number.", id))
+		}
+	}
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	results := fetchAuctionsConcurrently(ctx, ids, batchAuctionWorkers, getAuction)
+
+	found, failed := 0, 0
+	for _, r := range results {
+		if r.Found {
+			found++
+		} else if r.Error != "" {
+			failed++
+		}
+	}
+	summary := fmt.Sprintf("Found %d of %d auctions", found, len(results))
+	if missing := len(results) - found - failed; missing > 0 {
+		summary += fmt.Sprintf(" (%d not found)", missing)
+	}
+	if failed > 0 {
+		summary += fmt.Sprintf(" (%d failed)", failed)
+	}
+
+	var response GetAuctionsResponse
+	response.Summary = summary
+	response.Details.Auctions = results
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func queryOpenAuctionsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[QueryOpenAuctionsParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Querying open auctions")
+
+	window, err := newPageWindow(params.Arguments.Limit, params.Arguments.Offset)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	auctions, bids, err := fetchAuctionsAndBids(ctx)
+	if err != nil {
+		return fetchErrorResult("auctions and bids", err), nil
+	}
+	keys, err := getKeys(ctx)
+	if err != nil {
+		return fetchErrorResult("keys", err), nil
+	}
+	keyNames := keyNamesByAddress(keys)
+
+	// Filter open auctions
+	var openAuctions []Auction
+	for _, auction := range auctions {
+		if auction.Status == "open" {
+			openAuctions = append(openAuctions, auction)
+		}
+	}
+
+	total := len(openAuctions)
+	openAuctions = pageSlice(openAuctions, window)
+
+	participants, err := collectParticipants(ctx, openAuctions, bids, keyNames)
+	if err != nil {
+		return fetchErrorResult("participants", err), nil
+	}
+
+	// Build enhanced response
+	response := buildAuctionSummaryResponse(ctx, openAuctions, bids, participants, keyNames, "open")
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return withDetails(&mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, window.details(total)), nil
+}
+
+func queryAllAuctionsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[QueryAllAuctionsParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Querying all auctions")
+
+	window, err := newPageWindow(params.Arguments.Limit, params.Arguments.Offset)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	auctions, bids, err := fetchAuctionsAndBids(ctx)
+	if err != nil {
+		return fetchErrorResult("auctions and bids", err), nil
+	}
+	keys, err := getKeys(ctx)
+	if err != nil {
+		return fetchErrorResult("keys", err), nil
+	}
+	keyNames := keyNamesByAddress(keys)
+
+	total := len(auctions)
+	auctions = pageSlice(auctions, window)
+	participants, err := collectParticipants(ctx, auctions, bids, keyNames)
+	if err != nil {
+		return fetchErrorResult("participants", err), nil
+	}
+
+	response := buildAuctionSummaryResponse(ctx, auctions, bids, participants, keyNames, "all")
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return withDetails(&mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, window.details(total)), nil
+}
+
+type RecentAuctionsResponse struct {
+	Summary string `json:"summary"`
+	Details struct {
+		Auctions []AuctionDetail `json:"auctions"`
+	} `json:"details"`
+}
+
+func recentAuctionsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[RecentAuctionsParams]) (*mcp.CallToolResultFor[any], error) {
+	count := params.Arguments.Count
+	if count < 0 {
+		return fieldErrorResult("count", codeOutOfRange, fmt.Sprintf("'count' must not be negative (got %d)", count)), nil
+	}
+	if count == 0 {
+		count = defaultRecentAuctions
+	}
+	count = min(count, maxRecentAuctions)
+	logf(ctx, "INFO: Querying the %d most recent auctions", count)
+
+	auctions, bids, err := fetchAuctionsAndBids(ctx)
+	if err != nil {
+		return fetchErrorResult("auctions and bids", err), nil
+	}
+	keys, err := getKeys(ctx)
+	if err != nil {
+		return fetchErrorResult("keys", err), nil
+	}
+	auctions = recentAuctions(auctions, count)
+	keyNames := keyNamesByAddress(keys)
+
+	var response RecentAuctionsResponse
+	response.Details.Auctions = buildAuctionSummaryResponse(ctx, auctions, bids, nil, keyNames, "recent").Details.Auctions
+	if len(auctions) == 0 {
+		response.Summary = "No auctions found."
+	} else {
+		response.Summary = fmt.Sprintf("The %d most recent auctions, newest first (IDs %d to %d).",
+			len(auctions), auctions[0].ID, auctions[len(auctions)-1].ID)
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// recentAuctions returns up to count auctions with the highest IDs, highest
+// first. IDs are assigned sequentially, so these are the newest auctions.
+func recentAuctions(auctions []Auction, count int) []Auction {
+	sorted := slices.Clone(auctions)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID > sorted[j].ID })
+	return sorted[:min(count, len(sorted))]
+}
+
+func queryBidsForAuctionHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[QueryBidsForAuctionParams]) (*mcp.CallToolResultFor[any], error) {
+	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
+	logf(ctx, "INFO: Querying bids for auction: %s", auctionId)
+
+	if auctionId == "" {
+		return fieldErrorResult("auctionId", codeMissingField, "auctionId parameter is required. Use specific auction ID or 'all' for all bids."), nil
+	}
+
+	bids, err := fetchBids(ctx)
+	if err != nil {
+		return fetchErrorResult("bids", err), nil
+	}
+
+	// Filter by auction if not 'all'
+	if strings.ToLower(auctionId) != "all" {
+		auctionIdInt, err := strconv.Atoi(auctionId)
+		if err != nil {
+			return fieldErrorResult("auctionId", codeInvalidAuctionID, fmt.Sprintf("invalid auctionId '%s'. Must be a number or 'all'.", auctionId)), nil
+		}
+
+		var filteredBids []Bid
+		for _, bid := range bids {
+			if bid.AuctionID == auctionIdInt {
+				filteredBids = append(filteredBids, bid)
+			}
+		}
+		bids = filteredBids
+	}
+
+	var summary string
+	if strings.ToLower(auctionId) == "all" {
+		summary = fmt.Sprintf("Found %d total bids across all auctions", len(bids))
+	} else {
+		summary = fmt.Sprintf("Found %d bids for auction %s", len(bids), auctionId)
+	}
+
+	var response AuctionBidsResponse
+	response.Summary = summary
+	response.Details.Bids = bids
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func countBidsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[CountBidsParams]) (*mcp.CallToolResultFor[any], error) {
+	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
+	logf(ctx, "INFO: Counting bids for auction: %s", auctionId)
+
+	if auctionId == "" {
+		return fieldErrorResult("auctionId", codeMissingField, "auctionId parameter is required. Use specific auction ID or 'all' for all bids."), nil
+	}
+
+	bids, err := fetchBids(ctx)
+	if err != nil {
+		return fetchErrorResult("bids", err), nil
+	}
+
+	count, err := countBids(bids, auctionId)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	var summary string
+	if strings.ToLower(auctionId) == "all" {
+		summary = fmt.Sprintf("%d total bids across all auctions", count)
+	} else {
+		summary = fmt.Sprintf("%d bids for auction %s", count, auctionId)
+	}
+
+	response := map[string]interface{}{
+		"summary": summary,
+		"details": map[string]interface{}{
+			"auctionId": auctionId,
+			"count":     count,
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func auctionCountHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[AuctionCountParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Counting auctions")
+
+	source := "counter"
+	count, err := fetchAuctionCount(ctx)
+	if err != nil {
+		logf(ctx, "INFO: Auction counter unavailable, paging through auctions: %v", errorForLog(err))
+		source = "pagination"
+		auctions, err := fetchAuctions(ctx)
+		if err != nil {
+			return fetchErrorResult("auctions", err), nil
+		}
+		count = len(auctions)
+	}
+
+	response := map[string]interface{}{
+		"summary": fmt.Sprintf("%d auctions", count),
+		"details": map[string]interface{}{
+			"count":  count,
+			"source": source,
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// fetchAuctionCount asks list-auction for a one-item page with --count-total,
+// so the node reports the stored auction count in pagination.total without
+// returning every auction.
+func fetchAuctionCount(ctx context.Context) (int, error) {
+	args := buildQueryArgs(ctx, "issuemarket", "list-auction", "--page-limit", "1", "--count-total")
+	output, err := runCommand(ctx, swechaindCmd, args...)
+	if err != nil {
+		return 0, err
+	}
+	return parsePaginationTotal(output)
+}
+
+// parsePaginationTotal reads pagination.total from a list query's output.
+// Nodes that ignore --count-total omit it or report "0" alongside results,
+// which is treated as unavailable rather than as an empty list.
+func parsePaginationTotal(output string) (int, error) {
+	var response struct {
+		Pagination struct {
+			Total string `json:"total"`
+		} `json:"pagination"`
+		Auction []json.RawMessage `json:"Auction"`
+	}
+	if err := json.Unmarshal([]byte(output), &response); err != nil {
+		return 0, parse.OutputError("list-auction", err, output)
+	}
+	if response.Pagination.Total == "" {
+		return 0, fmt.Errorf("pagination.total missing from list-auction output")
+	}
+	total, err := strconv.Atoi(response.Pagination.Total)
+	if err != nil {
+		return 0, fmt.Errorf("invalid pagination.total %q: %w", response.Pagination.Total, err)
+	}
+	if total == 0 && len(response.Auction) > 0 {
+		return 0, fmt.Errorf("pagination.total not reported by node")
+	}
+	return total, nil
+}
+
+func bidsByBidderHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[BidsByBidderParams]) (*mcp.CallToolResultFor[any], error) {
+	address := strings.TrimSpace(params.Arguments.Address)
+	logf(ctx, "INFO: Querying bids by bidder: %s", address)
+
+	if address == "" {
+		return fieldErrorResult("address", codeMissingField, "address parameter is required and cannot be empty."), nil
+	}
+	if !isValidCosmosAddress(address) {
+		return fieldErrorResult("address", codeInvalidAddress, "address must be a valid cosmos address (cosmos1...)."), nil
+	}
+
+	auctions, bids, err := fetchAuctionsAndBids(ctx)
+	if err != nil {
+		return fetchErrorResult("auctions and bids", err), nil
+	}
+
+	response := buildBidsByBidderResponse(address, auctions, bids)
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func auctionActivityHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[AuctionActivityParams]) (*mcp.CallToolResultFor[any], error) {
+	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
+	logf(ctx, "INFO: Getting activity for auction: %s", auctionId)
+
+	auctionIdInt, err := strconv.Atoi(auctionId)
+	if err != nil {
+		return fieldErrorResult("auctionId", codeInvalidAuctionID, "'auctionId' must be a valid number."), nil
+	}
+
+	bids, err := fetchBids(ctx)
+	if err != nil {
+		return fetchErrorResult("bids", err), nil
+	}
+	auctionBids := filterBidsByAuction(bids, auctionIdInt)
+
+	// Bid records carry no timestamps, so look up each signer's create-bid txs.
+	var bidTimes []time.Time
+	seen := make(map[string]bool)
+	for _, bid := range auctionBids {
+		if bid.Creator == "" || seen[bid.Creator] {
+			continue
+		}
+		seen[bid.Creator] = true
+
+		txResponses, err := fetchTxResponses(ctx, "--events", fmt.Sprintf("message.sender=%s", bid.Creator))
+		if err != nil {
+			return fetchErrorResult("bid txs for "+bid.Creator, err), nil
+		}
+		bidTimes = append(bidTimes, bidTxTimes(txResponses, auctionIdInt)...)
+	}
+
+	response := buildAuctionActivityResponse(auctionIdInt, auctionBids, bidTimes, time.Now())
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func querySupplyHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[QuerySupplyParams]) (*mcp.CallToolResultFor[any], error) {
+	denom := strings.TrimSpace(params.Arguments.Denom)
+	if denom == "" {
+		denom = configFrom(ctx).denom
+	}
+	logf(ctx, "INFO: Querying supply of: %s", denom)
+
+	if !denomPattern.MatchString(denom) {
+		return fieldErrorResult("denom", codeInvalidDenom, fmt.Sprintf("invalid denom %q.", denom)), nil
+	}
+
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "bank", "total", "--denom", denom)...)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying supply of %s: %v", denom, err)}},
+		}, nil
+	}
+
+	amount, err := parse.Supply(output, denom)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error parsing supply of %s: %v", denom, err)}},
+		}, nil
+	}
+
+	response := map[string]interface{}{
+		"summary": fmt.Sprintf("Total supply of %s is %s", denom, amount),
+		"details": map[string]interface{}{
+			"denom":  denom,
+			"amount": amount,
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func isValidatorHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[IsValidatorParams]) (*mcp.CallToolResultFor[any], error) {
+	address := strings.TrimSpace(params.Arguments.Address)
+	logf(ctx, "INFO: Checking whether %s is a validator", address)
+
+	if !isValidCosmosAddress(address) {
+		return fieldErrorResult("address", codeInvalidAddress, "address must be a valid cosmos address (cosmos1...)."), nil
+	}
+
+	valoper, err := convertBech32Prefix(address, "cosmosvaloper")
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error converting %s to a validator address: %v", address, err)}},
+		}, nil
+	}
+
+	var response IsValidatorResponse
+	response.Details.Address = address
+	response.Details.ValoperAddress = valoper
+
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "staking", "validator", valoper)...)
+	if err != nil {
+		if !isNotFoundError(err) {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying validator %s: %v", valoper, err)}},
+			}, nil
+		}
+		response.Summary = fmt.Sprintf("Address %s is not a validator operator", address)
+	} else {
+		validator, err := parse.Validator(output)
+		if err != nil {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error parsing validator %s: %v", valoper, err)}},
+			}, nil
+		}
+		response.Details.IsValidator = true
+		response.Details.Moniker = validator.Moniker
+		response.Details.Status = validator.Status
+		response.Details.Jailed = validator.Jailed
+		response.Summary = fmt.Sprintf("Address %s operates validator %q (%s)", address, validator.Moniker, validator.Status)
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func validatorDetailsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[ValidatorDetailsParams]) (*mcp.CallToolResultFor[any], error) {
+	valoper := strings.TrimSpace(params.Arguments.Validator)
+	logf(ctx, "INFO: Getting details for validator: %s", valoper)
+
+	if !isValidValoperAddress(valoper) {
+		return fieldErrorResult("validator", codeInvalidAddress, "'validator' must be a valid validator operator address (cosmosvaloper1...)."), nil
+	}
+
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "staking", "validator", valoper)...)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying validator %s: %v", valoper, err)}},
+		}, nil
+	}
+	validator, err := parse.Validator(output)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error parsing validator %s: %v", valoper, err)}},
+		}, nil
+	}
+
+	var response ValidatorDetailsResponse
+	response.Details.ValidatorInfo = validator
+
+	// Self-delegation and uptime are best effort; the core details stand on their own.
+	if operator, err := convertBech32Prefix(valoper, "cosmos"); err == nil {
+		if output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "staking", "delegation", operator, valoper)...); err == nil {
+			response.Details.SelfDelegation, _ = parseDelegationAmount(output)
+		} else {
+			logf(ctx, "WARNING: could not query self-delegation for %s: %v", valoper, errorForLog(err))
+		}
+	}
+	if validator.ConsensusPubKey != "" {
+		signingInfo, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "slashing", "signing-info", validator.ConsensusPubKey)...)
+		if err == nil {
+			var slashingParams string
+			slashingParams, err = runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "slashing", "params")...)
+			if err == nil {
+				response.Details.Uptime, err = parse.ValidatorUptime(signingInfo, slashingParams)
+			}
+		}
+		if err != nil {
+			logf(ctx, "WARNING: could not determine uptime for %s: %v", valoper, errorForLog(err))
+		}
+	}
+
+	response.Summary = fmt.Sprintf("Validator %q (%s) charges %s commission (max %s, max change %s)",
+		validator.Moniker, validator.Status, validator.CommissionRate, validator.CommissionMaxRate, validator.CommissionMaxChangeRate)
+	if validator.Jailed {
+		response.Summary += " and is jailed"
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func getSequenceHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetSequenceParams]) (*mcp.CallToolResultFor[any], error) {
+	address := strings.TrimSpace(params.Arguments.Address)
+	logf(ctx, "INFO: Getting sequence for: %s", address)
+
+	if !isValidCosmosAddress(address) {
+		return fieldErrorResult("address", codeInvalidAddress, "address must be a valid cosmos address (cosmos1...)."), nil
+	}
+
+	exists := true
+	sequence, err := getAccountSequence(ctx, address)
+	if err != nil {
+		if !isNotFoundError(err) {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error getting sequence for %s: %v", address, err)}},
+			}, nil
+		}
+		exists = false
+		sequence = 0
+	}
+
+	summary := fmt.Sprintf("Address %s has sequence %d", address, sequence)
+	if !exists {
+		summary = fmt.Sprintf("Address %s has no on-chain account yet (sequence 0)", address)
+	}
+
+	response := map[string]interface{}{
+		"summary": summary,
+		"details": map[string]interface{}{
+			"address":  address,
+			"sequence": sequence,
+			"exists":   exists,
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func issuemarketParamsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetIssuemarketParamsParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Querying issuemarket params")
+
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "issuemarket", "params")...)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying issuemarket params: %v", err)}},
+		}, nil
+	}
+
+	moduleParams, err := parse.ModuleParams(output)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error parsing issuemarket params: %v", err)}},
+		}, nil
+	}
+
+	response := map[string]interface{}{
+		"summary": summarizeModuleParams("issuemarket", moduleParams),
+		"details": map[string]interface{}{
+			"params": moduleParams,
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func auctionLeaderboardHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[AuctionLeaderboardParams]) (*mcp.CallToolResultFor[any], error) {
+	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
+	logf(ctx, "INFO: Building leaderboard for auction: %s", auctionId)
+
+	auctionIdInt, err := strconv.Atoi(auctionId)
+	if err != nil {
+		return fieldErrorResult("auctionId", codeInvalidAuctionID, "'auctionId' must be a valid number."), nil
+	}
+
+	bids, err := fetchBids(ctx)
+	if err != nil {
+		return fetchErrorResult("bids", err), nil
+	}
+	auctionBids := filterBidsByAuction(bids, auctionIdInt)
+
+	response := buildAuctionLeaderboardResponse(auctionIdInt, auctionBids)
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func myAuctionsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[MyAuctionsParams]) (*mcp.CallToolResultFor[any], error) {
+	keyName := strings.TrimSpace(params.Arguments.KeyName)
+	logf(ctx, "INFO: Listing auctions for key: %s", keyName)
+
+	if keyName == "" {
+		return fieldErrorResult("keyName", codeMissingField, "keyName parameter is required and cannot be empty."), nil
+	}
+
+	address, err := getAddressForKey(ctx, keyName)
+	if err != nil {
+		return fieldErrorResult("keyName", codeNotFound, fmt.Sprintf("key '%s' was not found in the keyring: %v", keyName, err)), nil
+	}
+
+	auctions, bids, err := fetchAuctionsAndBids(ctx)
+	if err != nil {
+		return fetchErrorResult("auctions and bids", err), nil
+	}
+
+	response := buildMyAuctionsResponse(keyName, address, auctions, bids)
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func waitForAuctionStatusHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[WaitForAuctionStatusParams]) (*mcp.CallToolResultFor[any], error) {
+	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
+	target := parse.NormalizeStatus(params.Arguments.Status)
+	logf(ctx, "INFO: Waiting for auction %s to reach status %q", auctionId, target)
+
+	if _, err := strconv.Atoi(auctionId); err != nil {
+		return fieldErrorResult("auctionId", codeInvalidAuctionID, "'auctionId' must be a valid number."), nil
+	}
+	if target == "" {
+		return fieldErrorResult("status", codeMissingField, "'status' parameter is required."), nil
+	}
+
+	timeout := defaultWaitTimeout
+	if params.Arguments.Timeout > 0 {
+		timeout = time.Duration(params.Arguments.Timeout) * time.Second
+	}
+	if timeout > maxWaitTimeout {
+		timeout = maxWaitTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	polls := 0
+	var auction Auction
+	for {
+		polls++
+		var err error
+		auction, err = getAuction(ctx, auctionId)
+		if err != nil {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error fetching auction %s: %v", auctionId, err)}},
+			}, nil
+		}
+		if auction.Status == target || !sleepContext(ctx, waitPollInterval) {
+			break
+		}
+	}
+
+	reached := auction.Status == target
+	var summary string
+	if reached {
+		summary = fmt.Sprintf("Auction %s reached status %q after %s", auctionId, target, time.Since(start).Round(time.Second))
+	} else {
+		summary = fmt.Sprintf("Auction %s is still %q; stopped waiting for %q after %s", auctionId, auction.Status, target, time.Since(start).Round(time.Second))
+	}
+
+	response := map[string]interface{}{
+		"summary": summary,
+		"details": map[string]interface{}{
+			"auction": auction,
+			"reached": reached,
+			"polls":   polls,
+		},
+	}
+
+	result := encodeJSON(response)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func watchAuctionBidsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[WatchAuctionBidsParams]) (*mcp.CallToolResultFor[any], error) {
+	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
+	logf(ctx, "INFO: Watching auction %s for new bids", auctionId)
+
+	auctionIdInt, err := strconv.Atoi(auctionId)
+	if err != nil {
+		return fieldErrorResult("auctionId", codeInvalidAuctionID, "'auctionId' must be a valid number."), nil
+	}
+
+	interval := waitPollInterval
+	if params.Arguments.PollInterval > 0 {
+		interval = time.Duration(params.Arguments.PollInterval) * time.Second
+	}
+	if interval > maxWatchInterval {
+		interval = maxWatchInterval
+	}
+	maxPolls := defaultWatchPolls
+	if params.Arguments.MaxPolls > 0 {
+		maxPolls = params.Arguments.MaxPolls
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, maxWaitTimeout)
+	defer cancel()
+
+	fetch := func() ([]Bid, error) {
+		bids, err := fetchBids(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return filterBidsByAuction(bids, auctionIdInt), nil
+	}
+
+	// Without a baseline every existing bid would look new, so a failed
+	// first fetch fails the watch.
+	start := time.Now()
+	baseline, err := fetch()
+	if err != nil {
+		return fetchErrorResult("bids", err), nil
+	}
+	polls := 1
+	var appeared []Bid
+	for polls < maxPolls && sleepContext(ctx, interval) {
+		polls++
+		current, err := fetch()
+		if err != nil {
+			logf(ctx, "Error polling bids for auction %s, will retry: %v", auctionId, errorForLog(err))
+			continue
+		}
+		appeared = newBids(baseline, current)
+		if len(appeared) > 0 {
+			break
+		}
+	}
+
+	var summary string
+	if len(appeared) > 0 {
+		summary = fmt.Sprintf("%d new bids on auction %s after %s", len(appeared), auctionId, time.Since(start).Round(time.Second))
+	} else {
+		summary = fmt.Sprintf("No new bids on auction %s after %d polls (%s)", auctionId, polls, time.Since(start).Round(time.Second))
+	}
+
+	response := map[string]interface{}{
+		"summary": summary,
+		"details": map[string]interface{}{
+			"auctionId": auctionIdInt,
+			"newBids":   appeared,
+			"polls":     polls,
+		},
+	}
+
+	result := encodeJSON(response)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// filterBidsByAuction returns the bids placed on auctionID.
+func filterBidsByAuction(bids []Bid, auctionID int) []Bid {
+	var filtered []Bid
+	for _, bid := range bids {
+		if bid.AuctionID == auctionID {
+			filtered = append(filtered, bid)
+		}
+	}
+	return filtered
+}
+
+// newBids returns the bids in current that aren't in baseline. Bids have no
+// ID, so identical bids are matched by count: a second copy of an existing
+// bid counts as new.
+func newBids(baseline, current []Bid) []Bid {
+	seen := make(map[Bid]int, len(baseline))
+	for _, bid := range baseline {
+		seen[bid]++
+	}
+	appeared := []Bid{}
+	for _, bid := range current {
+		if seen[bid] > 0 {
+			seen[bid]--
+			continue
+		}
+		appeared = append(appeared, bid)
+	}
+	return appeared
+}
+
+func decodeTxHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[DecodeTxParams]) (*mcp.CallToolResultFor[any], error) {
+	raw := strings.TrimSpace(params.Arguments.Tx)
+	logf(ctx, "INFO: Decoding tx (%d bytes)", len(raw))
+
+	if raw == "" {
+		return fieldErrorResult("tx", codeMissingField, "'tx' parameter is required and cannot be empty."), nil
+	}
+
+	txJSON := raw
+	if !strings.HasPrefix(raw, "{") {
+		if _, err := base64.StdEncoding.DecodeString(raw); err != nil {
+			return fieldErrorResult("tx", codeInvalidEncoding, "'tx' must be base64-encoded tx bytes or tx JSON."), nil
+		}
+		output, err := runCommand(ctx, swechaindCmd, "tx", "decode", raw)
+		if err != nil {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error decoding tx: %v", err)}},
+			}, nil
+		}
+		txJSON = output
+	}
+
+	var tx map[string]interface{}
+	if err := json.Unmarshal([]byte(txJSON), &tx); err != nil {
+		return fieldErrorResult("tx", codeInvalidEncoding, fmt.Sprintf("tx is not valid JSON: %v", err)), nil
+	}
+
+	response := buildDecodedTxResponse(tx)
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func creatorCapacityHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[CreatorCapacityParams]) (*mcp.CallToolResultFor[any], error) {
+	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
+	logf(ctx, "INFO: Checking creator capacity for auction: %s", auctionId)
+
+	auctionIdInt, err := strconv.Atoi(auctionId)
+	if err != nil {
+		return fieldErrorResult("auctionId", codeInvalidAuctionID, "'auctionId' must be a valid number."), nil
+	}
+
+	auction, err := getAuction(ctx, auctionId)
+	if err != nil {
+		if isNotFoundError(err) {
+			return fieldErrorResult("auctionId", codeNotFound, fmt.Sprintf("auction %s does not exist.", auctionId)), nil
+		}
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error fetching auction %s: %v", auctionId, err)}},
+		}, nil
+	}
+
+	balances, err := getSpendableBalances(ctx, auction.Creator)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error getting spendable balance for %s: %v", auction.Creator, err)}},
+		}, nil
+	}
+
+	bids, err := fetchBids(ctx)
+	if err != nil {
+		return fetchErrorResult("bids", err), nil
+	}
+	auctionBids := filterBidsByAuction(bids, auctionIdInt)
+
+	response := buildCreatorCapacityResponse(auction, balances, auctionBids)
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func closedAuctionsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[ClosedAuctionsParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Listing closed auctions")
+
+	auctions, bids, err := fetchAuctionsAndBids(ctx)
+	if err != nil {
+		return fetchErrorResult("auctions and bids", err), nil
+	}
+
+	response := buildClosedAuctionsResponse(auctions, bids)
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func sendEnabledHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[SendEnabledParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Querying bank send-enabled flags")
+
+	defaultEnabled, denoms, err := fetchSendEnabled(ctx)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying send-enabled flags: %v", err)}},
+		}, nil
+	}
+
+	var response SendEnabledResponse
+	response.Details.DefaultSendEnabled = defaultEnabled
+	response.Details.Denoms = denoms
+
+	disabled := 0
+	for _, entry := range denoms {
+		if !entry.Enabled {
+			disabled++
+		}
+	}
+	response.Summary = fmt.Sprintf("%d denoms have explicit send-enabled flags (%d disabled); unlisted denoms default to enabled=%t",
+		len(denoms), disabled, defaultEnabled)
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func feesSpentHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[FeesSpentParams]) (*mcp.CallToolResultFor[any], error) {
+	address := strings.TrimSpace(params.Arguments.Address)
+	logf(ctx, "INFO: Summing fees spent by: %s", address)
+
+	if address == "" {
+		return fieldErrorResult("address", codeMissingField, "address parameter is required and cannot be empty."), nil
+	}
+	if !isValidCosmosAddress(address) {
+		return fieldErrorResult("address", codeInvalidAddress, "address must be a valid cosmos address (cosmos1...)."), nil
+	}
+
+	txResponses, err := fetchTxResponses(ctx, "--events", fmt.Sprintf("message.sender=%s", address))
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying txs for sender %s: %v", address, err)}},
+		}, nil
+	}
+
+	totals := parse.SumTxFees(txResponses)
+
+	parts := make([]string, 0, len(totals))
+	for denom, total := range totals {
+		parts = append(parts, total+denom)
+	}
+	sort.Strings(parts)
+	spent := strings.Join(parts, ", ")
+	if spent == "" {
+		spent = "nothing"
+	}
+
+	response := map[string]interface{}{
+		"summary": fmt.Sprintf("Address %s spent %s in fees across %d transactions", address, spent, len(txResponses)),
+		"details": map[string]interface{}{
+			"address":       address,
+			"txCount":       len(txResponses),
+			"totalsByDenom": totals,
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func auctionsWonHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[AuctionsWonParams]) (*mcp.CallToolResultFor[any], error) {
+	address := strings.TrimSpace(params.Arguments.Address)
+	logf(ctx, "INFO: Listing auctions won by: %s", address)
+
+	if address == "" {
+		return fieldErrorResult("address", codeMissingField, "address parameter is required and cannot be empty."), nil
+	}
+	if !isValidCosmosAddress(address) {
+		return fieldErrorResult("address", codeInvalidAddress, "address must be a valid cosmos address (cosmos1...)."), nil
+	}
+
+	auctions, bids, err := fetchAuctionsAndBids(ctx)
+	if err != nil {
+		return fetchErrorResult("auctions and bids", err), nil
+	}
+
+	response := buildAuctionsWonResponse(address, auctions, bids)
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func tvlAuctionsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[TVLAuctionsParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Computing total value locked in open auctions")
+
+	auctions, bids, err := fetchAuctionsAndBids(ctx)
+	if err != nil {
+		return fetchErrorResult("auctions and bids", err), nil
+	}
+
+	response := buildTVLAuctionsResponse(auctions, bids)
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func communityPoolHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[CommunityPoolParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Querying community pool")
+
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "distribution", "community-pool")...)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying community pool: %v", err)}},
+		}, nil
+	}
+
+	pool, err := parse.CommunityPool(output)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error parsing community pool: %v", err)}},
+		}, nil
+	}
+
+	parts := make([]string, 0, len(pool))
+	for _, coin := range pool {
+		parts = append(parts, coin.Amount+coin.Denom)
+	}
+	summary := "The community pool is empty"
+	if len(parts) > 0 {
+		summary = fmt.Sprintf("The community pool holds %s", strings.Join(parts, ", "))
+	}
+
+	response := map[string]interface{}{
+		"summary": summary,
+		"details": map[string]interface{}{
+			"pool": pool,
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// minGasPricesPattern finds the minimum-gas-prices setting in app.toml.
+var minGasPricesPattern = regexp.MustCompile(`(?m)^\s*minimum-gas-prices\s*=\s*"([^"]*)"`)
+
+func minGasPricesHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[MinGasPricesParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Querying minimum gas prices")
+
+	prices, source, err := fetchMinGasPrices(ctx)
+	if err != nil {
+		logf(ctx, "INFO: Minimum gas prices unavailable: %v", errorForLog(err))
+		response := map[string]interface{}{
+			"summary": "The node's minimum gas prices are not available",
+			"details": map[string]interface{}{
+				"available": false,
+				"reason":    err.Error(),
+			},
+		}
+		result := marshalResponse(response, params.Arguments.Fields)
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+		}, nil
+	}
+
+	parts := make([]string, 0, len(prices))
+	for _, coin := range prices {
+		parts = append(parts, coin.Amount+coin.Denom)
+	}
+	summary := "The node accepts transactions with any gas price"
+	if len(parts) > 0 {
+		summary = fmt.Sprintf("The node requires gas prices of at least %s", strings.Join(parts, ", "))
+	}
+
+	response := map[string]interface{}{
+		"summary": summary,
+		"details": map[string]interface{}{
+			"available":        true,
+			"minimumGasPrices": prices,
+			"source":           source,
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// fetchMinGasPrices asks the node for its configured minimum gas prices and,
+// if the node doesn't serve that query, falls back to app.toml under -home.
+// The returned source is "node" or the app.toml path.
+func fetchMinGasPrices(ctx context.Context) ([]Balance, string, error) {
+	output, queryErr := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "node", "config")...)
+	if queryErr == nil {
+		prices, err := parse.NodeMinGasPrices(output)
+		if err != nil {
+			return nil, "", err
+		}
+		return prices, "node", nil
+	}
+
+	if configFrom(ctx).home == "" {
+		return nil, "", fmt.Errorf("node config query failed and -home is not set: %w", queryErr)
+	}
+	path := filepath.Join(configFrom(ctx).home, "config", "app.toml")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("node config query failed and %s is unreadable: %w", path, err)
+	}
+	match := minGasPricesPattern.FindSubmatch(content)
+	if match == nil {
+		return nil, "", fmt.Errorf("minimum-gas-prices not set in %s", path)
+	}
+	prices, err := parse.DecCoinsString(string(match[1]))
+	if err != nil {
+		return nil, "", err
+	}
+	return prices, path, nil
+}
+
+func txsBySenderHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[TxsBySenderParams]) (*mcp.CallToolResultFor[any], error) {
+	address := strings.TrimSpace(params.Arguments.Address)
+	logf(ctx, "INFO: Querying txs by sender: %s", address)
+
+	if address == "" {
+		return fieldErrorResult("address", codeMissingField, "address parameter is required and cannot be empty."), nil
+	}
+	if !isValidCosmosAddress(address) {
+		return fieldErrorResult("address", codeInvalidAddress, "address must be a valid cosmos address (cosmos1...)."), nil
+	}
+
+	window, err := newPageWindow(params.Arguments.Limit, params.Arguments.Offset)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	txs, err := fetchTxSummaries(ctx, "--events", fmt.Sprintf("message.sender=%s", address))
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying txs for sender %s: %v", address, err)}},
+		}, nil
+	}
+
+	var response TxsBySenderResponse
+	response.Summary = fmt.Sprintf("Found %d transactions sent by %s", len(txs), address)
+	response.Details.Sender = address
+	response.Details.Txs = pageSlice(txs, window)
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return withDetails(&mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, window.details(len(txs))), nil
+}
+
+// checkHeightRange validates an inclusive block height range for txs-in-range.
+func checkHeightRange(minHeight, maxHeight int64) validationErrors {
+	var errs validationErrors
+	if minHeight < 1 {
+		errs.add("minHeight", codeOutOfRange, "'minHeight' must be at least 1.")
+	}
+	if maxHeight < 1 {
+		errs.add("maxHeight", codeOutOfRange, "'maxHeight' must be at least 1.")
+	}
+	if len(errs) == 0 && maxHeight < minHeight {
+		errs.add("maxHeight", codeOutOfRange, "'maxHeight' must not be below 'minHeight'.")
+	}
+	if len(errs) == 0 && maxHeight-minHeight >= maxTxRangeBlocks {
+		errs.add("maxHeight", codeOutOfRange, fmt.Sprintf("the range may span at most %d blocks (got %d).", maxTxRangeBlocks, maxHeight-minHeight+1))
+	}
+	return errs
+}
+
+func txsInRangeHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[TxsInRangeParams]) (*mcp.CallToolResultFor[any], error) {
+	minHeight, maxHeight := params.Arguments.MinHeight, params.Arguments.MaxHeight
+	logf(ctx, "INFO: Querying txs between heights %d and %d", minHeight, maxHeight)
+
+	if errs := checkHeightRange(minHeight, maxHeight); len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	// --events only takes key=value pairs, so height ranges need --query.
+	txs, err := fetchTxSummaries(ctx, "--query", fmt.Sprintf("tx.height>=%d AND tx.height<=%d", minHeight, maxHeight))
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying txs between heights %d and %d: %v", minHeight, maxHeight, err)}},
+		}, nil
+	}
+
+	var response TxsInRangeResponse
+	response.Summary = fmt.Sprintf("Found %d transactions between heights %d and %d", len(txs), minHeight, maxHeight)
+	response.Details.MinHeight = minHeight
+	response.Details.MaxHeight = maxHeight
+	response.Details.Txs = txs
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func getBlockchainStatusHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetBlockchainStatusParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Getting blockchain status")
+
+	auctions, bids, err := fetchAuctionsAndBids(ctx)
+	if err != nil {
+		return fetchErrorResult("auctions and bids", err), nil
+	}
+	owners, err := fetchDenomOwners(ctx)
+	if err != nil {
+		return fetchErrorResult("denom owners", err), nil
+	}
+	keys, err := getKeys(ctx)
+	if err != nil {
+		return fetchErrorResult("keys", err), nil
+	}
+
+	// Count open auctions
+	openCount := 0
+	for _, auction := range auctions {
+		if auction.Status == "open" {
+			openCount++
+		}
+	}
+
+	response := BlockchainStatusResponse{
+		Summary: fmt.Sprintf("Blockchain has %d total auctions (%d open), %d bids, %d keys, and %d token holders",
+			len(auctions), openCount, len(bids), len(keys), len(owners)),
+		Details: struct {
+			TotalAuctions int `json:"totalAuctions"`
+			OpenAuctions  int `json:"openAuctions"`
+			TotalBids     int `json:"totalBids"`
+			TotalKeys     int `json:"totalKeys"`
+			TokenHolders  int `json:"tokenHolders"`
+		}{
+			TotalAuctions: len(auctions),
+			OpenAuctions:  openCount,
+			TotalBids:     len(bids),
+			TotalKeys:     len(keys),
+			TokenHolders:  len(owners),
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func verifySignatureHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[VerifySignatureParams]) (*mcp.CallToolResultFor[any], error) {
+	keyName := strings.TrimSpace(params.Arguments.KeyName)
+	address := strings.TrimSpace(params.Arguments.Address)
+	message := params.Arguments.Message
+	logf(ctx, "INFO: Verifying signature for key %q address %q", keyName, address)
+
+	var errs validationErrors
+	if keyName == "" && address == "" {
+		errs.add("keyName", codeMissingField, "one of 'keyName' or 'address' is required.")
+	} else if keyName != "" && address != "" {
+		errs.add("keyName", codeInvalidValue, "pass only one of 'keyName' or 'address'.")
+	} else if address != "" && !isValidCosmosAddress(address) {
+		errs.add("address", codeInvalidAddress, "'address' must be a valid cosmos address (cosmos1...).")
+	}
+	if message == "" {
+		errs.add("message", codeMissingField, "'message' parameter is required.")
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(params.Arguments.Signature))
+	if strings.TrimSpace(params.Arguments.Signature) == "" {
+		errs.add("signature", codeMissingField, "'signature' parameter is required.")
+	} else if err != nil {
+		errs.add("signature", codeInvalidEncoding, "'signature' must be base64-encoded.")
+	}
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	var pubKey []byte
+	if keyName != "" {
+		address, pubKey, err = getKeyPubKey(ctx, keyName)
+	} else {
+		pubKey, err = getAddressPubKey(ctx, address)
+	}
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error getting public key: %v", err)}},
+		}, nil
+	}
+
+	valid, err := verifySecp256k1(pubKey, adr036SignDoc(address, []byte(message)), signature)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+		}, nil
+	}
+
+	summary := fmt.Sprintf("Signature is valid for %s", address)
+	if !valid {
+		summary = fmt.Sprintf("Signature is NOT valid for %s", address)
+	}
+
+	response := map[string]interface{}{
+		"summary": summary,
+		"details": map[string]interface{}{
+			"valid":   valid,
+			"address": address,
+			"keyName": keyName,
+			"pubKey":  base64.StdEncoding.EncodeToString(pubKey),
+		},
+	}
+
+	result := marshalResponse(response, "")
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func signMessageHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[SignMessageParams]) (*mcp.CallToolResultFor[any], error) {
+	keyName := strings.TrimSpace(params.Arguments.KeyName)
+	message := params.Arguments.Message
+	logf(ctx, "INFO: Signing a %d byte message with key %q", len(message), keyName)
+
+	var errs validationErrors
+	if keyName == "" {
+		errs.add("keyName", codeMissingField, "'keyName' parameter is required.")
+	}
+	if message == "" {
+		errs.add("message", codeMissingField, "'message' parameter is required.")
+	}
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	address, keyPubKey, err := getKeyPubKey(ctx, keyName)
+	if err != nil {
+		return fieldErrorResult("keyName", codeNotFound, fmt.Sprintf("key '%s' was not found in the keyring: %v", keyName, err)), nil
+	}
+
+	signature, pubKey, err := signADR036(ctx, keyName, address, []byte(message))
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error signing message: %v", err)}},
+		}, nil
+	}
+	if !bytes.Equal(pubKey, keyPubKey) {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: signing key does not match the public key of '%s'; refusing to return the signature.", keyName)}},
+		}, nil
+	}
+	if valid, err := verifySecp256k1(pubKey, adr036SignDoc(address, []byte(message)), signature); err != nil || !valid {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: swechaind's signature does not verify as an ADR-036 signature by '%s'; refusing to return it.", keyName)}},
+		}, nil
+	}
+
+	response := map[string]interface{}{
+		"summary": fmt.Sprintf("Signed message with key '%s' (%s) as an ADR-036 document", keyName, address),
+		"details": map[string]interface{}{
+			"keyName":   keyName,
+			"address":   address,
+			"pubKey":    base64.StdEncoding.EncodeToString(pubKey),
+			"signature": base64.StdEncoding.EncodeToString(signature),
+		},
+	}
+
+	result := marshalResponse(response, "")
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func findKeysHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[FindKeysParams]) (*mcp.CallToolResultFor[any], error) {
+	prefix := strings.TrimSpace(params.Arguments.Prefix)
+	logf(ctx, "INFO: Finding keys with prefix: %s", prefix)
+
+	if prefix == "" {
+		return fieldErrorResult("prefix", codeMissingField, "prefix parameter is required and cannot be empty."), nil
+	}
+
+	keys, err := getKeys(ctx)
+	if err != nil {
+		return fetchErrorResult("keys", err), nil
+	}
+	keys = filterKeysByPrefix(keys, prefix)
+
+	summary := fmt.Sprintf("Found %d keys starting with '%s'", len(keys), prefix)
+	if len(keys) == 0 {
+		summary = fmt.Sprintf("No keys start with '%s'", prefix)
+	}
+
+	response := KeySummaryResponse{
+		Summary: summary,
+		Details: struct {
+			Keys []Key `json:"keys"`
+		}{
+			Keys: keys,
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func getKeysHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetKeysParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Getting all keys")
+
+	keys, err := getKeys(ctx)
+	if err != nil {
+		return fetchErrorResult("keys", err), nil
+	}
+
+	response := KeySummaryResponse{
+		Summary: fmt.Sprintf("Found %d keys in the keyring", len(keys)),
+		Details: struct {
+			Keys []Key `json:"keys"`
+		}{
+			Keys: keys,
+		},
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func openAuctionHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[OpenAuctionParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'open-auction' tool request. Params: %+v", params.Arguments)
+
+	// Validate required parameters
+	issue := strings.TrimSpace(params.Arguments.Issue)
+	description := strings.TrimSpace(params.Arguments.Description)
+	from := strings.TrimSpace(params.Arguments.From)
+
+	var errs validationErrors
+	if issue == "" {
+		errs.add("issue", codeMissingField, "'issue' parameter is required and cannot be empty.")
+	}
+	errs.checkText("issue", issue)
+	if description == "" {
+		errs.add("description", codeMissingField, "'description' parameter is required and cannot be empty.")
+	}
+	errs.checkText("description", description)
+	errs.checkAddress("from", from)
+
+	// Set defaults for optional parameters
+	status := parse.NormalizeStatus(params.Arguments.Status)
+	if status == "" {
+		status = "open"
+	}
+	if err := validateAuctionStatus(status); err != nil {
+		errs.addError("status", codeInvalidValue, err)
+	}
+
+	winner := strings.TrimSpace(params.Arguments.Winner)
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		errs.addError("fees", codeInvalidCoin, err)
+	}
+	feeGranter, err := resolveFeeGranter(params.Arguments.FeeGranter)
+	if err != nil {
+		errs.addError("feeGranter", codeInvalidAddress, err)
+	}
+
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	args := buildTxArgs(ctx, "issuemarket", "create-auction",
+		[]string{issue, description, status, winner}, from, fees)
+	args = withFeeGranter(args, feeGranter)
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to create auction: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Auction creation", output, params.Arguments.Verbose), nil
+}
+
+func createBidHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateBidParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'create-bid' tool request. Params: %+v", params.Arguments)
+
+	// Validate required parameters
+	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
+	bidder := strings.TrimSpace(params.Arguments.Bidder)
+	from := strings.TrimSpace(params.Arguments.From)
+
+	var errs validationErrors
+	if auctionId == "" {
+		errs.add("auctionId", codeMissingField, "'auctionId' parameter is required.")
+	} else if _, err := strconv.Atoi(auctionId); err != nil {
+		errs.add("auctionId", codeInvalidAuctionID, "'auctionId' must be a valid number.")
+	}
+	errs.checkAddress("bidder", bidder)
+	errs.checkAddress("from", from)
+	// A relative "+N" amount is checked as N; resolveRelativeBid resolves it later.
+	errs.checkCoin("amount", strings.TrimPrefix(strings.TrimSpace(params.Arguments.Amount), "+"))
+	errs.checkText("description", params.Arguments.Description)
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		errs.addError("fees", codeInvalidCoin, err)
+	}
+	feeGranter, err := resolveFeeGranter(params.Arguments.FeeGranter)
+	if err != nil {
+		errs.addError("feeGranter", codeInvalidAddress, err)
+	}
+
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	// Set defaults
+	amount := strings.TrimSpace(params.Arguments.Amount)
+	if amount == "" {
+		amount = defaultBidAmount + configFrom(ctx).denom
+	}
+
+	if !strings.HasPrefix(amount, "+") {
+		amount = withDefaultDenom(ctx, amount)
+	} else {
+		auctionIdInt, _ := strconv.Atoi(auctionId)
+		bids, err := fetchBids(ctx)
+		if err != nil {
+			return fetchErrorResult("bids", err), nil
+		}
+		absolute, err := resolveRelativeBid(ctx, amount, filterBidsByAuction(bids, auctionIdInt))
+		if err != nil {
+			return fieldErrorResult("amount", codeInvalidCoin, err.Error()), nil
+		}
+		logf(ctx, "INFO: Resolved relative bid %s on auction %s to %s", amount, auctionId, absolute)
+		amount = absolute
+	}
+
+	description := strings.TrimSpace(params.Arguments.Description)
+	if description == "" {
+		description = fmt.Sprintf("Bid for auction %s", auctionId)
+	}
+
+	args := buildTxArgs(ctx, "issuemarket", "create-bid",
+		[]string{auctionId, bidder, amount, description}, from, fees)
+	args = withFeeGranter(args, feeGranter)
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to create bid: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Bid", output, params.Arguments.Verbose), nil
+}
+
+func payHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[PayParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'pay' tool request")
+
+	from := strings.TrimSpace(params.Arguments.From)
+	to := strings.TrimSpace(params.Arguments.To)
+	amount := strings.TrimSpace(params.Arguments.Amount)
+
+	var errs validationErrors
+	errs.checkAddress("from", from)
+	errs.checkAddress("to", to)
+	if amount == "" {
+		errs.add("amount", codeMissingField, "'amount' parameter is required.")
+	}
+	errs.checkCoin("amount", amount)
+	if from != "" && from == to && !params.Arguments.AllowSelf {
+		errs.add("to", codeInvalidValue, "'from' and 'to' are the same address; a self-send only spends the fee. Pass allowSelf: true if this is intended.")
+	}
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		errs.addError("fees", codeInvalidCoin, err)
+	}
+	feeGranter, err := resolveFeeGranter(params.Arguments.FeeGranter)
+	if err != nil {
+		errs.addError("feeGranter", codeInvalidAddress, err)
+	}
+
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	amount = withDefaultDenom(ctx, amount)
+
+	if params.Arguments.CheckSendEnabled {
+		_, denom, _ := parse.SplitCoin(amount)
+		enabled, err := isSendEnabled(ctx, denom)
+		if err != nil {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error checking whether %s is send-enabled: %v", denom, err)}},
+			}, nil
+		}
+		if !enabled {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: transfers of %s are disabled on this chain (bank send-enabled is false).", denom)}},
+			}, nil
+		}
+	}
+
+	args := buildTxArgs(ctx, "bank", "send", []string{from, to, amount}, from, fees)
+	args = withFeeGranter(args, feeGranter)
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to send payment: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Payment", output, params.Arguments.Verbose), nil
+}
+
+func bumpFeeResubmitHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[BumpFeeResubmitParams]) (*mcp.CallToolResultFor[any], error) {
+	tool := strings.TrimSpace(params.Arguments.Tool)
+	multiplier := params.Arguments.FeeMultiplier
+	txHash := strings.TrimSpace(params.Arguments.TxHash)
+	logf(ctx, "INFO: Resubmitting %s with fee multiplier %g", tool, multiplier)
+
+	var errs validationErrors
+	switch tool {
+	case "pay", "create-bid", "open-auction", "close-auction":
+	case "":
+		errs.add("tool", codeMissingField, "'tool' parameter is required.")
+	default:
+		errs.add("tool", codeInvalidValue, fmt.Sprintf("'tool' must be one of pay, create-bid, open-auction, close-auction (got %q)", tool))
+	}
+	if params.Arguments.Arguments == nil {
+		errs.add("arguments", codeMissingField, "'arguments' parameter is required.")
+	}
+	if multiplier <= 1 || multiplier > maxFeeMultiplier {
+		errs.add("feeMultiplier", codeOutOfRange, fmt.Sprintf("'feeMultiplier' must be greater than 1 and at most %d (got %g)", maxFeeMultiplier, multiplier))
+	}
+	errs.require("txHash", txHash)
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	originalFees, _ := params.Arguments.Arguments["fees"].(string)
+	fees, err := resolveFees(ctx, originalFees)
+	if err == nil {
+		fees, err = bumpFee(fees, multiplier)
+	}
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	// Only a tx the chain has recorded as failed, or one the caller saw
+	// rejected at broadcast, is safe to resend: one that isn't found may
+	// otherwise still be pending in the mempool and land later.
+	txResponse, err := lookupTx(ctx, txHash)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error checking original tx %s: %v", txHash, err)}},
+		}, nil
+	}
+	if txResponse == nil {
+		if !params.Arguments.RejectedAtBroadcast {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: original tx %s was not found on chain; it may still be pending, so not resubmitting. Set rejectedAtBroadcast if its broadcast returned a non-zero code.", txHash)}},
+			}, nil
+		}
+	} else {
+		if code, _ := strconv.Atoi(parse.String(txResponse, "code")); code == 0 {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: original tx %s already succeeded; not resubmitting.", txHash)}},
+			}, nil
+		}
+		from, _ := params.Arguments.Arguments["from"].(string)
+		if err := checkTxMatchesTool(txResponse, tool, strings.TrimSpace(from)); err != nil {
+			return fieldErrorResult("txHash", codeInvalidValue, fmt.Sprintf("original tx %s %v; not resubmitting.", txHash, err)), nil
+		}
+	}
+
+	logf(ctx, "INFO: Resubmitting %s with fees %s (was %s)", tool, fees, originalFees)
+	switch tool {
+	case "pay":
+		return rerunWithFees(ctx, sess, tool, payHandler, params.Arguments.Arguments, fees)
+	case "create-bid":
+		return rerunWithFees(ctx, sess, tool, createBidHandler, params.Arguments.Arguments, fees)
+	case "open-auction":
+		return rerunWithFees(ctx, sess, tool, openAuctionHandler, params.Arguments.Arguments, fees)
+	default:
+		return rerunWithFees(ctx, sess, tool, closeAuctionHandler, params.Arguments.Arguments, fees)
+	}
+}
+
+// rerunWithFees decodes the original arguments into h's params type with the
+// fees replaced and calls h. The original idempotency key is dropped so the
+// resubmission isn't answered from the first attempt's cached result.
+func rerunWithFees[In any](ctx context.Context, sess *mcp.ServerSession, tool string, h mcp.ToolHandlerFor[In, any], arguments map[string]interface{}, fees string) (*mcp.CallToolResultFor[any], error) {
+	rerun := make(map[string]interface{}, len(arguments))
+	for key, value := range arguments {
+		rerun[key] = value
+	}
+	rerun["fees"] = fees
+	delete(rerun, "idempotencyKey")
+
+	raw, _ := json.Marshal(rerun)
+	var in In
+	if err := json.Unmarshal(raw, &in); err != nil {
+		return fieldErrorResult("arguments", codeInvalidValue, fmt.Sprintf("'arguments' do not match the %s tool: %v", tool, err)), nil
+	}
+	return h(ctx, sess, &mcp.CallToolParamsFor[In]{Name: tool, Arguments: in})
+}
+
+// bumpFee multiplies a fee coin such as 200token, rounding up to a whole amount.
+func bumpFee(fees string, multiplier float64) (string, error) {
+	value, denom, err := parse.CoinAmount(fees)
+	if err != nil {
+		return "", &FieldError{Field: "fees", Code: codeInvalidCoin, Message: fmt.Sprintf("'fees' must be a coin amount such as 200token (got %q)", fees)}
+	}
+	// Go through the shortest decimal form so 1.1 means exactly 11/10.
+	factor, ok := new(big.Rat).SetString(strconv.FormatFloat(multiplier, 'f', -1, 64))
+	if !ok {
+		return "", fmt.Errorf("invalid fee multiplier %g", multiplier)
+	}
+
+	product := new(big.Rat).Mul(new(big.Rat).SetInt(value), factor)
+	bumped, remainder := new(big.Int).QuoRem(product.Num(), product.Denom(), new(big.Int))
+	if remainder.Sign() > 0 {
+		bumped.Add(bumped, big.NewInt(1))
+	}
+	return bumped.String() + denom, nil
+}
+
+// lookupTx fetches a tx response by hash, returning nil when the chain has no
+// record of it. A tx that isn't found may be pending, dropped or never sent.
+func lookupTx(ctx context.Context, txHash string) (map[string]interface{}, error) {
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "tx", "", txHash)...)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var txResponse map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &txResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse tx %s: %w", txHash, err)
+	}
+	return txResponse, nil
+}
+
+// bumpFeeMessageTypes maps each tool bump-fee-resubmit can rerun to the
+// message type it broadcasts.
+var bumpFeeMessageTypes = map[string]string{
+	"pay":           "MsgSend",
+	"create-bid":    "MsgCreateBid",
+	"open-auction":  "MsgCreateAuction",
+	"close-auction": "MsgUpdateAuction",
+}
+
+// checkTxMatchesTool reports an error unless txResponse carries a single
+// message of the type tool broadcasts, signed by from.
+func checkTxMatchesTool(txResponse map[string]interface{}, tool, from string) error {
+	messages := txMessages(txResponse)
+	if len(messages) != 1 {
+		return fmt.Errorf("has %d messages, not a single %s", len(messages), tool)
+	}
+	msgType := parse.String(messages[0], "@type")
+	if want := bumpFeeMessageTypes[tool]; !strings.HasSuffix(msgType, "."+want) {
+		return fmt.Errorf("is a %s, not a %s from %s", msgType, want, tool)
+	}
+	for _, field := range signerFields {
+		if signer := parse.String(messages[0], field); signer != "" {
+			if signer != from {
+				return fmt.Errorf("was signed by %s, not %s", signer, from)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("has no signer to compare with %s", from)
+}
+
+func closeAuctionHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[CloseAuctionParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'close-auction' tool request")
+
+	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
+	status := parse.NormalizeStatus(params.Arguments.Status)
+	from := strings.TrimSpace(params.Arguments.From)
+
+	// Validate required parameters
+	var errs validationErrors
+	if auctionId == "" {
+		errs.add("auctionId", codeMissingField, "'auctionId' parameter is required.")
+	} else if _, err := strconv.Atoi(auctionId); err != nil {
+		errs.add("auctionId", codeInvalidAuctionID, "'auctionId' must be a valid number.")
+	}
+	if status == "" {
+		errs.add("status", codeMissingField, "'status' parameter is required.")
+	} else if err := validateAuctionStatus(status); err != nil {
+		errs.addError("status", codeInvalidValue, err)
+	}
+	errs.checkAddress("from", from)
+	errs.checkText("issue", params.Arguments.Issue)
+	errs.checkText("description", params.Arguments.Description)
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		errs.addError("fees", codeInvalidCoin, err)
+	}
+	feeGranter, err := resolveFeeGranter(params.Arguments.FeeGranter)
+	if err != nil {
+		errs.addError("feeGranter", codeInvalidAddress, err)
+	}
+
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	args := buildTxArgs(ctx, "issuemarket", "update-auction", []string{
+		auctionId,
+		strings.TrimSpace(params.Arguments.Issue),
+		strings.TrimSpace(params.Arguments.Description),
+		status,
+		strings.TrimSpace(params.Arguments.Winner),
+	}, from, fees)
+	args = withFeeGranter(args, feeGranter)
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to close auction: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Auction update", output, params.Arguments.Verbose), nil
+}
+
+// cancelAuctionHandler sets an open auction's status to cancelled through
+// update-auction, keeping its issue, description and winner. The issuemarket
+// module has no refund message, so nothing else is sent.
+func cancelAuctionHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[CancelAuctionParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'cancel-auction' tool request")
+
+	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
+	from := strings.TrimSpace(params.Arguments.From)
+
+	var errs validationErrors
+	if auctionId == "" {
+		errs.add("auctionId", codeMissingField, "'auctionId' parameter is required.")
+	} else if _, err := strconv.Atoi(auctionId); err != nil {
+		errs.add("auctionId", codeInvalidAuctionID, "'auctionId' must be a valid number.")
+	}
+	errs.checkAddress("from", from)
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		errs.addError("fees", codeInvalidCoin, err)
+	}
+	feeGranter, err := resolveFeeGranter(params.Arguments.FeeGranter)
+	if err != nil {
+		errs.addError("feeGranter", codeInvalidAddress, err)
+	}
+	if err := validateAuctionStatus("cancelled"); err != nil {
+		errs.add("auctionId", codeInvalidValue, "auctions can't be cancelled: -auction-statuses doesn't include \"cancelled\".")
+	}
+
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	auction, err := getAuction(ctx, auctionId)
+	if err != nil {
+		if isNotFoundError(err) {
+			return fieldErrorResult("auctionId", codeNotFound, fmt.Sprintf("auction %s does not exist.", auctionId)), nil
+		}
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error fetching auction %s: %v", auctionId, err)}},
+		}, nil
+	}
+	if err := checkCanCancelAuction(auction, from); err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	args := withFeeGranter(buildCancelAuctionArgs(ctx, auction, from, fees), feeGranter)
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to cancel auction: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Auction cancellation", output, params.Arguments.Verbose), nil
+}
+
+// checkCanCancelAuction refuses unless from created the auction and it is
+// still open.
+func checkCanCancelAuction(auction Auction, from string) *FieldError {
+	if auction.Creator != from {
+		return &FieldError{Field: "from", Code: codeUnauthorized, Message: fmt.Sprintf("only the auction creator %s can cancel auction %d, not %s.", auction.Creator, auction.ID, from)}
+	}
+	if status := parse.NormalizeStatus(auction.Status); status != "open" {
+		return &FieldError{Field: "auctionId", Code: codeInvalidValue, Message: fmt.Sprintf("auction %d is %s; only open auctions can be cancelled.", auction.ID, status)}
+	}
+	return nil
+}
+
+// buildCancelAuctionArgs builds the update-auction tx that marks auction
+// cancelled, resubmitting its other fields unchanged.
+func buildCancelAuctionArgs(ctx context.Context, auction Auction, from, fees string) []string {
+	return buildTxArgs(ctx, "issuemarket", "update-auction", []string{
+		strconv.Itoa(auction.ID),
+		auction.Issue,
+		auction.Description,
+		"cancelled",
+		auction.Winner,
+	}, from, fees)
+}
+
+func queryDelegationsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[QueryDelegationsParams]) (*mcp.CallToolResultFor[any], error) {
+	delegator := strings.TrimSpace(params.Arguments.Delegator)
+	logf(ctx, "INFO: Querying delegations for: %s", delegator)
+
+	if delegator == "" {
+		return fieldErrorResult("delegator", codeMissingField, "delegator parameter is required and cannot be empty."), nil
+	}
+	if !isValidCosmosAddress(delegator) {
+		return fieldErrorResult("delegator", codeInvalidAddress, "delegator must be a valid cosmos address (cosmos1...)."), nil
+	}
+
+	rawDelegations, err := fetchPaginatedData(ctx, "staking", "delegations", "delegation_responses", delegator)
+	if err != nil {
+		return fetchErrorResult("delegations", err), nil
+	}
+	delegations := parse.Delegations(rawDelegations)
+
+	var response DelegationsResponse
+	response.Summary = fmt.Sprintf("Address %s has %d delegations", delegator, len(delegations))
+	response.Details.Delegator = delegator
+	response.Details.Delegations = delegations
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func portfolioHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[PortfolioParams]) (*mcp.CallToolResultFor[any], error) {
+	address := strings.TrimSpace(params.Arguments.Address)
+	logf(ctx, "INFO: Building portfolio for: %s", address)
+
+	var errs validationErrors
+	errs.checkAddress("address", address)
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	var (
+		balances    []Balance
+		delegations []Delegation
+		unbonding   []UnbondingEntry
+		rewards     []Balance
+		fetchErrors = make([]error, 4)
+	)
+	fetches := []func() error{
+		func() (err error) {
+			balances, err = getBalanceForAddress(ctx, address)
+			return err
+		},
+		func() error {
+			rawDelegations, err := fetchPaginatedData(ctx, "staking", "delegations", "delegation_responses", address)
+			delegations = parse.Delegations(rawDelegations)
+			return err
+		},
+		func() error {
+			// Unbonding entries carry bare amounts in the bond denom.
+			bondDenom := parse.String(queryOptionalModuleParams(ctx, "staking"), "bond_denom")
+			rawUnbonding, err := fetchPaginatedData(ctx, "staking", "unbonding-delegations", "unbonding_responses", address)
+			unbonding = parseUnbondingDelegations(rawUnbonding, bondDenom)
+			return err
+		},
+		func() (err error) {
+			rewards, err = getDelegatorRewards(ctx, address)
+			return err
+		},
+	}
+	forEachConcurrently(len(fetches), len(fetches), func(i int) {
+		fetchErrors[i] = fetches[i]()
+	})
+
+	componentErrors := make(map[string]string)
+	for i, component := range []string{"balances", "delegations", "unbonding", "rewards"} {
+		if fetchErrors[i] != nil {
+			logf(ctx, "ERROR: Portfolio %s for %s: %v", component, address, errorForLog(fetchErrors[i]))
+			componentErrors[component] = fetchErrors[i].Error()
+		}
+	}
+
+	response := buildPortfolioResponse(address, balances, delegations, unbonding, rewards, componentErrors)
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// buildPortfolioResponse sums each component per denom. Coins that fail to
+// parse are left out of the totals but still listed under their component.
+func buildPortfolioResponse(address string, balances []Balance, delegations []Delegation, unbonding []UnbondingEntry, rewards []Balance, componentErrors map[string]string) PortfolioResponse {
+	type holdings struct{ liquid, delegated, unbonding, rewards *big.Rat }
+	totals := make(map[string]*holdings)
+	add := func(denom string, value *big.Rat, pick func(*holdings) *big.Rat) {
+		if denom == "" || value == nil {
+			return
+		}
+		if totals[denom] == nil {
+			totals[denom] = &holdings{new(big.Rat), new(big.Rat), new(big.Rat), new(big.Rat)}
+		}
+		sum := pick(totals[denom])
+		sum.Add(sum, value)
+	}
+	coin := func(text string) (*big.Rat, string) {
+		value, denom, err := parse.CoinAmount(text)
+		if err != nil {
+			return nil, ""
+		}
+		return new(big.Rat).SetInt(value), denom
+	}
+
+	for _, balance := range balances {
+		if value, err := parse.Amount(balance.Amount); err == nil {
+			add(balance.Denom, new(big.Rat).SetInt(value), func(h *holdings) *big.Rat { return h.liquid })
+		}
+	}
+	for _, delegation := range delegations {
+		value, denom := coin(delegation.Balance)
+		add(denom, value, func(h *holdings) *big.Rat { return h.delegated })
+	}
+	for _, entry := range unbonding {
+		value, denom := coin(entry.Balance)
+		add(denom, value, func(h *holdings) *big.Rat { return h.unbonding })
+	}
+	for _, reward := range rewards {
+		if value, err := parse.DecAmount(reward.Amount); err == nil {
+			add(reward.Denom, value, func(h *holdings) *big.Rat { return h.rewards })
+		}
+	}
+
+	totalsByDenom := make(map[string]DenomHoldings, len(totals))
+	for denom, h := range totals {
+		total := new(big.Rat).Add(h.liquid, h.delegated)
+		total.Add(total, h.unbonding)
+		total.Add(total, h.rewards)
+		totalsByDenom[denom] = DenomHoldings{
+			Liquid:    parse.FormatDec(h.liquid),
+			Delegated: parse.FormatDec(h.delegated),
+			Unbonding: parse.FormatDec(h.unbonding),
+			Rewards:   parse.FormatDec(h.rewards),
+			Total:     parse.FormatDec(total),
+		}
+	}
+
+	var response PortfolioResponse
+	response.Details.Address = address
+	response.Details.Balances = balances
+	response.Details.Delegations = delegations
+	response.Details.Unbonding = unbonding
+	response.Details.Rewards = rewards
+	response.Details.TotalsByDenom = totalsByDenom
+	if len(componentErrors) > 0 {
+		response.Details.Errors = componentErrors
+	}
+
+	denoms := make([]string, 0, len(totalsByDenom))
+	for denom := range totalsByDenom {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+	parts := make([]string, 0, len(denoms))
+	for _, denom := range denoms {
+		parts = append(parts, totalsByDenom[denom].Total+denom)
+	}
+	response.Summary = fmt.Sprintf("Address %s holds nothing", address)
+	if len(parts) > 0 {
+		response.Summary = fmt.Sprintf("Address %s holds %s in total across %d delegations and %d unbonding entries", address, strings.Join(parts, ", "), len(delegations), len(unbonding))
+	}
+	if len(componentErrors) > 0 {
+		failed := make([]string, 0, len(componentErrors))
+		for component := range componentErrors {
+			failed = append(failed, component)
+		}
+		sort.Strings(failed)
+		response.Summary += fmt.Sprintf(" (%s unavailable)", strings.Join(failed, ", "))
+	}
+	return response
+}
+
+func queryFeegrantsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[QueryFeegrantsParams]) (*mcp.CallToolResultFor[any], error) {
+	grantee := strings.TrimSpace(params.Arguments.Grantee)
+	logf(ctx, "INFO: Querying fee allowances for: %s", grantee)
+
+	if grantee == "" {
+		return fieldErrorResult("grantee", codeMissingField, "grantee parameter is required and cannot be empty."), nil
+	}
+	if !isValidCosmosAddress(grantee) {
+		return fieldErrorResult("grantee", codeInvalidAddress, "grantee must be a valid cosmos address (cosmos1...)."), nil
+	}
+
+	rawAllowances, err := fetchPaginatedData(ctx, "feegrant", "grants-by-grantee", "allowances", grantee)
+	if err != nil {
+		return fetchErrorResult("fee allowances", err), nil
+	}
+	allowances := parse.FeegrantAllowances(rawAllowances, time.Now())
+
+	var response FeegrantsResponse
+	response.Summary = fmt.Sprintf("Address %s has %d active fee allowances", grantee, len(allowances))
+	response.Details.Grantee = grantee
+	response.Details.Allowances = allowances
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func cancelUnbondingHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[CancelUnbondingParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'cancel-unbonding' tool request. Params: %+v", params.Arguments)
+
+	delegator := strings.TrimSpace(params.Arguments.Delegator)
+	validator := strings.TrimSpace(params.Arguments.Validator)
+	amount := strings.TrimSpace(params.Arguments.Amount)
+	creationHeight := strings.TrimSpace(params.Arguments.CreationHeight)
+
+	var errs validationErrors
+	errs.require("delegator", delegator)
+	errs.require("validator", validator)
+	errs.require("amount", amount)
+	errs.require("creationHeight", creationHeight)
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+	if !isValidCosmosAddress(delegator) {
+		return fieldErrorResult("delegator", codeInvalidAddress, "'delegator' must be a valid cosmos address."), nil
+	}
+	if !isValidValoperAddress(validator) {
+		return fieldErrorResult("validator", codeInvalidAddress, "'validator' must be a valid validator operator address (cosmosvaloper1...)."), nil
+	}
+	if value, _, err := parse.CoinAmount(amount); err != nil || value.Sign() == 0 {
+		return fieldErrorResult("amount", codeInvalidCoin, "'amount' must be a positive coin amount such as 100stake."), nil
+	}
+	if height, err := strconv.ParseInt(creationHeight, 10, 64); err != nil || height <= 0 {
+		return fieldErrorResult("creationHeight", codeOutOfRange, "'creationHeight' must be a positive integer."), nil
+	}
+
+	feeGranter, err := resolveFeeGranter(params.Arguments.FeeGranter)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	args := buildTxArgs(ctx, "staking", "cancel-unbond", []string{validator, amount, creationHeight}, delegator, fees)
+	args = withFeeGranter(args, feeGranter)
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to cancel unbonding: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Cancel unbonding", output, params.Arguments.Verbose), nil
+}
+
+func redelegateHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[RedelegateParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'redelegate' tool request. Params: %+v", params.Arguments)
+
+	delegator := strings.TrimSpace(params.Arguments.Delegator)
+	srcValidator := strings.TrimSpace(params.Arguments.SrcValidator)
+	dstValidator := strings.TrimSpace(params.Arguments.DstValidator)
+	amount := strings.TrimSpace(params.Arguments.Amount)
+
+	var errs validationErrors
+	errs.require("delegator", delegator)
+	errs.require("srcValidator", srcValidator)
+	errs.require("dstValidator", dstValidator)
+	errs.require("amount", amount)
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+	if !isValidCosmosAddress(delegator) {
+		return fieldErrorResult("delegator", codeInvalidAddress, "'delegator' must be a valid cosmos address."), nil
+	}
+	if !isValidValoperAddress(srcValidator) {
+		return fieldErrorResult("srcValidator", codeInvalidAddress, "'srcValidator' must be a valid validator operator address (cosmosvaloper1...)."), nil
+	}
+	if !isValidValoperAddress(dstValidator) {
+		return fieldErrorResult("dstValidator", codeInvalidAddress, "'dstValidator' must be a valid validator operator address (cosmosvaloper1...)."), nil
+	}
+	if srcValidator == dstValidator {
+		return fieldErrorResult("dstValidator", codeInvalidValue, "'srcValidator' and 'dstValidator' must be different validators."), nil
+	}
+	if value, _, err := parse.CoinAmount(amount); err != nil || value.Sign() == 0 {
+		return fieldErrorResult("amount", codeInvalidCoin, "'amount' must be a positive coin amount such as 100stake."), nil
+	}
+
+	feeGranter, err := resolveFeeGranter(params.Arguments.FeeGranter)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	args := buildTxArgs(ctx, "staking", "redelegate", []string{srcValidator, dstValidator, amount}, delegator, fees)
+	args = withFeeGranter(args, feeGranter)
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to redelegate: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Redelegation", output, params.Arguments.Verbose), nil
+}
+
+func grantAuthzHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GrantAuthzParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'grant-authz' tool request. Params: %+v", params.Arguments)
+
+	granter := strings.TrimSpace(params.Arguments.Granter)
+	grantee := strings.TrimSpace(params.Arguments.Grantee)
+	msgType := strings.TrimSpace(params.Arguments.MsgType)
+	expiration := strings.TrimSpace(params.Arguments.Expiration)
+
+	if errs := validateAuthzParties(granter, grantee, msgType); len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+	if expiration != "" {
+		if ts, err := strconv.ParseInt(expiration, 10, 64); err != nil || ts <= time.Now().Unix() {
+			return fieldErrorResult("expiration", codeInvalidTime, "'expiration' must be a future unix timestamp in seconds."), nil
+		}
+	}
+
+	feeGranter, err := resolveFeeGranter(params.Arguments.FeeGranter)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	args := buildTxArgs(ctx, "authz", "grant", []string{grantee, "generic", "--msg-type", msgType}, granter, fees)
+	args = withFeeGranter(args, feeGranter)
+	if expiration != "" {
+		args = append(args, "--expiration", expiration)
+	}
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to grant authorization: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Authorization grant", output, params.Arguments.Verbose), nil
+}
+
+func revokeAuthzHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[RevokeAuthzParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'revoke-authz' tool request. Params: %+v", params.Arguments)
+
+	granter := strings.TrimSpace(params.Arguments.Granter)
+	grantee := strings.TrimSpace(params.Arguments.Grantee)
+	msgType := strings.TrimSpace(params.Arguments.MsgType)
+
+	if errs := validateAuthzParties(granter, grantee, msgType); len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	feeGranter, err := resolveFeeGranter(params.Arguments.FeeGranter)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	args := buildTxArgs(ctx, "authz", "revoke", []string{grantee, msgType}, granter, fees)
+	args = withFeeGranter(args, feeGranter)
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to revoke authorization: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Authorization revoke", output, params.Arguments.Verbose), nil
+}
+
+func execAuthzHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[ExecAuthzParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'exec-authz' tool request")
+
+	from := strings.TrimSpace(params.Arguments.From)
+	txJSON := strings.TrimSpace(params.Arguments.TxJSON)
+
+	var errs validationErrors
+	errs.require("from", from)
+	errs.require("txJson", txJSON)
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+	if !isValidCosmosAddress(from) {
+		return fieldErrorResult("from", codeInvalidAddress, "'from' must be a valid cosmos address."), nil
+	}
+
+	var tx map[string]interface{}
+	if err := json.Unmarshal([]byte(txJSON), &tx); err != nil {
+		return fieldErrorResult("txJson", codeInvalidEncoding, fmt.Sprintf("'txJson' must be valid JSON: %v", err)), nil
+	}
+	if len(txMessages(map[string]interface{}{"tx": tx})) == 0 {
+		return fieldErrorResult("txJson", codeInvalidValue, "'txJson' must contain at least one message in body.messages."), nil
+	}
+
+	feeGranter, err := resolveFeeGranter(params.Arguments.FeeGranter)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	txFile, err := os.CreateTemp("", "swechain-authz-*.json")
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error creating tx file: %v", err)}},
+		}, nil
+	}
+	defer os.Remove(txFile.Name())
+
+	_, err = txFile.WriteString(txJSON)
+	if closeErr := txFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error writing tx file: %v", err)}},
+		}, nil
+	}
+
+	args := buildTxArgs(ctx, "authz", "exec", []string{txFile.Name()}, from, fees)
+	args = withFeeGranter(args, feeGranter)
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to execute authorization: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Authorized execution", output, params.Arguments.Verbose), nil
+}
+
+func grantFeegrantHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GrantFeegrantParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'grant-feegrant' tool request. Params: %+v", params.Arguments)
+
+	granter := strings.TrimSpace(params.Arguments.Granter)
+	grantee := strings.TrimSpace(params.Arguments.Grantee)
+	spendLimit := strings.TrimSpace(params.Arguments.SpendLimit)
+	expiration := strings.TrimSpace(params.Arguments.Expiration)
+
+	if errs := validateFeegrantParties(granter, grantee); len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+	if spendLimit != "" {
+		if value, _, err := parse.CoinAmount(spendLimit); err != nil || value.Sign() == 0 {
+			return fieldErrorResult("spendLimit", codeInvalidCoin, "'spendLimit' must be a positive coin amount such as 1000token."), nil
+		}
+	}
+	if expiration != "" {
+		if t, err := time.Parse(time.RFC3339, expiration); err != nil || !t.After(time.Now()) {
+			return fieldErrorResult("expiration", codeInvalidTime, "'expiration' must be a future RFC 3339 time such as 2026-12-31T00:00:00Z."), nil
+		}
+	}
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	args := buildTxArgs(ctx, "feegrant", "grant", []string{granter, grantee}, granter, fees)
+	if spendLimit != "" {
+		args = append(args, "--spend-limit", spendLimit)
+	}
+	if expiration != "" {
+		args = append(args, "--expiration", expiration)
+	}
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to grant fee allowance: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Fee allowance grant", output, params.Arguments.Verbose), nil
+}
+
+func revokeFeegrantHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[RevokeFeegrantParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'revoke-feegrant' tool request. Params: %+v", params.Arguments)
+
+	granter := strings.TrimSpace(params.Arguments.Granter)
+	grantee := strings.TrimSpace(params.Arguments.Grantee)
+
+	if errs := validateFeegrantParties(granter, grantee); len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	args := buildTxArgs(ctx, "feegrant", "revoke", []string{granter, grantee}, granter, fees)
+
+	output, err := runTx(ctx, args)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to revoke fee allowance: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	return formatTxResult("Fee allowance revoke", output, params.Arguments.Verbose), nil
+}
+
+// validateFeegrantParties checks the granter and grantee addresses shared by
+// the grant and revoke tools.
+func validateFeegrantParties(granter, grantee string) validationErrors {
+	var errs validationErrors
+	errs.checkAddress("granter", granter)
+	errs.checkAddress("grantee", grantee)
+	if len(errs) == 0 && granter == grantee {
+		errs.add("grantee", codeInvalidValue, "'granter' and 'grantee' must be different addresses.")
+	}
+	return errs
+}
+
+// validateAuthzParties checks the addresses and message type shared by the
+// authz grant and revoke tools.
+func validateAuthzParties(granter, grantee, msgType string) validationErrors {
+	errs := validateFeegrantParties(granter, grantee)
+	if msgType == "" {
+		errs.add("msgType", codeMissingField, "'msgType' parameter is required.")
+	} else if !msgTypePattern.MatchString(msgType) {
+		errs.add("msgType", codeInvalidValue, fmt.Sprintf("'msgType' must be a message type URL such as /cosmos.bank.v1beta1.MsgSend (got %q).", msgType))
+	}
+	return errs
+}
+
+func setupTestAccountsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[SetupTestAccountsParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'setup-test-accounts' tool request. Params: %+v", params.Arguments)
+
+	if isMainnetChainID(configFrom(ctx).chainID) {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: refusing to create test accounts on mainnet chain '%s'.", configFrom(ctx).chainID)}},
+		}, nil
+	}
+
+	funderAddress := strings.TrimSpace(params.Arguments.FunderAddress)
+	if funderAddress == "" {
+		return fieldErrorResult("funderAddress", codeMissingField, "'funderAddress' parameter is required."), nil
+	}
+	if !isValidCosmosAddress(funderAddress) {
+		return fieldErrorResult("funderAddress", codeInvalidAddress, "'funderAddress' must be a valid cosmos address."), nil
+	}
+
+	count := params.Arguments.Count
+	if count < 1 || count > maxTestAccounts {
+		return fieldErrorResult("count", codeOutOfRange, fmt.Sprintf("'count' must be between 1 and %d.", maxTestAccounts)), nil
+	}
+
+	var errs validationErrors
+	errs.checkCoin("amount", params.Arguments.Amount)
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+	amount := withDefaultDenom(ctx, params.Arguments.Amount)
+	if amount == "" {
+		amount = defaultFundAmount + configFrom(ctx).denom
+	}
+
+	fees, err := resolveFees(ctx, params.Arguments.Fees)
+	if err != nil {
+		return paramErrorResult(err), nil
+	}
+
+	// Manage the funder's sequence locally so back-to-back sends in the same
+	// block don't collide on the account sequence.
+	sequence, err := getAccountSequence(ctx, funderAddress)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error getting sequence for funder %s: %v", funderAddress, err)}},
+		}, nil
+	}
+
+	prefix := fmt.Sprintf("test-%d", time.Now().Unix())
+	var accounts []TestAccountResult
+	for i := 0; i < count; i++ {
+		account := TestAccountResult{KeyName: fmt.Sprintf("%s-%d", prefix, i+1)}
+
+		address, err := createKey(ctx, account.KeyName)
+		if err != nil {
+			account.Error = err.Error()
+			accounts = append(accounts, account)
+			continue
+		}
+		account.Address = address
+
+		args := buildTxArgs(ctx, "bank", "send", []string{funderAddress, address, amount}, funderAddress, fees)
+		args = append(args, "--sequence", strconv.FormatUint(sequence, 10))
+
+		// Carry on from the sequence actually used, which runTxAtSequence
+		// corrects from the chain on a mismatch, and only move past it once
+		// the send was accepted.
+		output, used, err := runTxAtSequence(ctx, args)
+		sequence = used
+		if err != nil {
+			account.Error = fmt.Sprintf("funding failed: %v", err)
+			accounts = append(accounts, account)
+			continue
+		}
+		account.TxHash = extractTxHash(output)
+		if code := parse.TxCode(output); code != 0 {
+			account.Error = fmt.Sprintf("funding failed with code %d: %s", code, extractTxRawLog(output))
+			accounts = append(accounts, account)
+			continue
+		}
+		sequence++
+		accounts = append(accounts, account)
+	}
+
+	response := buildSetupTestAccountsResponse(accounts)
+
+	result := encodeJSON(response)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+/*
+func createAndFundAddressHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateAndFundAddressParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Handling 'create-and-fund-address' tool request")
+
+	keyName := strings.TrimSpace(params.Arguments.KeyName)
+	funderAddress := strings.TrimSpace(params.Arguments.FunderAddress)
+
+	if keyName == "" {
+		return fieldErrorResult("keyName", codeMissingField, "'keyName' parameter is required."), nil
+	}
+	if funderAddress == "" {
+		return fieldErrorResult("funderAddress", codeMissingField, "'funderAddress' parameter is required."), nil
+	}
+
+	if !isValidCosmosAddress(funderAddress) {
+		return fieldErrorResult("funderAddress", codeInvalidAddress, "'funderAddress' must be a valid cosmos address."), nil
+	}
+
+	amount := strings.TrimSpace(params.Arguments.Amount)
+	if amount == "" {
+		amount = "1000token"
+	}
+
+	// Create the key
+	createArgs := buildKeysArgs(ctx, "add", keyName)
+
+	output, err := runCommand(ctx, swechaindCmd, createArgs...)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to create key: %v\nOutput: %s", err, output)}},
+		}, nil
+	}
+
+	var keyData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &keyData); err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to parse key creation output: %v", err)}},
+		}, nil
+	}
+
+	newAddress, ok := keyData["address"].(string)
+	if !ok {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: "Failed to extract address from key creation output"}},
+		}, nil
+	}
+
+	// Fund the new address
+	fundArgs := buildTxArgs(ctx, "bank", "send", []string{funderAddress, newAddress, amount}, funderAddress, configFrom(ctx).fees)
+
+	fundOutput, err := runTx(ctx, fundArgs)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Key created but funding failed: %v\nKey: %s\nAddress: %s", err, keyName, newAddress)}},
+		}, nil
+	}
+
+	result := fmt.Sprintf("Successfully created and funded address:\nKey: %s\nAddress: %s\nAmount: %s\nFunding result: %s",
+		keyName, newAddress, amount, fundOutput)
+
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: result}},
+	}, nil
+}
+*/
+// Helper functions with enhanced error handling
+
+func getAddressForKey(ctx context.Context, keyName string) (string, error) {
+	output, err := runCommand(ctx, swechaindCmd, buildKeysArgs(ctx, "show", keyName)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get key info: %w", err)
+	}
+
+	var keyData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &keyData); err != nil {
+		return "", parse.OutputError("key data", err, output)
+	}
+
+	address, ok := keyData["address"].(string)
+	if !ok {
+		return "", fmt.Errorf("address not found in key data")
+	}
+
+	if !isValidCosmosAddress(address) {
+		return "", fmt.Errorf("invalid cosmos address format: %s", address)
+	}
+
+	return address, nil
+}
+
+// mnemonicPattern matches a BIP39 mnemonic of 12 to 24 lowercase words.
+var mnemonicPattern = regexp.MustCompile(`^[a-z]+( [a-z]+){11,23}$`)
+
+func generateMnemonicHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GenerateMnemonicParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Generating a mnemonic (not logged)")
+
+	mnemonic, err := generateMnemonic(ctx)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error generating mnemonic: %v", err)}},
+		}, nil
+	}
+
+	response := map[string]interface{}{
+		"summary": fmt.Sprintf("Generated a %d-word mnemonic. It is sensitive: store it securely and do not share or log it.", len(strings.Fields(mnemonic))),
+		"details": map[string]interface{}{
+			"mnemonic":  mnemonic,
+			"words":     len(strings.Fields(mnemonic)),
+			"sensitive": true,
+		},
+	}
+
+	result := encodeJSON(response)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// generateMnemonic runs `keys mnemonic` through runSecretCommand so the
+// mnemonic never reaches the log, and returns it if it looks like one.
+func generateMnemonic(ctx context.Context) (string, error) {
+	args := []string{"keys", "mnemonic"}
+	if configFrom(ctx).home != "" {
+		args = append(args, "--home", configFrom(ctx).home)
+	}
+	stdout, stderr, err := runSecretCommand(ctx, "", swechaindCmd, args...)
+	if err != nil {
+		return "", err
+	}
+	if mnemonic, ok := findMnemonic(stdout); ok {
+		return mnemonic, nil
+	}
+	// Some SDK versions print it to stderr.
+	if mnemonic, ok := findMnemonic(stderr); ok {
+		return mnemonic, nil
+	}
+	return "", fmt.Errorf("keys mnemonic output did not contain a mnemonic")
+}
+
+func importKeyHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[ImportKeyParams]) (*mcp.CallToolResultFor[any], error) {
+	keyName := strings.TrimSpace(params.Arguments.KeyName)
+	// Normalise whitespace so a mnemonic pasted across lines still matches.
+	mnemonic := strings.Join(strings.Fields(params.Arguments.Mnemonic), " ")
+	logf(ctx, "INFO: Importing key %q from a mnemonic (not logged)", keyName)
+
+	var errs validationErrors
+	errs.require("keyName", keyName)
+	errs.checkText("keyName", keyName)
+	if mnemonic == "" {
+		errs.add("mnemonic", codeMissingField, "'mnemonic' parameter is required.")
+	} else if !mnemonicPattern.MatchString(mnemonic) {
+		errs.add("mnemonic", codeInvalidValue, "'mnemonic' must be 12 to 24 lowercase words separated by spaces.")
+	}
+	if len(errs) > 0 {
+		return validationErrorResult(errs), nil
+	}
+
+	if address, err := getAddressForKey(ctx, keyName); err == nil {
+		return fieldErrorResult("keyName", codeAlreadyExists, fmt.Sprintf("key '%s' already exists with address %s.", keyName, address)), nil
+	}
+
+	address, err := importKey(ctx, keyName, mnemonic)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error importing key %s: %v", keyName, err)}},
+		}, nil
+	}
+
+	response := map[string]interface{}{
+		"summary": fmt.Sprintf("Imported key '%s' with address %s", keyName, address),
+		"details": map[string]interface{}{
+			"keyName": keyName,
+			"address": address,
+		},
+	}
+
+	result := encodeJSON(response)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// importKey runs `keys add --recover`, writing the mnemonic to its stdin
+// rather than its arguments, where it would show up in process listings.
+// keys add opens the keyring before it asks for the mnemonic, so a file or
+// os keyring passphrase goes first; the keyring already exists by then
+// because the caller's `keys show` check opened it.
+func importKey(ctx context.Context, keyName, mnemonic string) (string, error) {
+	args := buildKeysArgs(ctx, "add", keyName, "--recover")
+	passphrase, err := keyringPassphrase(ctx)
+	if err != nil {
+		return "", err
+	}
+	input := mnemonic + "\n"
+	if passphrase != "" {
+		input = passphrase + "\n" + input
+	}
+
+	stdout, _, err := runSecretCommand(ctx, input, swechaindCmd, args...)
+	if err != nil {
+		return "", err
+	}
+
+	var keyData map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &keyData); err != nil {
+		// Don't quote the output back: keep anything key-related out of errors.
+		return "", fmt.Errorf("could not parse keys add output: %v", err)
+	}
+	address := parse.String(keyData, "address")
+	if !isValidCosmosAddress(address) {
+		return "", fmt.Errorf("keys add output did not contain a valid address")
+	}
+	return address, nil
+}
+
+// findMnemonic returns the last line of output that is a mnemonic.
+func findMnemonic(output string) (string, bool) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.Join(strings.Fields(lines[i]), " ")
+		if mnemonicPattern.MatchString(line) {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+// getKeyPubKey returns a local key's address and secp256k1 public key.
+func getKeyPubKey(ctx context.Context, keyName string) (string, []byte, error) {
+	output, err := runCommand(ctx, swechaindCmd, buildKeysArgs(ctx, "show", keyName)...)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get key info: %w", err)
+	}
+
+	var keyData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &keyData); err != nil {
+		return "", nil, parse.OutputError("key data", err, output)
+	}
+
+	pubKey, err := parsePubKey(keyData["pubkey"])
+	if err != nil {
+		return "", nil, err
+	}
+	return parse.String(keyData, "address"), pubKey, nil
+}
+
+// getAddressPubKey returns the public key for an address, from the keyring when
+// it holds the address and otherwise from the on-chain account, which only
+// records a public key once the account has signed a tx.
+func getAddressPubKey(ctx context.Context, address string) ([]byte, error) {
+	keys, err := getKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	if keyName, ok := keyNamesByAddress(keys)[address]; ok {
+		_, pubKey, err := getKeyPubKey(ctx, keyName)
+		return pubKey, err
+	}
+
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "auth", "account", address)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account: %w", err)
+	}
+	return parseAccountPubKey(output)
+}
+
+// parseAccountPubKey reads pub_key from `query auth account` output, flat or
+// nested under account.value like parseAccountSequence.
+func parseAccountPubKey(output string) ([]byte, error) {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return nil, parse.OutputError("account data", err, output)
+	}
+
+	account := responseData
+	if nested, ok := responseData["account"].(map[string]interface{}); ok {
+		account = nested
+		if value, ok := nested["value"].(map[string]interface{}); ok {
+			account = value
+		}
+	}
+
+	if account["pub_key"] == nil {
+		return nil, fmt.Errorf("account has no public key on chain yet; it must sign a transaction first")
+	}
+	return parsePubKey(account["pub_key"])
+}
+
+// createKey adds a new key to the keyring and returns its address. keys add
+// prints the new key's mnemonic, so it goes through runSecretCommand: never
+// logged, and never retried, since a rerun would fail on the existing name.
+func createKey(ctx context.Context, keyName string) (string, error) {
+	args := buildKeysArgs(ctx, "add", keyName)
+	input, err := keyringInput(ctx, args)
+	if err != nil {
+		return "", err
+	}
+	output, _, err := runSecretCommand(ctx, input, swechaindCmd, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to create key: %w", err)
+	}
+
+	var keyData map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &keyData); err != nil {
+		// Don't quote the output back: it holds the new key's mnemonic.
+		return "", fmt.Errorf("could not parse keys add output: %v", err)
+	}
+
+	address := parse.String(keyData, "address")
+	if address == "" {
+		return "", fmt.Errorf("address not found in key creation output")
+	}
+
+	return address, nil
+}
+
+// accountExistsOnChain reports whether `query auth account` knows address.
+// Accounts are created when an address first receives tokens.
+func accountExistsOnChain(ctx context.Context, address string) (bool, error) {
+	_, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "auth", "account", address)...)
+	if err != nil {
+		if isNotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to query account: %w", err)
+	}
+	return true, nil
+}
+
+func getAccountSequence(ctx context.Context, address string) (uint64, error) {
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "auth", "account", address)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query account: %w", err)
+	}
+
+	return parse.AccountSequence(output)
+}
+
+func extractTxHash(output string) string {
+	var txData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &txData); err != nil {
+		return ""
+	}
+	return parse.String(txData, "txhash")
+}
+
+// extractTxRawLog returns the raw_log of a broadcast tx response, which
+// explains a non-zero code.
+func extractTxRawLog(output string) string {
+	var txData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &txData); err != nil {
+		return ""
+	}
+	return parse.String(txData, "raw_log")
+}
+
+// formatTxResult renders a broadcast tx response as a summary/details JSON document,
+// falling back to the raw output when it isn't JSON. A tx rejected with a
+// non-zero code is marked IsError.
+func formatTxResult(action, output string, verbose bool) *mcp.CallToolResultFor[any] {
+	var txData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &txData); err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: output}},
+		}
+	}
+
+	var response TxResultResponse
+	response.Details.TxHash = parse.String(txData, "txhash")
+	response.Details.Code, _ = strconv.Atoi(parse.String(txData, "code"))
+	response.Details.Height = parse.String(txData, "height")
+	response.Details.RawLog = parse.String(txData, "raw_log")
+	// Events are only present once the tx is in a block, e.g. in block
+	// broadcast mode; sync and async results leave these empty.
+	response.Details.Action, _ = findEventAttribute(txData, "message", "action")
+	response.Details.Sender, _ = findEventAttribute(txData, "message", "sender")
+	if verbose {
+		response.Details.Events = txEvents(txData)
+	}
+
+	if response.Details.Code == 0 {
+		response.Summary = fmt.Sprintf("%s submitted in tx %s", action, response.Details.TxHash)
+	} else {
+		response.Summary = fmt.Sprintf("%s failed with code %d in tx %s", action, response.Details.Code, response.Details.TxHash)
+	}
+
+	result := encodeJSON(response)
+	return &mcp.CallToolResultFor[any]{
+		IsError: response.Details.Code != 0,
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}
+}
+
+func isMainnetChainID(id string) bool {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if strings.Contains(id, "mainnet") {
+		return true
+	}
+	for _, mainnet := range strings.Split(*mainnetChainIDs, ",") {
+		if id == strings.ToLower(strings.TrimSpace(mainnet)) {
+			return true
+		}
+	}
+	return false
+}
+
+func buildSetupTestAccountsResponse(accounts []TestAccountResult) SetupTestAccountsResponse {
+	funded := 0
+	for _, account := range accounts {
+		if account.Error == "" {
+			funded++
+		}
+	}
+
+	return SetupTestAccountsResponse{
+		Summary: fmt.Sprintf("Created and funded %d of %d test accounts", funded, len(accounts)),
+		Details: struct {
+			Accounts []TestAccountResult `json:"accounts"`
+		}{
+			Accounts: accounts,
+		},
+	}
+}
+
+// checkChainLiveness returns an error when the latest block is older than
+// threshold. It fails closed: if the node's status can't be read, liveness
+// can't be confirmed and the tx is refused too.
+func checkChainLiveness(ctx context.Context, threshold time.Duration) error {
+	args := append([]string{"status"}, connectionFlags(ctx)...)
+	output, err := runCommand(ctx, swechaindCmd, args...)
+	if err != nil {
+		return fmt.Errorf("could not query node status to confirm the chain is live: %w", err)
+	}
+
+	height, blockTime, err := parse.NodeStatus(output)
+	if err != nil {
+		return fmt.Errorf("could not read node status to confirm the chain is live: %w", err)
+	}
+
+	if age := time.Since(blockTime); age > threshold {
+		return fmt.Errorf("chain appears stalled: latest block %d was produced %s ago (threshold %s)",
+			height, age.Round(time.Second), threshold)
+	}
+	return nil
+}
+
+// blockHeightCache holds the last block-height result so frequent heartbeats
+// don't each shell out to swechaind.
+var blockHeightCache struct {
+	sync.Mutex
+	height    int64
+	chainID   string
+	fetchedAt time.Time
+}
+
+func blockHeightHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[BlockHeightParams]) (*mcp.CallToolResultFor[any], error) {
+	// The lock only guards the cached values: a status query that retries
+	// against a slow node must not hold up every other heartbeat.
+	blockHeightCache.Lock()
+	height, chainID := blockHeightCache.height, blockHeightCache.chainID
+	fresh := time.Since(blockHeightCache.fetchedAt) < blockHeightCacheTTL
+	blockHeightCache.Unlock()
+
+	if !fresh {
+		args := append([]string{"status"}, connectionFlags(ctx)...)
+		output, err := runCommand(ctx, swechaindCmd, args...)
+		if err != nil {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying node status: %v", err)}},
+			}, nil
+		}
+
+		height, _, err = parse.NodeStatus(output)
+		if err != nil {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error parsing node status: %v", err)}},
+			}, nil
+		}
+		chainID = parseStatusNetwork(ctx, output)
+
+		blockHeightCache.Lock()
+		blockHeightCache.height = height
+		blockHeightCache.chainID = chainID
+		blockHeightCache.fetchedAt = time.Now()
+		blockHeightCache.Unlock()
+	}
+
+	response := map[string]interface{}{
+		"summary": fmt.Sprintf("Latest block height on %s is %d", chainID, height),
+		"details": map[string]interface{}{
+			"height":  height,
+			"chainId": chainID,
+		},
+	}
+
+	result := marshalResponse(response, "")
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// chainInfoCache holds the last chain-info response. The params it reports
+// change only through governance, so it is kept much longer than the block
+// height cache.
+var chainInfoCache struct {
+	sync.Mutex
+	response  ChainInfoResponse
+	fetchedAt time.Time
+}
+
+func chainInfoHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[ChainInfoParams]) (*mcp.CallToolResultFor[any], error) {
+	// As with block-height, fetch without holding the lock.
+	chainInfoCache.Lock()
+	response := chainInfoCache.response
+	fresh := time.Since(chainInfoCache.fetchedAt) < chainInfoCacheTTL
+	chainInfoCache.Unlock()
+
+	if !fresh {
+		logf(ctx, "INFO: Querying chain info")
+
+		args := append([]string{"status"}, connectionFlags(ctx)...)
+		statusOutput, err := runCommand(ctx, swechaindCmd, args...)
+		if err != nil {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying node status: %v", err)}},
+			}, nil
+		}
+
+		// Staking and mint are optional: a chain without the module simply
+		// omits that section rather than failing the whole call.
+		stakingParams := queryOptionalModuleParams(ctx, "staking")
+		mintParams := queryOptionalModuleParams(ctx, "mint")
+
+		response, err = buildChainInfoResponse(ctx, statusOutput, stakingParams, mintParams)
+		if err != nil {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error parsing node status: %v", err)}},
+			}, nil
+		}
+
+		chainInfoCache.Lock()
+		chainInfoCache.response = response
+		chainInfoCache.fetchedAt = time.Now()
+		chainInfoCache.Unlock()
+	}
+
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+func networkCongestionHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[NetworkCongestionParams]) (*mcp.CallToolResultFor[any], error) {
+	sample := params.Arguments.Blocks
+	if sample <= 0 {
+		sample = defaultCongestionBlocks
+	}
+	if sample > maxCongestionBlocks {
+		return fieldErrorResult("blocks", codeOutOfRange, fmt.Sprintf("'blocks' may be at most %d (got %d).", maxCongestionBlocks, sample)), nil
+	}
+	logf(ctx, "INFO: Sampling the last %d blocks for congestion", sample)
+
+	args := append([]string{"status"}, connectionFlags(ctx)...)
+	statusOutput, err := runCommand(ctx, swechaindCmd, args...)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying node status: %v", err)}},
+		}, nil
+	}
+	latest, _, err := parse.NodeStatus(statusOutput)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error parsing node status: %v", err)}},
+		}, nil
+	}
+
+	var blocks []parse.BlockGas
+	for height := latest; height > 0 && height > latest-int64(sample); height-- {
+		output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "block-results", strconv.FormatInt(height, 10))...)
+		if err != nil {
+			logf(ctx, "WARNING: Could not fetch block results for height %d: %v", height, errorForLog(err))
+			continue
+		}
+		block, err := parse.BlockResults(output)
+		if err != nil {
+			logf(ctx, "WARNING: Could not parse block results for height %d: %v", height, errorForLog(err))
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	if len(blocks) == 0 {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: "Error: no block results could be fetched for recent blocks."}},
+		}, nil
+	}
+
+	// The gas limit only sharpens the estimate; without it the fixed
+	// thresholds are used.
+	var maxGas int64
+	if output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "consensus", "params")...); err == nil {
+		maxGas, _ = parse.ConsensusMaxGas(output)
+	}
+
+	response := buildNetworkCongestionResponse(blocks, maxGas)
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
+
+// buildNetworkCongestionResponse averages gas over the sampled blocks and
+// rates congestion: against the block gas limit when maxGas is positive
+// (high from 80%, medium from 50%), and against congestionMediumGas and
+// congestionHighGas otherwise.
+func buildNetworkCongestionResponse(blocks []parse.BlockGas, maxGas int64) NetworkCongestionResponse {
+	var totalGas int64
+	var totalTxs int
+	for _, block := range blocks {
+		totalGas += block.GasUsed
+		totalTxs += block.Txs
+	}
+
+	var response NetworkCongestionResponse
+	response.Details.Blocks = blocks
+	response.Details.AvgGasUsed = totalGas / int64(len(blocks))
+	response.Details.AvgTxs = float64(totalTxs) / float64(len(blocks))
+
+	avg := response.Details.AvgGasUsed
+	congestion := "low"
+	if maxGas > 0 {
+		utilization := float64(avg) / float64(maxGas)
+		response.Details.MaxBlockGas = maxGas
+		response.Details.GasUtilization = math.Round(utilization*10000) / 100
+		switch {
+		case utilization >= 0.8:
+			congestion = "high"
+		case utilization >= 0.5:
+			congestion = "medium"
+		}
+	} else {
+		switch {
+		case avg >= congestionHighGas:
+			congestion = "high"
+		case avg >= congestionMediumGas:
+			congestion = "medium"
+		}
+	}
+	response.Details.Congestion = congestion
+	response.Summary = fmt.Sprintf("Congestion is %s: %d gas and %.1f txs per block on average over the last %d blocks",
+		congestion, avg, response.Details.AvgTxs, len(blocks))
+	return response
+}
+
+// queryOptionalModuleParams returns module's params, or nil when the query
+// fails or the chain doesn't have the module.
+func queryOptionalModuleParams(ctx context.Context, module string) map[string]interface{} {
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, module, "params")...)
+	if err != nil {
+		logf(ctx, "INFO: %s params unavailable: %v", module, errorForLog(err))
+		return nil
+	}
+	moduleParams, err := parse.ModuleParams(output)
+	if err != nil {
+		logf(ctx, "INFO: %s params unavailable: %v", module, errorForLog(err))
+		return nil
+	}
+	return moduleParams
+}
+
+// buildChainInfoResponse combines `swechaind status` output with staking and
+// mint params, either of which may be nil.
+func buildChainInfoResponse(ctx context.Context, statusOutput string, stakingParams, mintParams map[string]interface{}) (ChainInfoResponse, error) {
+	var response ChainInfoResponse
+
+	height, blockTime, err := parse.NodeStatus(statusOutput)
+	if err != nil {
+		return response, err
+	}
+
+	response.Details.ChainID = parseStatusNetwork(ctx, statusOutput)
+	response.Details.LatestHeight = height
+	response.Details.LatestBlockTime = blockTime.UTC().Format(time.RFC3339)
+	response.Details.BondDenom = parse.String(stakingParams, "bond_denom")
+	response.Details.Staking = stakingParams
+	response.Details.Mint = mintParams
+
+	response.Summary = fmt.Sprintf("Chain %s at height %d", response.Details.ChainID, height)
+	if response.Details.BondDenom != "" {
+		response.Summary += fmt.Sprintf(", bond denom %s", response.Details.BondDenom)
+	}
+	if inflationMax := parse.String(mintParams, "inflation_max"); inflationMax != "" {
+		response.Summary += fmt.Sprintf(", inflation between %s and %s", parse.String(mintParams, "inflation_min"), inflationMax)
+	}
+	return response, nil
+}
+
+// parseStatusNetwork returns the chain id reported by `swechaind status`,
+// accepting both the node_info and legacy NodeInfo keys. It falls back to
+// -chain-id when the status output doesn't include one.
+func parseStatusNetwork(ctx context.Context, output string) string {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err == nil {
+		nodeInfo, ok := responseData["node_info"].(map[string]interface{})
+		if !ok {
+			nodeInfo, _ = responseData["NodeInfo"].(map[string]interface{})
+		}
+		if network := parse.String(nodeInfo, "network"); network != "" {
+			return network
+		}
+	}
+	return configFrom(ctx).chainID
+}
+
+func getBalanceForAddress(ctx context.Context, address string) ([]Balance, error) {
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "bank", "balances", address)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query balance: %w", err)
+	}
+	return parse.Balances(output)
+}
+
+// getSpendableBalances returns the balances an address can spend now, excluding
+// tokens locked in vesting schedules.
+func getSpendableBalances(ctx context.Context, address string) ([]Balance, error) {
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "bank", "spendable-balances", address)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query spendable balance: %w", err)
+	}
+	return parse.Balances(output)
+}
+
+// sleepContext waits for d, returning false if ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// getAuction fetches a single auction via show-auction.
+func getAuction(ctx context.Context, auctionId string) (Auction, error) {
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "issuemarket", "show-auction", auctionId)...)
+	if err != nil {
+		return Auction{}, fmt.Errorf("failed to query auction: %w", err)
+	}
+
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return Auction{}, parse.OutputError("auction data", err, output)
+	}
+
+	raw, ok := responseData["Auction"].(map[string]interface{})
+	if !ok {
+		raw, ok = responseData["auction"].(map[string]interface{})
+	}
+	if !ok {
+		return Auction{}, fmt.Errorf("auction not found in response")
+	}
+
+	return parse.Auctions([]map[string]interface{}{raw})[0], nil
+}
+
+// getKeys lists the local keyring.
+func getKeys(ctx context.Context) ([]Key, error) {
+	output, err := runCommand(ctx, swechaindCmd, buildKeysArgs(ctx, "list")...)
+	if err != nil {
+		return nil, err
+	}
+	return parse.Keys(output)
+}
+
+// filterKeysByPrefix returns the keys whose names start with prefix, sorted by name.
+func filterKeysByPrefix(keys []Key, prefix string) []Key {
+	matches := []Key{}
+	for _, key := range keys {
+		if strings.HasPrefix(key.Name, prefix) {
+			matches = append(matches, key)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+	return matches
+}
+
+// keyNamesByAddress maps each local keyring address to its key name.
+func keyNamesByAddress(keys []Key) map[string]string {
+	names := make(map[string]string, len(keys))
+	for _, key := range keys {
+		names[key.Address] = key.Name
+	}
+	return names
+}
+
+// collectParticipants builds the participant list according to -participants:
+// "denom-owners" lists holders of the default denom, while "involved" fetches
+// full balances for every address appearing in the given auctions and their bids.
+func collectParticipants(ctx context.Context, auctions []Auction, bids []Bid, keyNames map[string]string) ([]ParticipantDetail, error) {
+	if *participantsMode != "involved" {
+		owners, err := fetchDenomOwners(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return participantsFromOwners(owners, keyNames), nil
+	}
+
+	auctionIDs := make(map[int]bool)
+	var addresses []string
+	seen := make(map[string]bool)
+	addAddress := func(address string) {
+		if address != "" && !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+
+	for _, auction := range auctions {
+		auctionIDs[auction.ID] = true
+		addAddress(auction.Creator)
+		addAddress(auction.Winner)
+	}
+	for _, bid := range bids {
+		if auctionIDs[bid.AuctionID] {
+			addAddress(bid.Bidder)
+			addAddress(bid.Creator)
+		}
+	}
+
+	balances := make(map[string][]Balance, len(addresses))
+	for _, address := range addresses {
+		if !isValidCosmosAddress(address) {
+			continue
+		}
+		addressBalances, err := getBalanceForAddress(ctx, address)
+		if err != nil {
+			logf(ctx, "Error fetching balance for participant %s: %v", address, errorForLog(err))
+			continue
+		}
+		balances[address] = addressBalances
+	}
 
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "close-auction",
-		Description: "Close/update an auction. Required: auctionId, status, issue, description, winner, from.",
-	}, closeAuctionHandler)
+	return participantsFromBalances(addresses, balances, keyNames), nil
+}
 
-	/*
-		mcp.AddTool(server, &mcp.Tool{
-			Name:        "create-and-fund-address",
-			Description: "Use only for new users. Create new key and fund it. Required: keyName, funderAddress. Optional: amount.",
-		}, createAndFundAddressHandler)
-	*/
+func participantsFromOwners(owners []DenomOwner, keyNames map[string]string) []ParticipantDetail {
+	var participants []ParticipantDetail
+	for _, owner := range owners {
+		participants = append(participants, ParticipantDetail{
+			Name:    participantName(owner.Address, keyNames),
+			Address: owner.Address,
+			Balance: fmt.Sprintf("%s %s", owner.Balance.Amount, owner.Balance.Denom),
+		})
+	}
+	return participants
+}
 
-	log.Println("MCP server starting on stdio")
-	if err := server.Run(context.Background(), mcp.NewStdioTransport()); err != nil {
-		log.Printf("Server error: %v", err)
+func participantsFromBalances(addresses []string, balances map[string][]Balance, keyNames map[string]string) []ParticipantDetail {
+	var participants []ParticipantDetail
+	for _, address := range addresses {
+		addressBalances, ok := balances[address]
+		if !ok {
+			continue
+		}
+
+		parts := make([]string, 0, len(addressBalances))
+		for _, balance := range addressBalances {
+			parts = append(parts, fmt.Sprintf("%s %s", balance.Amount, balance.Denom))
+		}
+
+		participants = append(participants, ParticipantDetail{
+			Name:     participantName(address, keyNames),
+			Address:  address,
+			Balance:  strings.Join(parts, ", "),
+			Balances: addressBalances,
+		})
 	}
-	log.Println("MCP server stopped")
+	return participants
 }
 
-// Enhanced handlers with better error handling and validation
+func buildAuctionSummaryResponse(ctx context.Context, auctions []Auction, bids []Bid, participants []ParticipantDetail, keyNames map[string]string, auctionType string) AuctionSummaryResponse {
+	var auctionDetails []AuctionDetail
 
-func getAddressForKeyHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetAddressForKeyParams]) (*mcp.CallToolResultFor[any], error) {
-	log.Printf("INFO: Getting address for key: %s", params.Arguments.KeyName)
+	for _, auction := range auctions {
+		var auctionBids []BidDetail
+		var currentBidAmount string = "0" + configFrom(ctx).denom
 
-	keyName := strings.TrimSpace(params.Arguments.KeyName)
-	if keyName == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: keyName parameter is required and cannot be empty."}},
-		}, nil
+		var matching []Bid
+		for _, bid := range bids {
+			if bid.AuctionID == auction.ID {
+				auctionBids = append(auctionBids, BidDetail{
+					Bidder:      bid.Bidder,
+					Amount:      bid.Amount,
+					Description: bid.Description,
+				})
+				matching = append(matching, bid)
+			}
+		}
+		if highest, ok := parse.HighestBid(matching); ok {
+			currentBidAmount = highest.Amount
+		}
+
+		auctionDetails = append(auctionDetails, AuctionDetail{
+			AuctionID:        auction.ID,
+			Issue:            auction.Issue,
+			Creator:          auction.Creator,
+			CreatorName:      keyNames[auction.Creator],
+			Description:      auction.Description,
+			Status:           auction.Status,
+			Winner:           auction.Winner,
+			CurrentBidAmount: currentBidAmount,
+			Bids:             auctionBids,
+		})
 	}
 
-	address, err := getAddressForKey(keyName)
-	if err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error getting address for key %s: %v", keyName, err)}},
-		}, nil
+	var summary string
+	if auctionType == "open" {
+		bidCount := 0
+		for _, detail := range auctionDetails {
+			if len(detail.Bids) > 0 {
+				bidCount++
+			}
+		}
+
+		if len(auctions) == 0 {
+			summary = "No open auctions found."
+		} else {
+			summary = fmt.Sprintf("There are %d open auctions (%d with bids, %d without bids).",
+				len(auctions), bidCount, len(auctions)-bidCount)
+		}
+	} else {
+		summary = fmt.Sprintf("There are %d total auctions with %d total bids.", len(auctions), len(bids))
 	}
 
-	response := map[string]interface{}{
-		"summary": fmt.Sprintf("Address for key '%s': %s", keyName, address),
-		"details": map[string]interface{}{
-			"keyName": keyName,
-			"address": address,
+	return AuctionSummaryResponse{
+		Summary: summary,
+		Details: struct {
+			Auctions     []AuctionDetail     `json:"auctions"`
+			Participants []ParticipantDetail `json:"participants"`
+		}{
+			Auctions:     auctionDetails,
+			Participants: participants,
 		},
 	}
-
-	result, _ := json.MarshalIndent(response, "", "  ")
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
-	}, nil
 }
 
-func getBalanceHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetBalanceParams]) (*mcp.CallToolResultFor[any], error) {
-	log.Printf("INFO: Getting balance for address: %s", params.Arguments.Address)
+// countBids counts bids for a numeric auction ID, or all bids when auctionId is "all".
+func countBids(bids []Bid, auctionId string) (int, error) {
+	if strings.ToLower(auctionId) == "all" {
+		return len(bids), nil
+	}
 
-	address := strings.TrimSpace(params.Arguments.Address)
-	if address == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: address parameter is required and cannot be empty."}},
-		}, nil
+	auctionIdInt, err := strconv.Atoi(auctionId)
+	if err != nil {
+		return 0, &FieldError{Field: "auctionId", Code: codeInvalidAuctionID, Message: fmt.Sprintf("invalid auctionId '%s'. Must be a number or 'all'", auctionId)}
 	}
 
-	if !isValidCosmosAddress(address) {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: address must be a valid cosmos address (cosmos1...)."}},
-		}, nil
+	count := 0
+	for _, bid := range bids {
+		if bid.AuctionID == auctionIdInt {
+			count++
+		}
 	}
+	return count, nil
+}
 
-	balances, err := getBalanceForAddress(address)
-	if err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error getting balance for address %s: %v", address, err)}},
-		}, nil
+func buildAuctionActivityResponse(auctionID int, bids []Bid, bidTimes []time.Time, now time.Time) AuctionActivityResponse {
+	var response AuctionActivityResponse
+	response.Details.AuctionID = auctionID
+	response.Details.BidCount = len(bids)
+
+	amounts := make([]string, 0, len(bids))
+	for _, bid := range bids {
+		amounts = append(amounts, bid.Amount)
 	}
+	response.Details.Amounts = amounts
+	response.Details.Trend = bidAmountTrend(amounts)
 
-	// Calculate total balance summary
-	var totalBalance string = "0"
-	var denom string = "token"
+	if len(bids) == 0 {
+		response.Summary = fmt.Sprintf("Auction %d has no bids", auctionID)
+		return response
+	}
 
-	if len(balances) > 0 {
-		totalBalance = balances[0].Amount
-		denom = balances[0].Denom
+	var lastBid time.Time
+	for _, t := range bidTimes {
+		if t.After(lastBid) {
+			lastBid = t
+		}
 	}
 
-	response := BalanceResponse{
-		Summary: fmt.Sprintf("Address %s has %s %s", address, totalBalance, denom),
-		Details: struct {
-			Address  string    `json:"address"`
-			Balances []Balance `json:"balances"`
-		}{
-			Address:  address,
-			Balances: balances,
-		},
+	if lastBid.IsZero() {
+		response.Summary = fmt.Sprintf("Auction %d has %d bids (trend: %s); last bid time unknown",
+			auctionID, len(bids), response.Details.Trend)
+		return response
 	}
 
-	result, _ := json.MarshalIndent(response, "", "  ")
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
-	}, nil
+	since := now.Sub(lastBid).Round(time.Second)
+	response.Details.LastBidAt = lastBid.UTC().Format(time.RFC3339)
+	response.Details.TimeSinceLastBid = since.String()
+	response.Summary = fmt.Sprintf("Auction %d has %d bids (trend: %s); last bid %s ago",
+		auctionID, len(bids), response.Details.Trend, since)
+	return response
 }
 
-func queryOpenAuctionsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[QueryOpenAuctionsParams]) (*mcp.CallToolResultFor[any], error) {
-	log.Printf("INFO: Querying open auctions")
-
-	// Get data with error handling
-	rawAuctions := fetchPaginatedData("issuemarket", "list-auction", "Auction")
-	rawBids := fetchPaginatedData("issuemarket", "list-bid", "Bid")
-	owners := fetchDenomOwners()
+// bidTxTimes returns the timestamps of create-bid messages for auctionID in the given txs.
+func bidTxTimes(txResponses []map[string]interface{}, auctionID int) []time.Time {
+	var times []time.Time
+	for _, txResponse := range txResponses {
+		timestamp, err := time.Parse(time.RFC3339, parse.String(txResponse, "timestamp"))
+		if err != nil {
+			continue
+		}
+		for _, message := range txMessages(txResponse) {
+			if !strings.HasSuffix(parse.String(message, "@type"), "MsgCreateBid") {
+				continue
+			}
+			if id, err := strconv.Atoi(parse.String(message, "auctionId")); err == nil && id == auctionID {
+				times = append(times, timestamp)
+			}
+		}
+	}
+	return times
+}
 
-	auctions := parseAuctions(rawAuctions)
-	bids := parseBids(rawBids)
+// bidAmountTrend classifies a sequence of bid amounts as increasing, decreasing,
+// flat, or mixed. Amounts that can't be compared yield "unknown".
+func bidAmountTrend(amounts []string) string {
+	switch len(amounts) {
+	case 0:
+		return "none"
+	case 1:
+		return "single"
+	}
 
-	// Filter open auctions
-	var openAuctions []Auction
-	for _, auction := range auctions {
-		if strings.ToLower(strings.TrimSpace(auction.Status)) == "open" {
-			openAuctions = append(openAuctions, auction)
+	var values []*big.Int
+	denom := ""
+	for _, amount := range amounts {
+		n, d, err := parse.CoinAmount(amount)
+		if err != nil || (denom != "" && d != denom) {
+			return "unknown"
 		}
+		denom = d
+		values = append(values, n)
 	}
 
-	// Build enhanced response
-	response := buildAuctionSummaryResponse(openAuctions, bids, owners, "open")
+	up, down := false, false
+	for i := 1; i < len(values); i++ {
+		switch values[i].Cmp(values[i-1]) {
+		case 1:
+			up = true
+		case -1:
+			down = true
+		}
+	}
 
-	result, _ := json.MarshalIndent(response, "", "  ")
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
-	}, nil
+	switch {
+	case up && down:
+		return "mixed"
+	case up:
+		return "increasing"
+	case down:
+		return "decreasing"
+	default:
+		return "flat"
+	}
 }
 
-func queryAllAuctionsHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[QueryAllAuctionsParams]) (*mcp.CallToolResultFor[any], error) {
-	log.Printf("INFO: Querying all auctions")
+// buildAuctionLeaderboardResponse ranks bids by amount, descending. Equal amounts
+// share a rank (1, 1, 3, ...); bids with unparseable amounts, or in a denom other
+// than the auction's (see auctionBidDenom), are listed last with rank 0.
+func buildAuctionLeaderboardResponse(auctionID int, bids []Bid) AuctionLeaderboardResponse {
+	type rankedBid struct {
+		bid   Bid
+		value *big.Int
+	}
 
-	rawAuctions := fetchPaginatedData("issuemarket", "list-auction", "Auction")
-	rawBids := fetchPaginatedData("issuemarket", "list-bid", "Bid")
-	owners := fetchDenomOwners()
+	auctionDenom := parse.AuctionBidDenom(bids)
+	var ranked, unranked []rankedBid
+	for _, bid := range bids {
+		value, denom, err := parse.CoinAmount(bid.Amount)
+		if err != nil || denom != auctionDenom {
+			unranked = append(unranked, rankedBid{bid: bid})
+			continue
+		}
+		ranked = append(ranked, rankedBid{bid: bid, value: value})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].value.Cmp(ranked[j].value) > 0 })
 
-	auctions := parseAuctions(rawAuctions)
-	bids := parseBids(rawBids)
+	leader, hasLeader := parse.HighestBid(bids)
 
-	response := buildAuctionSummaryResponse(auctions, bids, owners, "all")
+	entries := make([]LeaderboardEntry, 0, len(bids))
+	leaderMarked := false
+	for i, r := range ranked {
+		rank := i + 1
+		if i > 0 && r.value.Cmp(ranked[i-1].value) == 0 {
+			rank = entries[i-1].Rank
+		}
+		isLeader := hasLeader && !leaderMarked && r.bid == leader
+		if isLeader {
+			leaderMarked = true
+		}
+		entries = append(entries, LeaderboardEntry{
+			Rank:        rank,
+			Bidder:      r.bid.Bidder,
+			Amount:      r.bid.Amount,
+			Description: r.bid.Description,
+			IsLeader:    isLeader,
+		})
+	}
+	for _, r := range unranked {
+		entries = append(entries, LeaderboardEntry{
+			Bidder:      r.bid.Bidder,
+			Amount:      r.bid.Amount,
+			Description: r.bid.Description,
+		})
+	}
 
-	result, _ := json.MarshalIndent(response, "", "  ")
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
-	}, nil
+	var response AuctionLeaderboardResponse
+	response.Details.AuctionID = auctionID
+	response.Details.Entries = entries
+	if hasLeader {
+		response.Summary = fmt.Sprintf("Auction %d has %d bids; %s leads with %s", auctionID, len(bids), leader.Bidder, leader.Amount)
+	} else {
+		response.Summary = fmt.Sprintf("Auction %d has no ranked bids", auctionID)
+	}
+	return response
 }
 
-func queryBidsForAuctionHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[QueryBidsForAuctionParams]) (*mcp.CallToolResultFor[any], error) {
-	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
-	log.Printf("INFO: Querying bids for auction: %s", auctionId)
+// buildClosedAuctionsResponse lists closed auctions with their winner and the
+// winning (highest) bid. The chain doesn't record when an auction closed, so
+// they're ordered by auction ID, i.e. creation order.
+func buildClosedAuctionsResponse(auctions []Auction, bids []Bid) ClosedAuctionsResponse {
+	bidsByAuction := make(map[int][]Bid)
+	for _, bid := range bids {
+		bidsByAuction[bid.AuctionID] = append(bidsByAuction[bid.AuctionID], bid)
+	}
 
-	if auctionId == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: auctionId parameter is required. Use specific auction ID or 'all' for all bids."}},
-		}, nil
+	var response ClosedAuctionsResponse
+	response.Details.Auctions = []ClosedAuctionEntry{}
+	for _, auction := range auctions {
+		if parse.NormalizeStatus(auction.Status) != "closed" {
+			continue
+		}
+
+		entry := ClosedAuctionEntry{
+			AuctionID:     auction.ID,
+			Issue:         auction.Issue,
+			Creator:       auction.Creator,
+			Winner:        auction.Winner,
+			WinningAmount: "0",
+			BidCount:      len(bidsByAuction[auction.ID]),
+		}
+		if leader, ok := parse.HighestBid(bidsByAuction[auction.ID]); ok {
+			entry.WinningAmount = leader.Amount
+			if entry.Winner == "" {
+				entry.Winner = leader.Bidder
+			}
+		}
+		response.Details.Auctions = append(response.Details.Auctions, entry)
 	}
+	sort.Slice(response.Details.Auctions, func(i, j int) bool {
+		return response.Details.Auctions[i].AuctionID < response.Details.Auctions[j].AuctionID
+	})
 
-	rawBids := fetchPaginatedData("issuemarket", "list-bid", "Bid")
-	bids := parseBids(rawBids)
+	response.Summary = fmt.Sprintf("Found %d closed auctions", len(response.Details.Auctions))
+	return response
+}
 
-	// Filter by auction if not 'all'
-	if strings.ToLower(auctionId) != "all" {
-		auctionIdInt, err := strconv.Atoi(auctionId)
-		if err != nil {
-			return &mcp.CallToolResultFor[any]{
-				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: invalid auctionId '%s'. Must be a number or 'all'.", auctionId)}},
-			}, nil
+// buildAuctionsWonResponse lists the auctions whose recorded winner is address.
+// The winning amount is the winner's highest bid on the auction.
+func buildAuctionsWonResponse(address string, auctions []Auction, bids []Bid) AuctionsWonResponse {
+	winnerBids := make(map[int][]Bid)
+	bidCounts := make(map[int]int)
+	for _, bid := range bids {
+		bidCounts[bid.AuctionID]++
+		if bid.Bidder == address {
+			winnerBids[bid.AuctionID] = append(winnerBids[bid.AuctionID], bid)
 		}
+	}
 
-		var filteredBids []Bid
-		for _, bid := range bids {
-			if bid.AuctionID == auctionIdInt {
-				filteredBids = append(filteredBids, bid)
+	var response AuctionsWonResponse
+	response.Details.Winner = address
+	response.Details.Auctions = []ClosedAuctionEntry{}
+
+	totals := make(map[string]*big.Int)
+	for _, auction := range auctions {
+		if auction.Winner != address {
+			continue
+		}
+
+		entry := ClosedAuctionEntry{
+			AuctionID:     auction.ID,
+			Issue:         auction.Issue,
+			Creator:       auction.Creator,
+			Winner:        auction.Winner,
+			WinningAmount: "0",
+			BidCount:      bidCounts[auction.ID],
+		}
+		if winning, ok := parse.HighestBid(winnerBids[auction.ID]); ok {
+			entry.WinningAmount = winning.Amount
+			if value, denom, err := parse.CoinAmount(winning.Amount); err == nil {
+				if totals[denom] == nil {
+					totals[denom] = new(big.Int)
+				}
+				totals[denom].Add(totals[denom], value)
 			}
 		}
-		bids = filteredBids
+		response.Details.Auctions = append(response.Details.Auctions, entry)
 	}
+	sort.Slice(response.Details.Auctions, func(i, j int) bool {
+		return response.Details.Auctions[i].AuctionID < response.Details.Auctions[j].AuctionID
+	})
 
-	var summary string
-	if strings.ToLower(auctionId) == "all" {
-		summary = fmt.Sprintf("Found %d total bids across all auctions", len(bids))
-	} else {
-		summary = fmt.Sprintf("Found %d bids for auction %s", len(bids), auctionId)
+	response.Details.TotalsByDenom = make(map[string]string)
+	for denom, total := range totals {
+		response.Details.TotalsByDenom[denom] = total.String()
 	}
 
-	response := map[string]interface{}{
-		"summary": summary,
-		"details": map[string]interface{}{
-			"bids": bids,
-		},
+	response.Summary = fmt.Sprintf("Address %s has won %d auctions", address, len(response.Details.Auctions))
+	return response
+}
+
+// buildTVLAuctionsResponse sums the highest bid of every open auction per
+// denom. Open auctions without a parseable bid contribute nothing.
+func buildTVLAuctionsResponse(auctions []Auction, bids []Bid) TVLAuctionsResponse {
+	bidsByAuction := make(map[int][]Bid)
+	for _, bid := range bids {
+		bidsByAuction[bid.AuctionID] = append(bidsByAuction[bid.AuctionID], bid)
 	}
 
-	result, _ := json.MarshalIndent(response, "", "  ")
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
-	}, nil
-}
+	var response TVLAuctionsResponse
+	totals := make(map[string]*big.Int)
+	for _, auction := range auctions {
+		if auction.Status != "open" {
+			continue
+		}
+		response.Details.OpenAuctions++
 
-func getBlockchainStatusHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetBlockchainStatusParams]) (*mcp.CallToolResultFor[any], error) {
-	log.Printf("INFO: Getting blockchain status")
+		highest, ok := parse.HighestBid(bidsByAuction[auction.ID])
+		if !ok {
+			continue
+		}
+		value, denom, err := parse.CoinAmount(highest.Amount)
+		if err != nil {
+			continue
+		}
+		if totals[denom] == nil {
+			totals[denom] = new(big.Int)
+		}
+		totals[denom].Add(totals[denom], value)
+		response.Details.AuctionsWithBids++
+	}
 
-	rawAuctions := fetchPaginatedData("issuemarket", "list-auction", "Auction")
-	rawBids := fetchPaginatedData("issuemarket", "list-bid", "Bid")
-	owners := fetchDenomOwners()
-	keys := getKeys()
+	response.Details.TotalsByDenom = make(map[string]string)
+	denoms := make([]string, 0, len(totals))
+	for denom, total := range totals {
+		response.Details.TotalsByDenom[denom] = total.String()
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
 
-	auctions := parseAuctions(rawAuctions)
-	bids := parseBids(rawBids)
+	if len(denoms) == 0 {
+		response.Summary = fmt.Sprintf("No value locked: %d open auctions, none with bids", response.Details.OpenAuctions)
+		return response
+	}
+	parts := make([]string, 0, len(denoms))
+	for _, denom := range denoms {
+		parts = append(parts, response.Details.TotalsByDenom[denom]+denom)
+	}
+	response.Summary = fmt.Sprintf("%s locked across %d of %d open auctions",
+		strings.Join(parts, ", "), response.Details.AuctionsWithBids, response.Details.OpenAuctions)
+	return response
+}
 
-	// Count open auctions
-	openCount := 0
-	for _, auction := range auctions {
-		if strings.ToLower(strings.TrimSpace(auction.Status)) == "open" {
-			openCount++
-		}
+// buildCreatorCapacityResponse compares the creator's spendable balance with the
+// auction's current high bid. With no bids there is nothing to cover.
+func buildCreatorCapacityResponse(auction Auction, balances []Balance, bids []Bid) CreatorCapacityResponse {
+	var response CreatorCapacityResponse
+	response.Details.AuctionID = auction.ID
+	response.Details.Creator = auction.Creator
+	response.Details.SpendableBalances = balances
+	if response.Details.SpendableBalances == nil {
+		response.Details.SpendableBalances = []Balance{}
 	}
+	response.Details.CurrentBidAmount = "0"
 
-	response := BlockchainStatusResponse{
-		Summary: fmt.Sprintf("Blockchain has %d total auctions (%d open), %d bids, %d keys, and %d token holders",
-			len(auctions), openCount, len(bids), len(keys), len(owners)),
-		Details: struct {
-			TotalAuctions int `json:"totalAuctions"`
-			OpenAuctions  int `json:"openAuctions"`
-			TotalBids     int `json:"totalBids"`
-			TotalKeys     int `json:"totalKeys"`
-			TokenHolders  int `json:"tokenHolders"`
-		}{
-			TotalAuctions: len(auctions),
-			OpenAuctions:  openCount,
-			TotalBids:     len(bids),
-			TotalKeys:     len(keys),
-			TokenHolders:  len(owners),
-		},
+	leader, ok := parse.HighestBid(bids)
+	if !ok {
+		response.Details.CanCoverCurrentBid = true
+		response.Summary = fmt.Sprintf("Auction %d creator %s has %d spendable denoms; the auction has no bids yet",
+			auction.ID, auction.Creator, len(balances))
+		return response
+	}
+
+	response.Details.CurrentBidAmount = leader.Amount
+	bidValue, bidDenom, _ := parse.CoinAmount(leader.Amount)
+	spendable := new(big.Int)
+	for _, balance := range balances {
+		if balance.Denom != bidDenom {
+			continue
+		}
+		if value, err := parse.Amount(balance.Amount); err == nil {
+			spendable = value
+		}
 	}
+	response.Details.CanCoverCurrentBid = spendable.Cmp(bidValue) >= 0
 
-	result, _ := json.MarshalIndent(response, "", "  ")
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
-	}, nil
+	coverage := "can"
+	if !response.Details.CanCoverCurrentBid {
+		coverage = "cannot"
+	}
+	response.Summary = fmt.Sprintf("Auction %d creator %s has %s%s spendable and %s cover the current bid of %s",
+		auction.ID, auction.Creator, spendable, bidDenom, coverage, leader.Amount)
+	return response
 }
 
-func getKeysHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetKeysParams]) (*mcp.CallToolResultFor[any], error) {
-	log.Printf("INFO: Getting all keys")
+// buildMyAuctionsResponse lists the auctions address created or currently leads,
+// ordered by auction ID. An auction matching both is listed once with both flags set.
+func buildMyAuctionsResponse(keyName, address string, auctions []Auction, bids []Bid) MyAuctionsResponse {
+	bidsByAuction := make(map[int][]Bid)
+	for _, bid := range bids {
+		bidsByAuction[bid.AuctionID] = append(bidsByAuction[bid.AuctionID], bid)
+	}
 
-	keys := getKeys()
+	var response MyAuctionsResponse
+	response.Details.KeyName = keyName
+	response.Details.Address = address
+	response.Details.Auctions = []MyAuctionEntry{}
 
-	response := KeySummaryResponse{
-		Summary: fmt.Sprintf("Found %d keys in the keyring", len(keys)),
-		Details: struct {
-			Keys []Key `json:"keys"`
-		}{
-			Keys: keys,
-		},
+	for _, auction := range auctions {
+		leader, hasLeader := parse.HighestBid(bidsByAuction[auction.ID])
+		isCreator := auction.Creator == address
+		isHighBidder := hasLeader && leader.Bidder == address
+		if !isCreator && !isHighBidder {
+			continue
+		}
+
+		entry := MyAuctionEntry{
+			AuctionID:        auction.ID,
+			Issue:            auction.Issue,
+			Status:           auction.Status,
+			IsCreator:        isCreator,
+			IsHighBidder:     isHighBidder,
+			CurrentBidAmount: "0",
+		}
+		if hasLeader {
+			entry.CurrentBidAmount = leader.Amount
+			entry.HighBidder = leader.Bidder
+		}
+		if isCreator {
+			response.Details.Created++
+		}
+		if isHighBidder {
+			response.Details.Leading++
+		}
+		response.Details.Auctions = append(response.Details.Auctions, entry)
 	}
+	sort.Slice(response.Details.Auctions, func(i, j int) bool {
+		return response.Details.Auctions[i].AuctionID < response.Details.Auctions[j].AuctionID
+	})
 
-	result, _ := json.MarshalIndent(response, "", "  ")
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
-	}, nil
+	response.Summary = fmt.Sprintf("Key '%s' (%s) created %d auctions and leads the bidding on %d",
+		keyName, address, response.Details.Created, response.Details.Leading)
+	return response
 }
 
-func openAuctionHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[OpenAuctionParams]) (*mcp.CallToolResultFor[any], error) {
-	log.Printf("INFO: Handling 'open-auction' tool request. Params: %+v", params.Arguments)
+func buildBidsByBidderResponse(bidder string, auctions []Auction, bids []Bid) BidsByBidderResponse {
+	statusByID := make(map[int]string)
+	for _, auction := range auctions {
+		statusByID[auction.ID] = auction.Status
+	}
 
-	// Validate required parameters
-	issue := strings.TrimSpace(params.Arguments.Issue)
-	description := strings.TrimSpace(params.Arguments.Description)
-	from := strings.TrimSpace(params.Arguments.From)
+	grouped := make(map[int]*BidderAuctionBids)
+	totals := make(map[string]*big.Int)
+	totalBids := 0
 
-	if issue == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'issue' parameter is required and cannot be empty."}},
-		}, nil
-	}
-	if description == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'description' parameter is required and cannot be empty."}},
-		}, nil
-	}
-	if from == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'from' parameter is required and cannot be empty."}},
-		}, nil
-	}
+	for _, bid := range bids {
+		if bid.Bidder != bidder {
+			continue
+		}
+		totalBids++
 
-	if !isValidCosmosAddress(from) {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'from' must be a valid cosmos address (cosmos1...)."}},
-		}, nil
-	}
+		group, ok := grouped[bid.AuctionID]
+		if !ok {
+			group = &BidderAuctionBids{
+				AuctionID: bid.AuctionID,
+				Status:    statusByID[bid.AuctionID],
+			}
+			grouped[bid.AuctionID] = group
+		}
+		group.Bids = append(group.Bids, BidDetail{
+			Bidder:      bid.Bidder,
+			Amount:      bid.Amount,
+			Description: bid.Description,
+		})
 
-	// Set defaults for optional parameters
-	status := strings.TrimSpace(params.Arguments.Status)
-	if status == "" {
-		status = "open"
+		value, denom, err := parse.CoinAmount(bid.Amount)
+		if err != nil {
+			continue
+		}
+		if totals[denom] == nil {
+			totals[denom] = new(big.Int)
+		}
+		totals[denom].Add(totals[denom], value)
 	}
 
-	winner := strings.TrimSpace(params.Arguments.Winner)
-
-	args := []string{
-		"tx", "issuemarket", "create-auction",
-		issue,
-		description,
-		status,
-		winner,
-		"--from", from,
-		"--keyring-backend", "test",
-		"--chain-id", "swechain",
-		"--fees", "200token",
-		"--yes",
-		"--output", "json",
+	groups := make([]BidderAuctionBids, 0, len(grouped))
+	for _, group := range grouped {
+		groups = append(groups, *group)
 	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].AuctionID < groups[j].AuctionID })
 
-	output, err := runCommand(swechaindCmd, args...)
-	if err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to create auction: %v\nOutput: %s", err, output)}},
-		}, nil
+	totalsByDenom := make(map[string]string)
+	for denom, total := range totals {
+		totalsByDenom[denom] = total.String()
 	}
 
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: output}},
-	}, nil
+	var response BidsByBidderResponse
+	response.Summary = fmt.Sprintf("Address %s has placed %d bids across %d auctions", bidder, totalBids, len(groups))
+	response.Details.Bidder = bidder
+	response.Details.TotalBids = totalBids
+	response.Details.TotalsByDenom = totalsByDenom
+	response.Details.Auctions = groups
+	return response
 }
 
-func createBidHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateBidParams]) (*mcp.CallToolResultFor[any], error) {
-	log.Printf("INFO: Handling 'create-bid' tool request. Params: %+v", params.Arguments)
-
-	// Validate required parameters
-	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
-	bidder := strings.TrimSpace(params.Arguments.Bidder)
-	from := strings.TrimSpace(params.Arguments.From)
+// validateAuctionStatus checks status against the -auction-statuses allow-list.
+func validateAuctionStatus(status string) error {
+	allowed := strings.Split(*auctionStatuses, ",")
+	for _, candidate := range allowed {
+		if parse.NormalizeStatus(candidate) == parse.NormalizeStatus(status) {
+			return nil
+		}
+	}
+	return fmt.Errorf("'status' must be one of %s (got %q)", strings.Join(allowed, ", "), status)
+}
 
-	if auctionId == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'auctionId' parameter is required."}},
-		}, nil
+// resolveFees returns the per-call fee override if given, or the configured default.
+func resolveFees(ctx context.Context, override string) (string, error) {
+	override = strings.TrimSpace(override)
+	if override == "" {
+		return configFrom(ctx).fees, nil
 	}
-	if bidder == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'bidder' parameter is required."}},
-		}, nil
+	if _, err := parse.Amount(override); err == nil {
+		// A bare number is in the same denom as the configured fees.
+		_, feeDenom, _ := parse.SplitCoin(configFrom(ctx).fees)
+		return override + feeDenom, nil
 	}
-	if from == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'from' parameter is required."}},
-		}, nil
+	if _, _, ok := parse.SplitCoin(override); !ok {
+		return "", &FieldError{Field: "fees", Code: codeInvalidCoin, Message: fmt.Sprintf("'fees' must be a coin amount such as 200token (got %q)", override)}
 	}
+	return override, nil
+}
 
-	// Validate addresses
-	if !isValidCosmosAddress(bidder) {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'bidder' must be a valid cosmos address (cosmos1...)."}},
-		}, nil
-	}
-	if !isValidCosmosAddress(from) {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'from' must be a valid cosmos address (cosmos1...)."}},
-		}, nil
-	}
+// resolveRelativeBid turns an increment such as "+50token" into an absolute bid
+// of the current high bid plus 50. A bare "+50" uses the high bid's denom. The
+// increment's denom must match the auction's bids; with no bids it counts up from zero.
+func resolveRelativeBid(ctx context.Context, increment string, bids []Bid) (string, error) {
+	raw := strings.TrimPrefix(strings.TrimSpace(increment), "+")
 
-	// Validate auction ID is numeric
-	if _, err := strconv.Atoi(auctionId); err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'auctionId' must be a valid number."}},
-		}, nil
+	current := new(big.Int)
+	currentDenom := ""
+	if leader, ok := parse.HighestBid(bids); ok {
+		current, currentDenom, _ = parse.CoinAmount(leader.Amount)
 	}
 
-	// Set defaults
-	amount := strings.TrimSpace(params.Arguments.Amount)
-	if amount == "" {
-		amount = "100token"
+	var delta *big.Int
+	var denom string
+	if value, err := parse.Amount(raw); err == nil {
+		delta, denom = value, currentDenom
+		if denom == "" {
+			denom = configFrom(ctx).denom
+		}
+	} else {
+		value, d, err := parse.CoinAmount(raw)
+		if err != nil {
+			return "", fmt.Errorf("relative amount %q must look like +50 or +50token", increment)
+		}
+		delta, denom = value, d
 	}
 
-	description := strings.TrimSpace(params.Arguments.Description)
-	if description == "" {
-		description = fmt.Sprintf("Bid for auction %s", auctionId)
+	if delta.Sign() == 0 {
+		return "", fmt.Errorf("relative amount %q must be greater than zero", increment)
+	}
+	if currentDenom != "" && denom != currentDenom {
+		return "", fmt.Errorf("relative amount denom %q does not match the auction's bid denom %q", denom, currentDenom)
 	}
 
-	args := []string{
-		"tx", "issuemarket", "create-bid",
-		auctionId,
-		bidder,
-		amount,
-		description,
-		"--from", from,
-		"--keyring-backend", "test",
-		"--chain-id", "swechain",
-		"--fees", "200token",
-		"--yes",
-		"--output", "json",
+	return new(big.Int).Add(current, delta).String() + denom, nil
+}
+
+// withDefaultDenom appends -default-denom to a purely numeric amount such as "100",
+// leaving amounts that already carry a denom unchanged.
+func withDefaultDenom(ctx context.Context, amount string) string {
+	amount = strings.TrimSpace(amount)
+	if _, err := parse.Amount(amount); err != nil {
+		return amount
 	}
+	logf(ctx, "INFO: Amount %q has no denom; using default denom %s", amount, configFrom(ctx).denom)
+	return amount + configFrom(ctx).denom
+}
 
-	output, err := runCommand(swechaindCmd, args...)
-	if err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to create bid: %v\nOutput: %s", err, output)}},
-		}, nil
+// participantName prefers the local key name and falls back to an address fragment.
+func participantName(address string, keyNames map[string]string) string {
+	if name, ok := keyNames[address]; ok {
+		return name
 	}
+	return extractNameFromAddress(address)
+}
 
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: output}},
-	}, nil
+func extractNameFromAddress(address string) string {
+	if len(address) >= 12 {
+		return address[7:12]
+	}
+	return address
 }
 
-func payHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[PayParams]) (*mcp.CallToolResultFor[any], error) {
-	log.Printf("INFO: Handling 'pay' tool request")
+// fetchPaginatedData pages through a list query and returns every dataKey
+// entry. A failed or unparseable page fails the whole fetch, and so does a list
+// longer than maxPages pages, so callers never mistake a partial list for the
+// full one.
+func fetchPaginatedData(ctx context.Context, module, query, dataKey string, positional ...string) ([]map[string]interface{}, error) {
+	var allResults []map[string]interface{}
+	offset := 0
 
-	from := strings.TrimSpace(params.Arguments.From)
-	to := strings.TrimSpace(params.Arguments.To)
-	amount := strings.TrimSpace(params.Arguments.Amount)
+	// The page after the last allowed one is only fetched to check the list ended.
+	for page := 0; page <= maxPages; page++ {
+		if page > 0 && !sleepContext(ctx, *pageDelay) {
+			return nil, fmt.Errorf("listing %s %s: %w", module, query, ctx.Err())
+		}
+		extra := append([]string{}, positional...)
+		extra = append(extra,
+			"--page-offset", strconv.Itoa(offset),
+			"--page-limit", strconv.Itoa(*pageLimit),
+		)
+		args := buildQueryArgs(ctx, module, query, extra...)
 
-	if from == "" || to == "" || amount == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'from', 'to', and 'amount' parameters are all required."}},
-		}, nil
-	}
+		output, err := runCommand(ctx, swechaindCmd, args...)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s %s at offset %d: %w", module, query, offset, err)
+		}
 
-	if !isValidCosmosAddress(from) {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'from' must be a valid cosmos address."}},
-		}, nil
-	}
-	if !isValidCosmosAddress(to) {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'to' must be a valid cosmos address."}},
-		}, nil
-	}
+		var responseData map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+			return nil, fmt.Errorf("listing %s %s at offset %d: %w", module, query, offset, parse.OutputError(query, err, output))
+		}
 
-	args := []string{
-		"tx", "bank", "send",
-		from, to, amount,
-		"--from", from,
-		"--keyring-backend", "test",
-		"--chain-id", "swechain",
-		"--fees", "200token",
-		"--yes",
-		"--output", "json",
-	}
+		results, ok := responseData[dataKey].([]interface{})
+		if !ok || len(results) == 0 {
+			break
+		}
+		if page == maxPages {
+			return nil, fmt.Errorf("listing %s %s: more than %d pages of %d entries; refusing to return a truncated list", module, query, maxPages, *pageLimit)
+		}
 
-	output, err := runCommand(swechaindCmd, args...)
-	if err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Payment failed: %v\nOutput: %s", err, output)}},
-		}, nil
+		for _, result := range results {
+			if resultMap, ok := result.(map[string]interface{}); ok {
+				allResults = append(allResults, resultMap)
+			}
+		}
+
+		if len(results) < *pageLimit {
+			// A short page is the last one; don't wait out -page-delay to confirm it.
+			break
+		}
+		offset += *pageLimit
 	}
 
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: output}},
-	}, nil
+	return allResults, nil
 }
 
-func closeAuctionHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[CloseAuctionParams]) (*mcp.CallToolResultFor[any], error) {
-	log.Printf("INFO: Handling 'close-auction' tool request")
-
-	auctionId := strings.TrimSpace(params.Arguments.AuctionId)
-	status := strings.TrimSpace(params.Arguments.Status)
-	from := strings.TrimSpace(params.Arguments.From)
-
-	// Validate required parameters
-	if auctionId == "" || status == "" || from == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'auctionId', 'status', and 'from' parameters are required."}},
-		}, nil
+// fetchAuctions pages through every auction on chain.
+func fetchAuctions(ctx context.Context) ([]Auction, error) {
+	rawAuctions, err := fetchPaginatedData(ctx, "issuemarket", "list-auction", "Auction")
+	if err != nil {
+		return nil, err
 	}
+	return parse.Auctions(rawAuctions), nil
+}
 
-	if !isValidCosmosAddress(from) {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'from' must be a valid cosmos address."}},
-		}, nil
+// fetchBids pages through every bid on chain.
+func fetchBids(ctx context.Context) ([]Bid, error) {
+	rawBids, err := fetchPaginatedData(ctx, "issuemarket", "list-bid", "Bid")
+	if err != nil {
+		return nil, err
 	}
+	return parse.Bids(rawBids), nil
+}
 
-	if _, err := strconv.Atoi(auctionId); err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'auctionId' must be a valid number."}},
-		}, nil
+// fetchAuctionsAndBids pages through every auction and every bid.
+func fetchAuctionsAndBids(ctx context.Context) ([]Auction, []Bid, error) {
+	auctions, err := fetchAuctions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	bids, err := fetchBids(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
+	return auctions, bids, nil
+}
 
-	args := []string{
-		"tx", "issuemarket", "update-auction",
-		auctionId,
-		strings.TrimSpace(params.Arguments.Issue),
-		strings.TrimSpace(params.Arguments.Description),
-		status,
-		strings.TrimSpace(params.Arguments.Winner),
-		"--from", from,
-		"--keyring-backend", "test",
-		"--chain-id", "swechain",
-		"--fees", "200token",
-		"--yes",
-		"--output", "json",
+// fetchErrorResult reports a failed chain query as a tool result, naming what
+// could not be fetched.
+func fetchErrorResult(what string, err error) *mcp.CallToolResultFor[any] {
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error fetching %s: %v", what, err)}},
 	}
+}
 
-	output, err := runCommand(swechaindCmd, args...)
+// fetchTxSummaries pages through `query txs` results for the given filter,
+// passed as flag ("--events" or "--query").
+func fetchTxSummaries(ctx context.Context, flag, filter string) ([]TxSummary, error) {
+	txResponses, err := fetchTxResponses(ctx, flag, filter)
 	if err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to close auction: %v\nOutput: %s", err, output)}},
-		}, nil
+		return nil, err
 	}
 
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: output}},
-	}, nil
+	txs := make([]TxSummary, 0, len(txResponses))
+	for _, txResponse := range txResponses {
+		txs = append(txs, summarizeTx(txResponse))
+	}
+	return txs, nil
 }
 
-/*
-func createAndFundAddressHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateAndFundAddressParams]) (*mcp.CallToolResultFor[any], error) {
-	log.Printf("INFO: Handling 'create-and-fund-address' tool request")
+// fetchTxResponses pages through `query txs` results and returns the raw tx
+// responses. Like fetchPaginatedData it fails rather than return a partial list.
+func fetchTxResponses(ctx context.Context, flag, filter string) ([]map[string]interface{}, error) {
+	var allTxs []map[string]interface{}
 
-	keyName := strings.TrimSpace(params.Arguments.KeyName)
-	funderAddress := strings.TrimSpace(params.Arguments.FunderAddress)
+	for page := 1; page <= maxPages; page++ {
+		args := buildQueryArgs(ctx, "txs", "",
+			flag, filter,
+			"--page", strconv.Itoa(page),
+			"--limit", strconv.Itoa(*pageLimit),
+		)
 
-	if keyName == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'keyName' parameter is required."}},
-		}, nil
+		output, err := runCommand(ctx, swechaindCmd, args...)
+		if err != nil {
+			return nil, fmt.Errorf("listing txs for %s page %d: %w", filter, page, err)
+		}
+
+		txs, pageTotal, err := parse.TxSearch(output)
+		if err != nil {
+			return nil, fmt.Errorf("listing txs for %s page %d: %w", filter, page, err)
+		}
+
+		allTxs = append(allTxs, txs...)
+		if len(txs) < *pageLimit || page >= pageTotal {
+			break
+		}
+		if page == maxPages {
+			return nil, fmt.Errorf("listing txs for %s: %d pages of %d txs, more than the %d allowed; refusing to return a truncated list", filter, pageTotal, *pageLimit, maxPages)
+		}
+		if !sleepContext(ctx, *pageDelay) {
+			return nil, fmt.Errorf("listing txs for %s: %w", filter, ctx.Err())
+		}
 	}
-	if funderAddress == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'funderAddress' parameter is required."}},
-		}, nil
+
+	return allTxs, nil
+}
+
+func summarizeTx(txResponse map[string]interface{}) TxSummary {
+	return TxSummary{
+		TxHash:      parse.String(txResponse, "txhash"),
+		Height:      parse.String(txResponse, "height"),
+		Timestamp:   parse.String(txResponse, "timestamp"),
+		MessageType: firstMessageType(txResponse),
 	}
+}
 
-	if !isValidCosmosAddress(funderAddress) {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: 'funderAddress' must be a valid cosmos address."}},
-		}, nil
+// findEventAttribute returns the value of the first attrKey attribute on an
+// eventType event in a tx result. It reads the top-level events of SDK 0.47+
+// results and the per-message logs of older ones, and accepts attributes in
+// both plain form and the base64-encoded form CometBFT used before 0.37.
+func findEventAttribute(txResult map[string]interface{}, eventType, attrKey string) (string, bool) {
+	for _, event := range txEvents(txResult) {
+		if event.Type != eventType {
+			continue
+		}
+		for _, attribute := range event.Attributes {
+			if attribute.Key == attrKey {
+				return attribute.Value, true
+			}
+		}
 	}
+	return "", false
+}
 
-	amount := strings.TrimSpace(params.Arguments.Amount)
-	if amount == "" {
-		amount = "1000token"
+// txEvents returns every event in a tx result, top-level events first and
+// then those in the per-message logs, with base64 attributes decoded.
+func txEvents(txResult map[string]interface{}) []TxEvent {
+	var rawEvents []interface{}
+	if topLevel, ok := txResult["events"].([]interface{}); ok {
+		rawEvents = append(rawEvents, topLevel...)
+	}
+	if logs, ok := txResult["logs"].([]interface{}); ok {
+		for _, rawLog := range logs {
+			if msgLog, ok := rawLog.(map[string]interface{}); ok {
+				logEvents, _ := msgLog["events"].([]interface{})
+				rawEvents = append(rawEvents, logEvents...)
+			}
+		}
 	}
 
-	// Create the key
-	createArgs := []string{
-		"keys", "add", keyName,
-		"--keyring-backend", "test",
-		"--output", "json",
+	var events []TxEvent
+	for _, rawEvent := range rawEvents {
+		event, ok := rawEvent.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		txEvent := TxEvent{Type: parse.String(event, "type"), Attributes: []TxEventAttribute{}}
+		attributes, _ := event["attributes"].([]interface{})
+		for _, rawAttribute := range attributes {
+			attribute, ok := rawAttribute.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			key, value := decodeEventAttribute(parse.String(attribute, "key"), parse.String(attribute, "value"))
+			txEvent.Attributes = append(txEvent.Attributes, TxEventAttribute{Key: key, Value: value})
+		}
+		events = append(events, txEvent)
 	}
+	return events
+}
 
-	output, err := runCommand(swechaindCmd, createArgs...)
+// decodeEventAttribute undoes the base64 encoding CometBFT used for event
+// attributes before 0.37. A key only counts as encoded if it decodes to
+// printable text, since plain keys such as "receiver" are valid base64 too.
+func decodeEventAttribute(key, value string) (string, string) {
+	decodedKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || !isPrintableASCII(decodedKey) {
+		return key, value
+	}
+	decodedValue, err := base64.StdEncoding.DecodeString(value)
 	if err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to create key: %v\nOutput: %s", err, output)}},
-		}, nil
+		return string(decodedKey), value
 	}
+	return string(decodedKey), string(decodedValue)
+}
 
-	var keyData map[string]interface{}
-	if err := json.Unmarshal([]byte(output), &keyData); err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to parse key creation output: %v", err)}},
-		}, nil
+func isPrintableASCII(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
 	}
+	return true
+}
 
-	newAddress, ok := keyData["address"].(string)
+// txMessages returns the messages in a tx response body.
+func txMessages(txResponse map[string]interface{}) []map[string]interface{} {
+	tx, ok := txResponse["tx"].(map[string]interface{})
 	if !ok {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Failed to extract address from key creation output"}},
-		}, nil
+		return nil
 	}
-
-	// Fund the new address
-	fundArgs := []string{
-		"tx", "bank", "send",
-		funderAddress, newAddress, amount,
-		"--from", funderAddress,
-		"--keyring-backend", "test",
-		"--chain-id", "swechain",
-		"--fees", "200token",
-		"--yes",
-		"--output", "json",
+	body, ok := tx["body"].(map[string]interface{})
+	if !ok {
+		return nil
 	}
-
-	fundOutput, err := runCommand(swechaindCmd, fundArgs...)
-	if err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Key created but funding failed: %v\nKey: %s\nAddress: %s", err, keyName, newAddress)}},
-		}, nil
+	rawMessages, ok := body["messages"].([]interface{})
+	if !ok {
+		return nil
 	}
 
-	result := fmt.Sprintf("Successfully created and funded address:\nKey: %s\nAddress: %s\nAmount: %s\nFunding result: %s",
-		keyName, newAddress, amount, fundOutput)
-
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: result}},
-	}, nil
+	var messages []map[string]interface{}
+	for _, raw := range rawMessages {
+		if message, ok := raw.(map[string]interface{}); ok {
+			messages = append(messages, message)
+		}
+	}
+	return messages
 }
-*/
-// Helper functions with enhanced error handling
 
-func getAddressForKey(keyName string) (string, error) {
-	output, err := runCommand(swechaindCmd, "keys", "show", keyName, "--keyring-backend", "test", "--output", "json")
-	if err != nil {
-		return "", fmt.Errorf("failed to get key info: %w", err)
+// signerFields are the message fields naming the signing address, in the order
+// they're checked; MsgGrant carries both granter and grantee but only the
+// granter signs, so granter comes first.
+var signerFields = []string{"creator", "from_address", "delegator_address", "sender", "granter", "grantee", "signer"}
+
+// buildDecodedTxResponse summarizes a decoded tx ({body, auth_info, signatures}).
+// Signers are taken from each message's signer field since signer_infos only
+// carry public keys.
+func buildDecodedTxResponse(tx map[string]interface{}) DecodedTxResponse {
+	var response DecodedTxResponse
+	response.Details.Messages = txMessages(map[string]interface{}{"tx": tx})
+	response.Details.MessageTypes = []string{}
+	response.Details.Signers = []string{}
+
+	if response.Details.Messages == nil {
+		response.Details.Messages = []map[string]interface{}{}
 	}
 
-	var keyData map[string]interface{}
-	if err := json.Unmarshal([]byte(output), &keyData); err != nil {
-		return "", fmt.Errorf("failed to parse key data: %w", err)
+	seen := make(map[string]bool)
+	for _, message := range response.Details.Messages {
+		response.Details.MessageTypes = append(response.Details.MessageTypes, parse.String(message, "@type"))
+		for _, field := range signerFields {
+			if signer := parse.String(message, field); signer != "" {
+				if !seen[signer] {
+					seen[signer] = true
+					response.Details.Signers = append(response.Details.Signers, signer)
+				}
+				break
+			}
+		}
 	}
 
-	address, ok := keyData["address"].(string)
-	if !ok {
-		return "", fmt.Errorf("address not found in key data")
+	if body, ok := tx["body"].(map[string]interface{}); ok {
+		response.Details.Memo = parse.String(body, "memo")
 	}
-
-	if !isValidCosmosAddress(address) {
-		return "", fmt.Errorf("invalid cosmos address format: %s", address)
+	if fee := parse.TxFee(tx); fee != nil {
+		var coins []string
+		for _, coin := range parse.FeeCoins(fee) {
+			coins = append(coins, coin.Amount+coin.Denom)
+		}
+		response.Details.Fee = strings.Join(coins, ",")
+		response.Details.GasLimit = parse.String(fee, "gas_limit")
+	}
+	if signatures, ok := tx["signatures"].([]interface{}); ok {
+		response.Details.Signatures = len(signatures)
 	}
 
-	return address, nil
+	response.Summary = fmt.Sprintf("Tx with %d messages (%s) signed by %d of %d signers",
+		len(response.Details.Messages), strings.Join(response.Details.MessageTypes, ", "),
+		response.Details.Signatures, len(response.Details.Signers))
+	return response
 }
 
-func getBalanceForAddress(address string) ([]Balance, error) {
-	output, err := runCommand(swechaindCmd, "query", "bank", "balances", address, "--keyring-backend", "test", "--output", "json")
-	if err != nil {
-		return nil, fmt.Errorf("failed to query balance: %w", err)
-	}
-
-	var responseData map[string]interface{}
-	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
-		return nil, fmt.Errorf("failed to parse balance data: %w", err)
+func firstMessageType(txResponse map[string]interface{}) string {
+	messages := txMessages(txResponse)
+	if len(messages) == 0 {
+		return ""
 	}
+	return parse.String(messages[0], "@type")
+}
 
-	rawBalances, ok := responseData["balances"].([]interface{})
-	if !ok {
-		return []Balance{}, nil // No balances found
+func fetchDenomOwners(ctx context.Context) ([]DenomOwner, error) {
+	rawDenomOwners, err := fetchPaginatedData(ctx, "bank", "denom-owners", "denom_owners", configFrom(ctx).denom)
+	if err != nil {
+		return nil, err
 	}
+	return parseDenomOwners(rawDenomOwners), nil
+}
 
-	var balances []Balance
-	for _, raw := range rawBalances {
-		rawMap, ok := raw.(map[string]interface{})
+func parseDenomOwners(rawDenomOwners []map[string]interface{}) []DenomOwner {
+	denomOwners := []DenomOwner{}
+	for _, rawMap := range rawDenomOwners {
+		address := parse.String(rawMap, "address")
+		balanceRaw, ok := rawMap["balance"].(map[string]interface{})
 		if !ok {
 			continue
 		}
 
-		amount := fmt.Sprintf("%v", rawMap["amount"])
-		denom := fmt.Sprintf("%v", rawMap["denom"])
+		amount := parse.String(balanceRaw, "amount")
+		denom := parse.String(balanceRaw, "denom")
 
-		balances = append(balances, Balance{
-			Amount: amount,
-			Denom:  denom,
+		denomOwners = append(denomOwners, DenomOwner{
+			Address: address,
+			Balance: struct {
+				Amount string `json:"amount"`
+				Denom  string `json:"denom"`
+			}{
+				Amount: amount,
+				Denom:  denom,
+			},
 		})
 	}
 
-	return balances, nil
+	return denomOwners
 }
 
-func getKeys() []Key {
-	output, err := runCommand(swechaindCmd, "keys", "list", "--keyring-backend", "test", "--output", "json")
-	if err != nil {
-		log.Printf("Error fetching keys: %v", err)
-		return []Key{}
+// parseUnbondingDelegations flattens `query staking unbonding-delegations`
+// into one entry per unbonding, adding bondDenom to the bare balances.
+func parseUnbondingDelegations(rawData []map[string]interface{}, bondDenom string) []UnbondingEntry {
+	var entries []UnbondingEntry
+	for _, raw := range rawData {
+		validator := parse.String(raw, "validator_address")
+		list, _ := raw["entries"].([]interface{})
+		for _, item := range list {
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			entries = append(entries, UnbondingEntry{
+				Validator:      validator,
+				Balance:        parse.String(entry, "balance") + bondDenom,
+				CreationHeight: parse.String(entry, "creation_height"),
+				CompletionTime: parse.String(entry, "completion_time"),
+			})
+		}
 	}
+	return entries
+}
 
-	var keys []Key
-	if err := json.Unmarshal([]byte(output), &keys); err != nil {
-		log.Printf("Error parsing keys: %v", err)
-		return []Key{}
+// getDelegatorRewards returns the total pending staking rewards of delegator
+// across all validators, as DecCoins.
+func getDelegatorRewards(ctx context.Context, delegator string) ([]Balance, error) {
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "distribution", "rewards", delegator)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rewards: %w", err)
 	}
 
-	return keys
+	var responseData map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
+		return nil, parse.OutputError("rewards data", err, output)
+	}
+	return parse.DecCoins(responseData["total"])
 }
 
-func buildAuctionSummaryResponse(auctions []Auction, bids []Bid, owners []DenomOwner, auctionType string) AuctionSummaryResponse {
-	var auctionDetails []AuctionDetail
+func inflationHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[InflationParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Querying inflation")
 
-	for _, auction := range auctions {
-		var auctionBids []BidDetail
-		var currentBidAmount string = "0token"
+	var response InflationResponse
+	inflation, provisions, err := fetchInflation(ctx)
+	if err != nil && !isModuleUnavailable(err) {
+		logf(ctx, "ERROR: Failed to query inflation: %v", errorForLog(err))
+		return fetchErrorResult("inflation", err), nil
+	}
+	if err != nil {
+		logf(ctx, "INFO: Inflation unavailable: %v", errorForLog(err))
+		response.Summary = "Inflation is not available on this chain"
+		response.Details.Reason = err.Error()
+	} else {
+		mintDenom := parse.String(queryOptionalModuleParams(ctx, "mint"), "mint_denom")
+		response = buildInflationResponse(inflation, provisions, mintDenom)
+	}
 
-		for _, bid := range bids {
-			if bid.AuctionID == auction.ID {
-				auctionBids = append(auctionBids, BidDetail{
-					Bidder:      bid.Bidder,
-					Amount:      bid.Amount,
-					Description: bid.Description,
-				})
-				currentBidAmount = bid.Amount
-			}
-		}
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
 
-		auctionDetails = append(auctionDetails, AuctionDetail{
-			AuctionID:        auction.ID,
-			Issue:            auction.Issue,
-			Creator:          auction.Creator,
-			Description:      auction.Description,
-			Status:           auction.Status,
-			Winner:           auction.Winner,
-			CurrentBidAmount: currentBidAmount,
-			Bids:             auctionBids,
-		})
+// fetchInflation queries the mint module's inflation rate and annual
+// provisions. Either query fails on a chain without the mint module.
+func fetchInflation(ctx context.Context) (*big.Rat, *big.Rat, error) {
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "mint", "inflation")...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query inflation: %w", err)
+	}
+	inflation, err := parse.MintDec(output, "inflation")
+	if err != nil {
+		return nil, nil, err
 	}
 
-	var participants []ParticipantDetail
-	for _, owner := range owners {
-		participants = append(participants, ParticipantDetail{
-			Name:    extractNameFromAddress(owner.Address),
-			Address: owner.Address,
-			Balance: fmt.Sprintf("%s %s", owner.Balance.Amount, owner.Balance.Denom),
-		})
+	output, err = runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "mint", "annual-provisions")...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query annual provisions: %w", err)
+	}
+	provisions, err := parse.MintDec(output, "annual_provisions")
+	if err != nil {
+		return nil, nil, err
 	}
+	return inflation, provisions, nil
+}
 
-	var summary string
-	if auctionType == "open" {
-		bidCount := 0
-		for _, detail := range auctionDetails {
-			if len(detail.Bids) > 0 {
-				bidCount++
-			}
-		}
+// buildInflationResponse reports inflation as the chain's fraction and as a
+// percentage in the summary. mintDenom may be empty if mint params failed.
+func buildInflationResponse(inflation, provisions *big.Rat, mintDenom string) InflationResponse {
+	var response InflationResponse
+	response.Details.Available = true
+	response.Details.Inflation = parse.FormatDec(inflation)
+	response.Details.AnnualProvisions = parse.FormatDec(provisions)
+	response.Details.MintDenom = mintDenom
+
+	percent := new(big.Rat).Mul(inflation, big.NewRat(100, 1))
+	response.Summary = fmt.Sprintf("Inflation is %s%% with annual provisions of %s%s", percent.FloatString(2), response.Details.AnnualProvisions, mintDenom)
+	return response
+}
 
-		if len(auctions) == 0 {
-			summary = "No open auctions found."
-		} else {
-			summary = fmt.Sprintf("There are %d open auctions (%d with bids, %d without bids).",
-				len(auctions), bidCount, len(auctions)-bidCount)
-		}
-	} else {
-		summary = fmt.Sprintf("There are %d total auctions with %d total bids.", len(auctions), len(bids))
-	}
+func stakingRatioHandler(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[StakingRatioParams]) (*mcp.CallToolResultFor[any], error) {
+	logf(ctx, "INFO: Computing staking ratio")
 
-	return AuctionSummaryResponse{
-		Summary: summary,
-		Details: struct {
-			Auctions     []AuctionDetail     `json:"auctions"`
-			Participants []ParticipantDetail `json:"participants"`
-		}{
-			Auctions:     auctionDetails,
-			Participants: participants,
-		},
+	bondDenom := parse.String(queryOptionalModuleParams(ctx, "staking"), "bond_denom")
+	if bondDenom == "" {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: "Error: could not determine the bond denom from staking params."}},
+		}, nil
 	}
-}
 
-func extractNameFromAddress(address string) string {
-	if len(address) >= 12 {
-		return address[7:12]
+	rawValidators, err := fetchPaginatedData(ctx, "staking", "validators", "validators")
+	if err != nil {
+		return fetchErrorResult("validators", err), nil
+	}
+	var validators []ValidatorInfo
+	for _, raw := range rawValidators {
+		validators = append(validators, parse.ValidatorFromMap(raw))
 	}
-	return address
-}
 
-func fetchPaginatedData(module, query, dataKey string) []map[string]interface{} {
-	var allResults []map[string]interface{}
-	offset := 0
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "bank", "total", "--denom", bondDenom)...)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error querying supply of %s: %v", bondDenom, err)}},
+		}, nil
+	}
+	totalSupply, err := parse.Supply(output, bondDenom)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error parsing supply of %s: %v", bondDenom, err)}},
+		}, nil
+	}
 
-	for offset/pageLimit < maxPages {
-		args := []string{
-			"query", module, query,
-			"--keyring-backend", "test",
-			"--output", "json",
-			"--page-offset", strconv.Itoa(offset),
-			"--page-limit", strconv.Itoa(pageLimit),
-		}
+	response, err := buildStakingRatioResponse(bondDenom, validators, totalSupply)
+	if err != nil {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error computing staking ratio: %v", err)}},
+		}, nil
+	}
 
-		output, err := runCommand(swechaindCmd, args...)
-		if err != nil {
-			log.Printf("Error fetching %s/%s offset %d: %v", module, query, offset, err)
-			break
-		}
+	result := marshalResponse(response, params.Arguments.Fields)
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(result)}},
+	}, nil
+}
 
-		var responseData map[string]interface{}
-		if err := json.Unmarshal([]byte(output), &responseData); err != nil {
-			log.Printf("Error parsing %s/%s offset %d: %v", module, query, offset, err)
-			break
+// buildStakingRatioResponse sums the tokens of bonded validators and divides
+// by totalSupply. A zero supply is an error rather than a 0% or NaN ratio,
+// and the ratio is capped at 100% in case the two queries saw different heights.
+func buildStakingRatioResponse(bondDenom string, validators []ValidatorInfo, totalSupply string) (StakingRatioResponse, error) {
+	bonded := new(big.Int)
+	bondedValidators := 0
+	for _, validator := range validators {
+		if !isBondedStatus(validator.Status) {
+			continue
 		}
-
-		results, ok := responseData[dataKey].([]interface{})
-		if !ok || len(results) == 0 {
-			break
+		tokens, ok := new(big.Int).SetString(validator.Tokens, 10)
+		if !ok {
+			return StakingRatioResponse{}, fmt.Errorf("invalid tokens %q for validator %s", validator.Tokens, validator.OperatorAddress)
 		}
+		bonded.Add(bonded, tokens)
+		bondedValidators++
+	}
 
-		for _, result := range results {
-			if resultMap, ok := result.(map[string]interface{}); ok {
-				allResults = append(allResults, resultMap)
-			}
-		}
+	total, ok := new(big.Int).SetString(totalSupply, 10)
+	if !ok {
+		return StakingRatioResponse{}, fmt.Errorf("invalid total supply %q", totalSupply)
+	}
+	if total.Sign() == 0 {
+		return StakingRatioResponse{}, fmt.Errorf("total supply of %s is zero", bondDenom)
+	}
 
-		offset += pageLimit
-		time.Sleep(requestDelay)
+	ratio := new(big.Rat).SetFrac(new(big.Int).Mul(bonded, big.NewInt(100)), total)
+	if ratio.Cmp(big.NewRat(100, 1)) > 0 {
+		ratio.SetInt64(100)
 	}
 
-	return allResults
+	var response StakingRatioResponse
+	response.Details.BondDenom = bondDenom
+	response.Details.BondedTokens = bonded.String()
+	response.Details.TotalSupply = total.String()
+	response.Details.BondedRatio = ratio.FloatString(2)
+	response.Details.BondedValidators = bondedValidators
+	response.Summary = fmt.Sprintf("%s%% of %s is bonded (%s of %s across %d validators)",
+		response.Details.BondedRatio, bondDenom, response.Details.BondedTokens, response.Details.TotalSupply, bondedValidators)
+	return response, nil
 }
 
-func fetchDenomOwners() []DenomOwner {
-	output, err := runCommand(swechaindCmd, "query", "bank", "denom-owners", "token", "--keyring-backend", "test", "--output", "json")
-	if err != nil {
-		log.Printf("Error fetching denom owners: %v", err)
-		return []DenomOwner{}
-	}
+// isBondedStatus reports whether a validator status is bonded. Amino JSON
+// renders the status enum by name and some older outputs by number.
+func isBondedStatus(status string) bool {
+	return status == "BOND_STATUS_BONDED" || status == "3"
+}
 
+// parseDelegationAmount reads the balance amount from `query staking delegation`.
+func parseDelegationAmount(output string) (string, error) {
 	var responseData map[string]interface{}
 	if err := json.Unmarshal([]byte(output), &responseData); err != nil {
-		log.Printf("Error parsing denom owners: %v", err)
-		return []DenomOwner{}
+		return "", parse.OutputError("delegation data", err, output)
 	}
-
-	rawDenomOwners, ok := responseData["denom_owners"].([]interface{})
-	if !ok {
-		log.Printf("Unexpected format for denom owners")
-		return []DenomOwner{}
+	if nested, ok := responseData["delegation_response"].(map[string]interface{}); ok {
+		responseData = nested
 	}
+	balance, _ := responseData["balance"].(map[string]interface{})
+	return parse.String(balance, "amount") + parse.String(balance, "denom"), nil
+}
 
-	var denomOwners []DenomOwner
-	for _, raw := range rawDenomOwners {
-		rawMap, ok := raw.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		address := fmt.Sprintf("%v", rawMap["address"])
-		balanceRaw, ok := rawMap["balance"].(map[string]interface{})
-		if !ok {
-			continue
-		}
+// isNotFoundError reports whether a command failure means the queried object doesn't exist.
+func isNotFoundError(err error) bool {
+	if isCommandNotExecutable(err) {
+		// "executable file not found" is about the binary, not the queried object.
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "not found") || strings.Contains(msg, "does not exist")
+}
 
-		amount := fmt.Sprintf("%v", balanceRaw["amount"])
-		denom := fmt.Sprintf("%v", balanceRaw["denom"])
+// isModuleUnavailable reports whether a query failed because the chain lacks
+// the module: the CLI has no such command or the node doesn't serve it.
+// Timeouts and RPC outages are not unavailability.
+func isModuleUnavailable(err error) bool {
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.Kind != CommandFailed {
+		return false
+	}
+	msg := strings.ToLower(cmdErr.Stderr + " " + cmdErr.Stdout)
+	return strings.Contains(msg, "unknown command") || strings.Contains(msg, "unimplemented") || strings.Contains(msg, "unknown service")
+}
 
-		denomOwners = append(denomOwners, DenomOwner{
-			Address: address,
-			Balance: struct {
-				Amount string `json:"amount"`
-				Denom  string `json:"denom"`
-			}{
-				Amount: amount,
-				Denom:  denom,
-			},
-		})
+// fetchSendEnabled returns the bank module's default_send_enabled param and the
+// per-denom overrides from `query bank send-enabled`.
+func fetchSendEnabled(ctx context.Context) (bool, []SendEnabledEntry, error) {
+	output, err := runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "bank", "params")...)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to query bank params: %w", err)
+	}
+	bankParams, err := parse.ModuleParams(output)
+	if err != nil {
+		return false, nil, err
+	}
+	defaultEnabled := true
+	if value, ok := bankParams["default_send_enabled"]; ok {
+		defaultEnabled = parse.Bool(value)
 	}
 
-	return denomOwners
+	output, err = runCommand(ctx, swechaindCmd, buildQueryArgs(ctx, "bank", "send-enabled")...)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to query send-enabled: %w", err)
+	}
+	denoms, err := parse.SendEnabled(output)
+	if err != nil {
+		return false, nil, err
+	}
+	return defaultEnabled, denoms, nil
 }
 
-func parseAuctions(rawData []map[string]interface{}) []Auction {
-	var auctions []Auction
-	for _, raw := range rawData {
-		idStr := fmt.Sprintf("%v", raw["id"])
-		id, _ := strconv.Atoi(idStr)
-
-		auction := Auction{
-			ID:          id,
-			Issue:       fmt.Sprintf("%v", raw["issue"]),
-			Description: fmt.Sprintf("%v", raw["description"]),
-			Status:      fmt.Sprintf("%v", raw["status"]),
-			Winner:      fmt.Sprintf("%v", raw["winner"]),
-			Creator:     fmt.Sprintf("%v", raw["creator"]),
+// isSendEnabled reports whether denom can be transferred, falling back to the
+// chain default for denoms without an explicit flag.
+func isSendEnabled(ctx context.Context, denom string) (bool, error) {
+	defaultEnabled, denoms, err := fetchSendEnabled(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range denoms {
+		if entry.Denom == denom {
+			return entry.Enabled, nil
 		}
-		auctions = append(auctions, auction)
 	}
-	return auctions
+	return defaultEnabled, nil
 }
 
-func parseBids(rawData []map[string]interface{}) []Bid {
-	var bids []Bid
-	for _, raw := range rawData {
-		auctionIDStr := fmt.Sprintf("%v", raw["auctionId"])
-		auctionID, _ := strconv.Atoi(auctionIDStr)
+func summarizeModuleParams(module string, moduleParams map[string]interface{}) string {
+	if len(moduleParams) == 0 {
+		return fmt.Sprintf("The %s module has no parameters", module)
+	}
 
-		bid := Bid{
-			AuctionID:   auctionID,
-			Amount:      fmt.Sprintf("%v", raw["amount"]),
-			Description: fmt.Sprintf("%v", raw["description"]),
-			Creator:     fmt.Sprintf("%v", raw["creator"]),
-			Bidder:      fmt.Sprintf("%v", raw["bidder"]),
+	keys := make([]string, 0, len(moduleParams))
+	for key := range moduleParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := moduleParams[key]
+		if _, ok := value.(string); !ok {
+			encoded, _ := json.Marshal(value)
+			value = string(encoded)
 		}
-		bids = append(bids, bid)
+		parts = append(parts, fmt.Sprintf("%s=%v", key, value))
 	}
-	return bids
+	return fmt.Sprintf("%s params: %s", module, strings.Join(parts, ", "))
 }
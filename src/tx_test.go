@@ -0,0 +1,606 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonschema"
+
+	"swechain-mcp-server/src/internal/parse"
+)
+
+// argValue returns the value following flag in args, or "" when absent.
+func argValue(args []string, flag string) string {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == flag {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+func TestSetupTestAccountsTracksCorrectedSequence(t *testing.T) {
+	captureLog(t)
+	runner := newFakeRunner().
+		on("query auth account", `{"account":{"sequence":"5"}}`).
+		on("keys add", `{"address":"`+testBob+`"}`).
+		onCall("tx bank send", func(call int, args []string) (string, error) {
+			switch call {
+			case 1:
+				return fmt.Sprintf(`{"code":32,"txhash":"T1","raw_log":"account sequence mismatch, expected 7, got %s: incorrect account sequence"}`, argValue(args, "--sequence")), nil
+			case 3:
+				return `{"code":5,"txhash":"T3","raw_log":"insufficient funds"}`, nil
+			}
+			return fmt.Sprintf(`{"code":0,"txhash":"T%d"}`, call), nil
+		})
+
+	text, _ := callTool(t, runner, setupTestAccountsHandler, SetupTestAccountsParams{FunderAddress: testAlice, Count: 3, Amount: "10token"})
+
+	var sequences []string
+	for _, call := range runner.callsTo("tx bank send") {
+		sequences = append(sequences, argValue(call, "--sequence"))
+	}
+	// 5 is rejected and rerun at 7; the next send uses 8, and since that one
+	// fails with a non-zero code the last send reuses 8.
+	if want := []string{"5", "7", "8", "8"}; fmt.Sprint(sequences) != fmt.Sprint(want) {
+		t.Errorf("sequences = %v, want %v", sequences, want)
+	}
+
+	var response SetupTestAccountsResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	accounts := response.Details.Accounts
+	if len(accounts) != 3 || accounts[0].Error != "" || accounts[1].Error == "" || accounts[2].Error != "" {
+		t.Errorf("accounts = %+v, want only the second to fail", accounts)
+	}
+}
+
+func TestFormatTxResultMarksFailedTxAsError(t *testing.T) {
+	if result := formatTxResult("Redelegation", `{"code":0,"txhash":"A"}`, false); result.IsError {
+		t.Error("successful tx marked as an error")
+	}
+	if result := formatTxResult("Redelegation", `{"code":5,"txhash":"B","raw_log":"insufficient funds"}`, false); !result.IsError {
+		t.Error("tx rejected with code 5 not marked as an error")
+	}
+}
+
+func TestPayVerboseIncludesEvents(t *testing.T) {
+	runner := newFakeRunner().on("tx bank send", `{"code":0,"txhash":"A","events":[{"type":"transfer","attributes":[{"key":"amount","value":"10token"}]}]}`)
+
+	for _, verbose := range []bool{false, true} {
+		text, _ := callTool(t, runner, payHandler, PayParams{From: testAlice, To: testBob, Amount: "10token", Verbose: verbose})
+		var response TxResultResponse
+		if err := json.Unmarshal([]byte(text), &response); err != nil {
+			t.Fatalf("response is not JSON: %v\n%s", err, text)
+		}
+		if got := len(response.Details.Events) > 0; got != verbose {
+			t.Errorf("verbose=%v: events included = %v", verbose, got)
+		}
+	}
+}
+
+// withStaleBlockThreshold sets -stale-block-threshold for the rest of the test.
+func withStaleBlockThreshold(t *testing.T, threshold time.Duration) {
+	t.Helper()
+	previous := *staleBlockThreshold
+	*staleBlockThreshold = threshold
+	t.Cleanup(func() { *staleBlockThreshold = previous })
+}
+
+func TestTxValidatesBeforeLivenessCheck(t *testing.T) {
+	withStaleBlockThreshold(t, time.Minute)
+	runner := newFakeRunner()
+
+	_, isError := callTool(t, runner, payHandler, PayParams{From: testAlice, To: "not-an-address", Amount: "1token"})
+	if !isError {
+		t.Error("invalid params not reported as a validation error")
+	}
+	if calls := len(runner.callsTo("status")); calls != 0 {
+		t.Errorf("checked liveness %d times before validating params, want 0", calls)
+	}
+}
+
+func TestTxRefusedWhenLivenessUnknown(t *testing.T) {
+	withStaleBlockThreshold(t, time.Minute)
+	runner := newFakeRunner().
+		fail("status", "Error: rpc error: code = InvalidArgument desc = bad request").
+		on("tx bank send", `{"code":0,"txhash":"A"}`)
+
+	text, _ := callTool(t, runner, payHandler, PayParams{From: testAlice, To: testBob, Amount: "1token"})
+	if !strings.Contains(text, "confirm the chain is live") {
+		t.Errorf("result = %s, want a liveness error", text)
+	}
+	if calls := len(runner.callsTo("tx bank send")); calls != 0 {
+		t.Errorf("broadcast %d times without confirming liveness, want 0", calls)
+	}
+}
+
+func TestTxRefusedWhenChainStalled(t *testing.T) {
+	withStaleBlockThreshold(t, time.Minute)
+	stale := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339Nano)
+	runner := newFakeRunner().
+		on("status", `{"sync_info":{"latest_block_height":"42","latest_block_time":"`+stale+`"}}`).
+		on("tx bank send", `{"code":0,"txhash":"A"}`)
+
+	text, _ := callTool(t, runner, payHandler, PayParams{From: testAlice, To: testBob, Amount: "1token"})
+	if !strings.Contains(text, "stalled") {
+		t.Errorf("result = %s, want a stalled-chain error", text)
+	}
+	if calls := len(runner.callsTo("tx bank send")); calls != 0 {
+		t.Errorf("broadcast %d times on a stalled chain, want 0", calls)
+	}
+}
+
+func TestFeeGranterIsPassedToTx(t *testing.T) {
+	runner := newFakeRunner().on("tx bank send", `{"code":0,"txhash":"A"}`)
+
+	callTool(t, runner, payHandler, PayParams{From: testAlice, To: testBob, Amount: "1token", FeeGranter: " " + testBob + " "})
+	calls := runner.callsTo("tx bank send")
+	if len(calls) != 1 || argValue(calls[0], "--fee-granter") != testBob {
+		t.Errorf("calls = %v, want one send with --fee-granter %s", calls, testBob)
+	}
+
+	runner = newFakeRunner().on("tx bank send", `{"code":0,"txhash":"A"}`)
+	callTool(t, runner, payHandler, PayParams{From: testAlice, To: testBob, Amount: "1token"})
+	if calls := runner.callsTo("tx bank send"); len(calls) != 1 || argValue(calls[0], "--fee-granter") != "" {
+		t.Errorf("calls = %v, want no --fee-granter without a feeGranter", calls)
+	}
+}
+
+func TestInvalidFeeGranterIsRejected(t *testing.T) {
+	for name, h := range map[string]func(string) (string, bool){
+		"pay": func(granter string) (string, bool) {
+			return callTool(t, newFakeRunner(), payHandler, PayParams{From: testAlice, To: testBob, Amount: "1token", FeeGranter: granter})
+		},
+		"redelegate": func(granter string) (string, bool) {
+			return callTool(t, newFakeRunner(), redelegateHandler, RedelegateParams{Delegator: testAlice, SrcValidator: testValidator, DstValidator: testOtherValidator, Amount: "1stake", FeeGranter: granter})
+		},
+	} {
+		text, isError := h("cosmos1nope")
+		if !isError || !strings.Contains(text, `"feeGranter"`) {
+			t.Errorf("%s: result = %s, want a feeGranter validation error", name, text)
+		}
+	}
+}
+
+// sendTx is a `query tx` result for a MsgSend from from with the given code.
+func sendTx(from string, code int) string {
+	return fmt.Sprintf(`{"code":%d,"txhash":"ORIG","tx":{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":%q,"to_address":%q}]}}}`, code, from, testBob)
+}
+
+func TestBumpFeeResubmitRefusesUnlessOriginalFailed(t *testing.T) {
+	arguments := map[string]interface{}{"from": testAlice, "to": testBob, "amount": "1token", "fees": "200token"}
+	notFound := func(r *fakeRunner) *fakeRunner {
+		return r.fail("query tx", "Error: rpc error: code = NotFound desc = tx not found: ORIG")
+	}
+	for _, tc := range []struct {
+		name      string
+		lookup    func(*fakeRunner) *fakeRunner
+		rejected  bool
+		resubmits bool
+	}{
+		{"failed", func(r *fakeRunner) *fakeRunner { return r.on("query tx", sendTx(testAlice, 13)) }, false, true},
+		{"succeeded", func(r *fakeRunner) *fakeRunner { return r.on("query tx", sendTx(testAlice, 0)) }, false, false},
+		{"pending", notFound, false, false},
+		{"rejected at broadcast", notFound, true, true},
+		{"other signer", func(r *fakeRunner) *fakeRunner { return r.on("query tx", sendTx(testBob, 13)) }, false, false},
+		{"other message", func(r *fakeRunner) *fakeRunner {
+			return r.on("query tx", `{"code":13,"txhash":"ORIG","tx":{"body":{"messages":[{"@type":"/swechain.issuemarket.MsgCreateBid","creator":"`+testAlice+`"}]}}}`)
+		}, false, false},
+	} {
+		runner := tc.lookup(newFakeRunner().on("tx bank send", `{"code":0,"txhash":"NEW"}`))
+		text, _ := callTool(t, runner, bumpFeeResubmitHandler, BumpFeeResubmitParams{Tool: "pay", Arguments: arguments, FeeMultiplier: 1.5, TxHash: "ORIG", RejectedAtBroadcast: tc.rejected})
+
+		calls := runner.callsTo("tx bank send")
+		if resubmitted := len(calls) > 0; resubmitted != tc.resubmits {
+			t.Errorf("%s: resubmitted = %v, want %v: %s", tc.name, resubmitted, tc.resubmits, text)
+		}
+		if tc.resubmits && argValue(calls[0], "--fees") != "300token" {
+			t.Errorf("%s: fees = %s, want 300token", tc.name, argValue(calls[0], "--fees"))
+		}
+	}
+}
+
+func TestBumpFee(t *testing.T) {
+	for _, tc := range []struct {
+		fees       string
+		multiplier float64
+		want       string
+	}{
+		{"200token", 1.5, "300token"},
+		{"200token", 1.1, "220token"},
+		{"10token", 1.1, "11token"},
+		{"7token", 1.5, "11token"},
+		{"1token", 1.01, "2token"},
+		{"123456789012345678901234567890uswe", 2, "246913578024691357802469135780uswe"},
+		{"100000000000000000000token", 1.1, "110000000000000000000token"},
+	} {
+		got, err := bumpFee(tc.fees, tc.multiplier)
+		if err != nil || got != tc.want {
+			t.Errorf("bumpFee(%q, %g) = %q, %v; want %q", tc.fees, tc.multiplier, got, err, tc.want)
+		}
+	}
+
+	var fieldErr *FieldError
+	if _, err := bumpFee("200", 1.5); !errors.As(err, &fieldErr) || fieldErr.Code != codeInvalidCoin {
+		t.Errorf("bumpFee(\"200\") error = %v, want invalid_coin", err)
+	}
+}
+
+func TestBumpFeeResubmitBareNumberFeeUsesFeeDenom(t *testing.T) {
+	_, feeDenom, _ := parse.SplitCoin(loadConfig().fees)
+	arguments := map[string]interface{}{"from": testAlice, "to": testBob, "amount": "1token", "fees": "200"}
+	runner := newFakeRunner().on("query tx", sendTx(testAlice, 13)).on("tx bank send", `{"code":0,"txhash":"NEW"}`)
+
+	text, _ := callTool(t, runner, bumpFeeResubmitHandler, BumpFeeResubmitParams{Tool: "pay", Arguments: arguments, FeeMultiplier: 1.1, TxHash: "ORIG"})
+	calls := runner.callsTo("tx bank send")
+	if len(calls) != 1 || argValue(calls[0], "--fees") != "220"+feeDenom {
+		t.Errorf("calls = %v, want one send with fees 220%s: %s", calls, feeDenom, text)
+	}
+}
+
+func TestBumpFeeResubmitRequiresTxHash(t *testing.T) {
+	arguments := map[string]interface{}{"from": testAlice, "to": testBob, "amount": "1token"}
+	text, isError := callTool(t, newFakeRunner(), bumpFeeResubmitHandler, BumpFeeResubmitParams{Tool: "pay", Arguments: arguments, FeeMultiplier: 2})
+	if !isError || !strings.Contains(text, `"txHash"`) {
+		t.Errorf("result = %s, want a missing txHash error", text)
+	}
+}
+
+func TestNumericAmountsUseDefaultDenom(t *testing.T) {
+	setDefaultDenom(t, "stake")
+	for _, tc := range []struct{ amount, want string }{
+		{"100", "100stake"},
+		{" 100 ", "100stake"},
+		{"100token", "100token"},
+	} {
+		runner := newFakeRunner().
+			on("tx bank send", `{"code":0,"txhash":"A"}`).
+			on("tx issuemarket create-bid", `{"code":0,"txhash":"B"}`)
+
+		callTool(t, runner, payHandler, PayParams{From: testAlice, To: testBob, Amount: tc.amount})
+		if calls := runner.callsTo("tx bank send"); len(calls) != 1 || calls[0][5] != tc.want {
+			t.Errorf("pay %q: calls = %v, want amount %s", tc.amount, calls, tc.want)
+		}
+
+		callTool(t, runner, createBidHandler, CreateBidParams{AuctionId: "1", Bidder: testAlice, From: testAlice, Amount: tc.amount})
+		if calls := runner.callsTo("tx issuemarket create-bid"); len(calls) != 1 || calls[0][5] != tc.want {
+			t.Errorf("create-bid %q: calls = %v, want amount %s", tc.amount, calls, tc.want)
+		}
+	}
+}
+
+func TestDefaultAmountsUseConfiguredDenom(t *testing.T) {
+	setDefaultDenom(t, "uswe")
+	runner := newFakeRunner().on("tx issuemarket create-bid", `{"code":0,"txhash":"A"}`)
+
+	callTool(t, runner, createBidHandler, CreateBidParams{AuctionId: "1", Bidder: testAlice, From: testAlice})
+	calls := runner.callsTo("tx issuemarket create-bid")
+	if len(calls) != 1 {
+		t.Fatalf("calls = %v, want one bid", calls)
+	}
+	if calls[0][5] != defaultBidAmount+"uswe" {
+		t.Errorf("default bid amount = %s, want %suswe", calls[0][5], defaultBidAmount)
+	}
+	if fees := argValue(calls[0], "--fees"); fees != defaultFeeAmount+"uswe" {
+		t.Errorf("default fees = %s, want %suswe", fees, defaultFeeAmount)
+	}
+}
+
+func TestFindEventAttributeReadsBothEncodings(t *testing.T) {
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	for name, raw := range map[string]string{
+		"plain": `{"events":[
+			{"type":"message","attributes":[{"key":"action","value":"/issuemarket.MsgCreateAuction"},{"key":"sender","value":"` + testAlice + `"}]},
+			{"type":"transfer","attributes":[{"key":"receiver","value":"` + testBob + `"}]}
+		]}`,
+		"base64": `{"events":[
+			{"type":"message","attributes":[{"key":"` + b64("action") + `","value":"` + b64("/issuemarket.MsgCreateAuction") + `"},{"key":"` + b64("sender") + `","value":"` + b64(testAlice) + `"}]},
+			{"type":"transfer","attributes":[{"key":"` + b64("receiver") + `","value":"` + b64(testBob) + `"}]}
+		]}`,
+		"legacy logs": `{"logs":[{"msg_index":0,"events":[
+			{"type":"message","attributes":[{"key":"action","value":"/issuemarket.MsgCreateAuction"},{"key":"sender","value":"` + testAlice + `"}]},
+			{"type":"transfer","attributes":[{"key":"receiver","value":"` + testBob + `"}]}
+		]}]}`,
+	} {
+		var txResult map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &txResult); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, want := range []struct{ eventType, key, value string }{
+			{"message", "action", "/issuemarket.MsgCreateAuction"},
+			{"message", "sender", testAlice},
+			{"transfer", "receiver", testBob},
+		} {
+			if value, ok := findEventAttribute(txResult, want.eventType, want.key); !ok || value != want.value {
+				t.Errorf("%s: %s.%s = %q, %v, want %q", name, want.eventType, want.key, value, ok, want.value)
+			}
+		}
+		if value, ok := findEventAttribute(txResult, "message", "receiver"); ok {
+			t.Errorf("%s: found receiver %q on the wrong event type", name, value)
+		}
+	}
+}
+
+func TestDecodeEventAttributeKeepsPlainBase64LookingKeys(t *testing.T) {
+	// "receiver" and "spender" are valid base64 but decode to binary.
+	for _, key := range []string{"receiver", "spender", "amount"} {
+		if got, value := decodeEventAttribute(key, "100token"); got != key || value != "100token" {
+			t.Errorf("decodeEventAttribute(%q) = %q, %q, want it unchanged", key, got, value)
+		}
+	}
+}
+
+func TestCancelAuctionRequiresCreator(t *testing.T) {
+	for _, tc := range []struct {
+		name, creator, status, field, code string
+	}{
+		{"not the creator", testBob, "open", "from", codeUnauthorized},
+		{"already closed", testAlice, "closed", "auctionId", codeInvalidValue},
+	} {
+		runner := newFakeRunner().
+			on("query issuemarket show-auction", `{"Auction":{"id":"5","issue":"fix-it","creator":"`+tc.creator+`","status":"`+tc.status+`"}}`).
+			on("tx issuemarket update-auction", `{"code":0,"txhash":"A"}`)
+
+		text, isError := callTool(t, runner, cancelAuctionHandler, CancelAuctionParams{AuctionId: "5", From: testAlice})
+		if !isError || !hasFieldError(fieldErrors(t, text), tc.field, tc.code) {
+			t.Errorf("%s: result = %s, want %s on %s", tc.name, text, tc.code, tc.field)
+		}
+		if calls := runner.callsTo("tx issuemarket update-auction"); len(calls) != 0 {
+			t.Errorf("%s: broadcast %v", tc.name, calls)
+		}
+	}
+}
+
+func TestCancelAuctionKeepsOtherFields(t *testing.T) {
+	runner := newFakeRunner().
+		on("query issuemarket show-auction", `{"Auction":{"id":"5","issue":"fix-it","description":"the bug","creator":"`+testAlice+`","status":"open","winner":""}}`).
+		on("tx issuemarket update-auction", `{"code":0,"txhash":"A"}`)
+
+	text, isError := callTool(t, runner, cancelAuctionHandler, CancelAuctionParams{AuctionId: "5", From: testAlice, Fees: "300token", FeeGranter: testBob})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	calls := runner.callsTo("tx issuemarket update-auction")
+	if len(calls) != 1 {
+		t.Fatalf("calls = %v, want one update-auction", calls)
+	}
+	args := calls[0]
+	if got := args[3:8]; !slices.Equal(got, []string{"5", "fix-it", "the bug", "cancelled", ""}) {
+		t.Errorf("positional args = %q, want id, issue, description, cancelled, winner", got)
+	}
+	if argValue(args, "--from") != testAlice || argValue(args, "--fees") != "300token" || argValue(args, "--fee-granter") != testBob {
+		t.Errorf("args = %v, want --from, --fees and --fee-granter set", args)
+	}
+}
+
+func TestPayRefusesSelfSendUnlessAllowed(t *testing.T) {
+	runner := newFakeRunner().on("tx bank send", `{"code":0,"txhash":"A"}`)
+
+	text, isError := callTool(t, runner, payHandler, PayParams{From: testAlice, To: " " + testAlice + " ", Amount: "1token"})
+	if !isError || !hasFieldError(fieldErrors(t, text), "to", codeInvalidValue) {
+		t.Errorf("result = %s, want invalid_value on to", text)
+	}
+	if calls := runner.callsTo("tx bank send"); len(calls) != 0 {
+		t.Fatalf("broadcast %v for a self-send", calls)
+	}
+
+	text, isError = callTool(t, runner, payHandler, PayParams{From: testAlice, To: testAlice, Amount: "1token", AllowSelf: true})
+	if isError {
+		t.Fatalf("unexpected error result with allowSelf: %s", text)
+	}
+	if calls := runner.callsTo("tx bank send"); len(calls) != 1 || !slices.Equal(calls[0][3:6], []string{testAlice, testAlice, "1token"}) {
+		t.Errorf("calls = %v, want one send from alice to alice", calls)
+	}
+}
+
+func TestCancelUnbondingValidatesParams(t *testing.T) {
+	valid := CancelUnbondingParams{Delegator: testAlice, Validator: testValidator, Amount: "100stake", CreationHeight: "1200"}
+	for _, tc := range []struct {
+		name        string
+		edit        func(*CancelUnbondingParams)
+		field, code string
+	}{
+		{"missing delegator", func(p *CancelUnbondingParams) { p.Delegator = "" }, "delegator", codeMissingField},
+		{"missing creation height", func(p *CancelUnbondingParams) { p.CreationHeight = " " }, "creationHeight", codeMissingField},
+		{"malformed delegator", func(p *CancelUnbondingParams) { p.Delegator = "cosmos1nope" }, "delegator", codeInvalidAddress},
+		{"account address as validator", func(p *CancelUnbondingParams) { p.Validator = testBob }, "validator", codeInvalidAddress},
+		{"amount without denom", func(p *CancelUnbondingParams) { p.Amount = "100" }, "amount", codeInvalidCoin},
+		{"zero amount", func(p *CancelUnbondingParams) { p.Amount = "0stake" }, "amount", codeInvalidCoin},
+		{"non-numeric height", func(p *CancelUnbondingParams) { p.CreationHeight = "latest" }, "creationHeight", codeOutOfRange},
+		{"zero height", func(p *CancelUnbondingParams) { p.CreationHeight = "0" }, "creationHeight", codeOutOfRange},
+	} {
+		params := valid
+		tc.edit(&params)
+		runner := newFakeRunner().on("tx staking cancel-unbond", `{"code":0,"txhash":"A"}`)
+
+		text, isError := callTool(t, runner, cancelUnbondingHandler, params)
+		if !isError || !hasFieldError(fieldErrors(t, text), tc.field, tc.code) {
+			t.Errorf("%s: result = %s, want %s on %s", tc.name, text, tc.code, tc.field)
+		}
+		if calls := runner.callsTo("tx staking cancel-unbond"); len(calls) != 0 {
+			t.Errorf("%s: broadcast %v", tc.name, calls)
+		}
+	}
+}
+
+func TestCancelUnbondingBuildsArgs(t *testing.T) {
+	runner := newFakeRunner().on("tx staking cancel-unbond", `{"code":0,"txhash":"A"}`)
+
+	text, isError := callTool(t, runner, cancelUnbondingHandler, CancelUnbondingParams{Delegator: testAlice, Validator: " " + testValidator, Amount: "100stake", CreationHeight: "1200 ", Fees: "300token"})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	calls := runner.callsTo("tx staking cancel-unbond")
+	if len(calls) != 1 {
+		t.Fatalf("calls = %v, want one cancel-unbond", calls)
+	}
+	if got := calls[0][3:6]; !slices.Equal(got, []string{testValidator, "100stake", "1200"}) {
+		t.Errorf("positional args = %q, want validator, amount, creation height", got)
+	}
+	if argValue(calls[0], "--from") != testAlice || argValue(calls[0], "--fees") != "300token" {
+		t.Errorf("args = %v, want --from alice and --fees 300token", calls[0])
+	}
+}
+
+func TestRedelegateRefusesSameValidator(t *testing.T) {
+	runner := newFakeRunner().on("tx staking redelegate", `{"code":0,"txhash":"A"}`)
+
+	text, isError := callTool(t, runner, redelegateHandler, RedelegateParams{Delegator: testAlice, SrcValidator: testValidator, DstValidator: " " + testValidator + " ", Amount: "100stake"})
+	if !isError || !hasFieldError(fieldErrors(t, text), "dstValidator", codeInvalidValue) {
+		t.Errorf("result = %s, want invalid_value on dstValidator", text)
+	}
+	if calls := runner.callsTo("tx staking redelegate"); len(calls) != 0 {
+		t.Errorf("broadcast %v for a same-validator redelegation", calls)
+	}
+}
+
+func TestRedelegateBuildsArgs(t *testing.T) {
+	runner := newFakeRunner().on("tx staking redelegate", `{"code":0,"txhash":"A"}`)
+
+	text, isError := callTool(t, runner, redelegateHandler, RedelegateParams{Delegator: testAlice, SrcValidator: testValidator, DstValidator: testOtherValidator, Amount: "100stake", Fees: "300token"})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	calls := runner.callsTo("tx staking redelegate")
+	if len(calls) != 1 {
+		t.Fatalf("calls = %v, want one redelegate", calls)
+	}
+	if got := calls[0][3:6]; !slices.Equal(got, []string{testValidator, testOtherValidator, "100stake"}) {
+		t.Errorf("positional args = %q, want source, destination, amount", got)
+	}
+	if argValue(calls[0], "--from") != testAlice || argValue(calls[0], "--fees") != "300token" {
+		t.Errorf("args = %v, want --from alice and --fees 300token", calls[0])
+	}
+}
+
+func TestTxArgsUseConfiguredBroadcastMode(t *testing.T) {
+	restoreConfig(t)
+	for _, mode := range []string{"sync", "async", "block"} {
+		if err := flag.Set("broadcast-mode", mode); err != nil {
+			t.Fatal(err)
+		}
+		args := buildTxArgs(withConfig(context.Background(), loadConfig()), "bank", "send", []string{testAlice, testBob, "1token"}, testAlice, "200token")
+		if got := argValue(args, "--broadcast-mode"); got != mode {
+			t.Errorf("-broadcast-mode %s: args = %v, want --broadcast-mode %s", mode, args, mode)
+		}
+
+		runner := newFakeRunner().on("tx bank send", `{"code":0,"txhash":"A"}`)
+		callTool(t, runner, payHandler, PayParams{From: testAlice, To: testBob, Amount: "1token"})
+		if calls := runner.callsTo("tx bank send"); len(calls) != 1 || argValue(calls[0], "--broadcast-mode") != mode {
+			t.Errorf("-broadcast-mode %s: pay calls = %v", mode, calls)
+		}
+	}
+}
+
+func TestTxParamsSchemaMarksRequiredFields(t *testing.T) {
+	for _, tc := range []struct {
+		tool     string
+		schema   func() (*jsonschema.Schema, error)
+		required []string
+	}{
+		{"pay", jsonschema.For[PayParams], []string{"from", "to", "amount"}},
+		{"open-auction", jsonschema.For[OpenAuctionParams], []string{"issue", "description", "from"}},
+		{"create-bid", jsonschema.For[CreateBidParams], []string{"auctionId", "bidder", "from"}},
+		{"close-auction", jsonschema.For[CloseAuctionParams], []string{"auctionId", "status", "issue", "description", "winner", "from"}},
+	} {
+		schema, err := tc.schema()
+		if err != nil {
+			t.Fatalf("%s: %v", tc.tool, err)
+		}
+		required := slices.Clone(schema.Required)
+		slices.Sort(required)
+		want := slices.Clone(tc.required)
+		slices.Sort(want)
+		if !slices.Equal(required, want) {
+			t.Errorf("%s required = %v, want %v", tc.tool, required, want)
+		}
+		for _, name := range []string{"fees", "feeGranter", "verbose", "idempotencyKey"} {
+			if _, ok := schema.Properties[name]; !ok {
+				t.Errorf("%s schema has no %s property", tc.tool, name)
+			}
+		}
+	}
+}
+
+func TestBuildTxArgs(t *testing.T) {
+	cfg := config{keyringBackend: "os", chainID: "swechain-7", broadcastMode: "block"}
+	args := buildTxArgs(withConfig(context.Background(), cfg), "issuemarket", "create-bid", []string{"3", testBob, "50token", "my bid"}, testAlice, "200token")
+	want := []string{
+		"tx", "issuemarket", "create-bid", "3", testBob, "50token", "my bid",
+		"--from", testAlice,
+		"--keyring-backend", "os",
+		"--chain-id", "swechain-7",
+		"--broadcast-mode", "block",
+		"--fees", "200token",
+		"--yes",
+		"--output", "json",
+	}
+	if !slices.Equal(args, want) {
+		t.Errorf("args = %q\nwant %q", args, want)
+	}
+
+	cfg.node, cfg.home = "tcp://node:26657", "/data/swechain"
+	args = buildTxArgs(withConfig(context.Background(), cfg), "bank", "send", []string{testAlice, testBob, "1token"}, testAlice, "200token")
+	if got := args[len(args)-4:]; !slices.Equal(got, []string{"--node", "tcp://node:26657", "--home", "/data/swechain"}) {
+		t.Errorf("trailing args = %q, want --node and --home", got)
+	}
+}
+
+func TestDecodeTxSummarizesEncodedTx(t *testing.T) {
+	const encoded = "CpABCo0BChwvY29zbW9zLmJhbmsudjFiZXRhMS5Nc2dTZW5kEm0KLWNvc21vczE="
+	decoded := `{
+		"body":{"messages":[
+			{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"` + testAlice + `","to_address":"` + testBob + `","amount":[{"denom":"token","amount":"10"}]},
+			{"@type":"/swechain.issuemarket.MsgCreateBid","creator":"` + testBob + `","auctionId":"3"},
+			{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"` + testAlice + `","to_address":"` + testBob + `","amount":[{"denom":"token","amount":"5"}]}
+		],"memo":"rent"},
+		"auth_info":{"fee":{"amount":[{"denom":"token","amount":"200"},{"denom":"stake","amount":"1"}],"gas_limit":"200000"}},
+		"signatures":["c2ln"]
+	}`
+	runner := newFakeRunner().on("tx decode", decoded)
+
+	text, isError := callTool(t, runner, decodeTxHandler, DecodeTxParams{Tx: " " + encoded + "\n"})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	if calls := runner.callsTo("tx decode"); len(calls) != 1 || calls[0][2] != encoded {
+		t.Errorf("tx decode calls = %v, want one with the trimmed tx bytes", calls)
+	}
+
+	var response DecodedTxResponse
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	details := response.Details
+	if !slices.Equal(details.MessageTypes, []string{"/cosmos.bank.v1beta1.MsgSend", "/swechain.issuemarket.MsgCreateBid", "/cosmos.bank.v1beta1.MsgSend"}) {
+		t.Errorf("messageTypes = %v", details.MessageTypes)
+	}
+	if !slices.Equal(details.Signers, []string{testAlice, testBob}) {
+		t.Errorf("signers = %v, want alice then bob, each once", details.Signers)
+	}
+	if details.Memo != "rent" || details.Fee != "200token,1stake" || details.GasLimit != "200000" || details.Signatures != 1 {
+		t.Errorf("details = %+v, want memo, fee, gas limit and one signature", details)
+	}
+	if want := "Tx with 3 messages (/cosmos.bank.v1beta1.MsgSend, /swechain.issuemarket.MsgCreateBid, /cosmos.bank.v1beta1.MsgSend) signed by 1 of 2 signers"; response.Summary != want {
+		t.Errorf("summary = %q, want %q", response.Summary, want)
+	}
+
+	// Tx JSON is summarized as is, without shelling out.
+	fromJSON, _ := callTool(t, runner, decodeTxHandler, DecodeTxParams{Tx: decoded})
+	if fromJSON != text {
+		t.Errorf("tx JSON summary differs from the decoded bytes':\n%s\n---\n%s", fromJSON, text)
+	}
+	if calls := runner.callsTo("tx decode"); len(calls) != 1 {
+		t.Errorf("tx decode calls = %v, want none for tx JSON", calls)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// fieldErrors decodes a validation error result into its field errors.
+func fieldErrors(t *testing.T, text string) []FieldError {
+	t.Helper()
+	var result struct {
+		Errors []FieldError `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(text), &result); err != nil || len(result.Errors) == 0 {
+		t.Fatalf("not a validation error result: %s", text)
+	}
+	return result.Errors
+}
+
+// hasFieldError reports whether errs holds code for field.
+func hasFieldError(errs []FieldError, field, code string) bool {
+	for _, e := range errs {
+		if e.Field == field && e.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheckTextRejectsNewlinesAndNullBytes(t *testing.T) {
+	for _, value := range []string{"line one\nline two", "carriage\rreturn", "null\x00byte", "escape\x1b[31m", "del\x7f"} {
+		var errs validationErrors
+		errs.checkText("description", value)
+		if !hasFieldError(errs, "description", codeInvalidText) {
+			t.Errorf("checkText(%q) = %v, want an invalid_text error", value, errs)
+		}
+	}
+	for _, value := range []string{"", "plain text", "tab\tseparated", "ünïcödé — ok"} {
+		var errs validationErrors
+		errs.checkText("description", value)
+		if len(errs) != 0 {
+			t.Errorf("checkText(%q) = %v, want no error", value, errs)
+		}
+	}
+}
+
+func TestOpenAuctionRejectsControlCharacters(t *testing.T) {
+	runner := newFakeRunner().on("tx issuemarket create-auction", `{"code":0,"txhash":"A"}`)
+
+	text, isError := callTool(t, runner, openAuctionHandler, OpenAuctionParams{Issue: "fix\nbug", Description: "null\x00byte", From: testAlice})
+	if !isError {
+		t.Fatalf("expected a validation error: %s", text)
+	}
+	errs := fieldErrors(t, text)
+	if !hasFieldError(errs, "issue", codeInvalidText) || !hasFieldError(errs, "description", codeInvalidText) {
+		t.Errorf("errors = %+v, want invalid_text for issue and description", errs)
+	}
+	if calls := runner.callsTo("tx issuemarket create-auction"); len(calls) != 0 {
+		t.Errorf("broadcast %v despite invalid text", calls)
+	}
+}
+
+func TestCreateBidRejectsNewlineInDescription(t *testing.T) {
+	runner := newFakeRunner().on("tx issuemarket create-bid", `{"code":0,"txhash":"A"}`)
+
+	text, isError := callTool(t, runner, createBidHandler, CreateBidParams{AuctionId: "1", Bidder: testAlice, From: testAlice, Amount: "10token", Description: "first\nsecond"})
+	if !isError || !hasFieldError(fieldErrors(t, text), "description", codeInvalidText) {
+		t.Errorf("result = %s, want an invalid_text error for description", text)
+	}
+	if calls := runner.callsTo("tx issuemarket create-bid"); len(calls) != 0 {
+		t.Errorf("broadcast %v despite invalid text", calls)
+	}
+}
+
+func TestValidationErrorCodes(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		call        func() (string, bool)
+		field, code string
+	}{
+		{"missing from", func() (string, bool) {
+			return callTool(t, newFakeRunner(), payHandler, PayParams{To: testBob, Amount: "1token"})
+		}, "from", codeMissingField},
+		{"malformed address", func() (string, bool) {
+			return callTool(t, newFakeRunner(), payHandler, PayParams{From: testAlice, To: "cosmos1nope", Amount: "1token"})
+		}, "to", codeInvalidAddress},
+		{"malformed fees", func() (string, bool) {
+			return callTool(t, newFakeRunner(), payHandler, PayParams{From: testAlice, To: testBob, Amount: "1token", Fees: "lots"})
+		}, "fees", codeInvalidCoin},
+		{"non-numeric auction id", func() (string, bool) {
+			return callTool(t, newFakeRunner(), createBidHandler, CreateBidParams{AuctionId: "seven", Bidder: testAlice, From: testAlice})
+		}, "auctionId", codeInvalidAuctionID},
+		{"undecodable tx", func() (string, bool) {
+			return callTool(t, newFakeRunner(), decodeTxHandler, DecodeTxParams{Tx: "%%% not a tx"})
+		}, "tx", codeInvalidEncoding},
+		{"inverted height range", func() (string, bool) {
+			return callTool(t, newFakeRunner(), txsInRangeHandler, TxsInRangeParams{MinHeight: 10, MaxHeight: 5})
+		}, "maxHeight", codeOutOfRange},
+	} {
+		text, isError := tc.call()
+		if !isError {
+			t.Errorf("%s: expected a validation error, got %s", tc.name, text)
+			continue
+		}
+		if errs := fieldErrors(t, text); !hasFieldError(errs, tc.field, tc.code) {
+			t.Errorf("%s: errors = %+v, want %s on %s", tc.name, errs, tc.code, tc.field)
+		}
+	}
+}
+
+func TestValidationReportsEveryFailedField(t *testing.T) {
+	text, _ := callTool(t, newFakeRunner(), payHandler, PayParams{From: "nope", Fees: "lots"})
+	errs := fieldErrors(t, text)
+	for _, want := range []FieldError{
+		{Field: "from", Code: codeInvalidAddress},
+		{Field: "to", Code: codeMissingField},
+		{Field: "amount", Code: codeMissingField},
+		{Field: "fees", Code: codeInvalidCoin},
+	} {
+		if !hasFieldError(errs, want.Field, want.Code) {
+			t.Errorf("errors = %+v, missing %s on %s", errs, want.Code, want.Field)
+		}
+	}
+	for _, e := range errs {
+		if e.Message == "" {
+			t.Errorf("error %+v has no human-readable message", e)
+		}
+	}
+}
+
+func TestMalformedAmountsAreInvalidCoins(t *testing.T) {
+	for _, amount := range []string{"abc", "1.5.0token", "-5token", "10 token"} {
+		runner := newFakeRunner()
+		calls := map[string]func() (string, bool){
+			"pay": func() (string, bool) {
+				return callTool(t, runner, payHandler, PayParams{From: testAlice, To: testBob, Amount: amount})
+			},
+			"create-bid": func() (string, bool) {
+				return callTool(t, runner, createBidHandler, CreateBidParams{AuctionId: "1", Bidder: testAlice, From: testAlice, Amount: amount})
+			},
+			"create-bid relative": func() (string, bool) {
+				return callTool(t, runner, createBidHandler, CreateBidParams{AuctionId: "1", Bidder: testAlice, From: testAlice, Amount: "+" + amount})
+			},
+			"setup-test-accounts": func() (string, bool) {
+				return callTool(t, runner, setupTestAccountsHandler, SetupTestAccountsParams{FunderAddress: testAlice, Count: 1, Amount: amount})
+			},
+		}
+		for name, call := range calls {
+			text, isError := call()
+			if !isError || !hasFieldError(fieldErrors(t, text), "amount", codeInvalidCoin) {
+				t.Errorf("%s %q: result = %s, want invalid_coin on amount", name, amount, text)
+			}
+		}
+		if len(runner.calls) != 0 {
+			t.Errorf("%q: ran %v despite an invalid amount", amount, runner.calls)
+		}
+	}
+}
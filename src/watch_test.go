@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// bidPage renders one page of list-bid output holding the given bid amounts on auction 0.
+func bidPage(amounts ...string) string {
+	bids := make([]string, 0, len(amounts))
+	for _, amount := range amounts {
+		bids = append(bids, fmt.Sprintf(`{"auctionId":"0","amount":%q,"creator":%q,"bidder":%q}`, amount, testBob, testBob))
+	}
+	return `{"Bid":[` + strings.Join(bids, ",") + `]}`
+}
+
+// isFirstPage reports whether a list query asks for its first page. Later
+// pages are answered empty, which is how fetchPaginatedData finds the end.
+func isFirstPage(args []string) bool {
+	for i, arg := range args {
+		if arg == "--page-offset" && i+1 < len(args) {
+			return args[i+1] == "0"
+		}
+	}
+	return true
+}
+
+func TestWatchAuctionBidsReportsBidPlacedDuringWatch(t *testing.T) {
+	listings := 0
+	runner := newFakeRunner().onCall("query issuemarket list-bid", func(call int, args []string) (string, error) {
+		if !isFirstPage(args) {
+			return `{"Bid":[]}`, nil
+		}
+		listings++
+		if listings < 3 {
+			return bidPage("100token"), nil
+		}
+		return bidPage("100token", "150token"), nil
+	})
+
+	text, isError := callTool(t, runner, watchAuctionBidsHandler, WatchAuctionBidsParams{AuctionId: "0", PollInterval: 1, MaxPolls: 5})
+	if isError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	var response struct {
+		Details struct {
+			NewBids []Bid `json:"newBids"`
+			Polls   int   `json:"polls"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	if len(response.Details.NewBids) != 1 || response.Details.NewBids[0].Amount != "150token" {
+		t.Errorf("newBids = %+v, want only the 150token bid", response.Details.NewBids)
+	}
+	if response.Details.Polls != 3 {
+		t.Errorf("polls = %d, want 3", response.Details.Polls)
+	}
+}
+
+func TestWatchAuctionBidsFailsWithoutBaseline(t *testing.T) {
+	runner := newFakeRunner().onCall("query issuemarket list-bid", func(call int, args []string) (string, error) {
+		if call == 1 {
+			return "", errors.New("Error: rpc error: code = InvalidArgument desc = bad request")
+		}
+		if !isFirstPage(args) {
+			return `{"Bid":[]}`, nil
+		}
+		return bidPage("100token"), nil
+	})
+
+	text, _ := callTool(t, runner, watchAuctionBidsHandler, WatchAuctionBidsParams{AuctionId: "0", PollInterval: 1, MaxPolls: 2})
+	if !strings.HasPrefix(text, "Error fetching bids") {
+		t.Errorf("result = %s, want a fetch error instead of reporting existing bids as new", text)
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// textResult is a plain text tool result.
+func textResult(text string) *mcp.CallToolResultFor[any] {
+	return &mcp.CallToolResultFor[any]{Content: []mcp.Content{&mcp.TextContent{Text: text}}}
+}
+
+func TestIdempotencyCachesOnlySuccessfulBroadcasts(t *testing.T) {
+	results := []*mcp.CallToolResultFor[any]{
+		textResult("Failed to send payment: command failed"),
+		formatTxResult("Payment", `{"code":5,"txhash":"A"}`, false),
+		formatTxResult("Payment", `{"code":0,"txhash":"B"}`, false),
+		formatTxResult("Payment", `{"code":0,"txhash":"C"}`, false),
+	}
+	calls := 0
+	h := withIdempotency("pay", func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[PayParams]) (*mcp.CallToolResultFor[any], error) {
+		calls++
+		return results[calls-1], nil
+	})
+
+	params := PayParams{From: testAlice, To: testBob, Amount: "1token", IdempotencyKey: "idempotency-test"}
+	for i := 0; i < 4; i++ {
+		callTool(t, newFakeRunner(), h, params)
+	}
+	// The two failures run again; the first success is returned from then on.
+	if calls != 3 {
+		t.Errorf("handler ran %d times, want 3", calls)
+	}
+}
+
+func TestIsErrorResult(t *testing.T) {
+	for _, tc := range []struct {
+		result *mcp.CallToolResultFor[any]
+		want   bool
+	}{
+		{textResult(`{"summary":"ok","details":{}}`), false},
+		{textResult("Error fetching bids: boom"), true},
+		{textResult("Failed to create bid: boom"), true},
+		{textResult(`{"error":"chain_stalled","message":"stalled"}`), true},
+		{structuredErrorResult("x", "y"), true},
+		{formatTxResult("Bid", `{"code":13,"txhash":"A"}`, false), true},
+	} {
+		if got := isErrorResult(tc.result); got != tc.want {
+			t.Errorf("isErrorResult(%v) = %v, want %v", tc.result.Content[0].(*mcp.TextContent).Text, got, tc.want)
+		}
+	}
+}
+
+// withAuditLog points -audit-log at a temp file for the rest of the test and
+// returns a func reading back its entries.
+func withAuditLog(t *testing.T) func() []AuditEntry {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit.log")
+	previous := *auditLogPath
+	*auditLogPath = path
+	t.Cleanup(func() { *auditLogPath = previous })
+
+	return func() []AuditEntry {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var entries []AuditEntry
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var entry AuditEntry
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				t.Fatalf("bad audit line %q: %v", line, err)
+			}
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+}
+
+func TestAuditRecordsErrorTextAndSkipsReplays(t *testing.T) {
+	entries := withAuditLog(t)
+	results := []*mcp.CallToolResultFor[any]{
+		textResult("Failed to send payment: command failed\nOutput: "),
+		formatTxResult("Payment", `{"code":0,"txhash":"ABC"}`, false),
+	}
+	calls := 0
+	h := withIdempotency("pay", withAudit("pay", func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[PayParams]) (*mcp.CallToolResultFor[any], error) {
+		calls++
+		return results[calls-1], nil
+	}))
+
+	params := PayParams{From: testAlice, To: testBob, Amount: "1token", IdempotencyKey: "audit-test"}
+	for i := 0; i < 3; i++ {
+		callTool(t, newFakeRunner(), h, params)
+	}
+
+	got := entries()
+	if len(got) != 2 {
+		t.Fatalf("got %d audit entries, want 2 (the replay is not audited): %+v", len(got), got)
+	}
+	if got[0].Error != "Failed to send payment: command failed" || got[0].TxHash != "" {
+		t.Errorf("first entry = %+v, want the failure text", got[0])
+	}
+	if got[1].TxHash != "ABC" || got[1].Error != "" || got[1].From != testAlice {
+		t.Errorf("second entry = %+v, want txhash ABC from %s", got[1], testAlice)
+	}
+}
+
+func TestAuditOmitsGeneratedMnemonic(t *testing.T) {
+	entries := withAuditLog(t)
+	runner := newFakeRunner()
+	runner.responses["keys mnemonic"] = fakeResponse{stdout: testMnemonic}
+
+	callTool(t, runner, withAudit("generate-mnemonic", generateMnemonicHandler), GenerateMnemonicParams{})
+
+	got := entries()
+	if len(got) != 1 || got[0].Tool != "generate-mnemonic" {
+		t.Fatalf("entries = %+v, want one generate-mnemonic entry", got)
+	}
+	if line, _ := json.Marshal(got[0]); strings.Contains(string(line), "abandon") {
+		t.Errorf("mnemonic recorded in the audit log: %s", line)
+	}
+}
+
+func TestAccountLockGivesUpOnCancelAndPrunes(t *testing.T) {
+	unlock, err := lockAccount(context.Background(), testAlice)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := lockAccount(ctx, testAlice); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want the waiter to give up when its ctx expires", err)
+	}
+
+	unlock()
+	accountLocksMu.Lock()
+	remaining := len(accountLocks)
+	accountLocksMu.Unlock()
+	if remaining != 0 {
+		t.Errorf("%d account locks left after release, want 0", remaining)
+	}
+}
+
+// withReadCacheTTL enables the read cache with an empty cache for one test.
+func withReadCacheTTL(t *testing.T, ttl time.Duration) {
+	t.Helper()
+	previous := *readCacheTTL
+	*readCacheTTL = ttl
+	readCache.Lock()
+	readCache.entries = make(map[string]readCacheEntry)
+	readCache.Unlock()
+	t.Cleanup(func() {
+		*readCacheTTL = previous
+		readCache.Lock()
+		readCache.entries = make(map[string]readCacheEntry)
+		readCache.Unlock()
+	})
+}
+
+func TestReadCacheSkipsErrorText(t *testing.T) {
+	withReadCacheTTL(t, time.Minute)
+	results := []*mcp.CallToolResultFor[any]{
+		textResult("Error fetching balances: command failed"),
+		textResult(`{"summary":"ok","details":{"balance":"1"}}`),
+	}
+	calls := 0
+	h := withReadCache("get-balance", func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetBalanceParams]) (*mcp.CallToolResultFor[any], error) {
+		calls++
+		return results[calls-1], nil
+	})
+
+	params := GetBalanceParams{Address: testAlice}
+	if text, _ := callTool(t, newFakeRunner(), h, params); !strings.HasPrefix(text, "Error") {
+		t.Fatalf("first call = %s, want the error", text)
+	}
+	if text, _ := callTool(t, newFakeRunner(), h, params); !strings.Contains(text, `"balance"`) {
+		t.Errorf("second call = %s, want a fresh fetch rather than the cached error", text)
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want 2", calls)
+	}
+}
+
+func TestReadCacheStampsFetchedAt(t *testing.T) {
+	h := withReadCache("tool", func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetBalanceParams]) (*mcp.CallToolResultFor[any], error) {
+		return textResult(`{"summary":"ok","details":{"balance":"1"}}`), nil
+	})
+
+	for _, ttl := range []time.Duration{0, time.Minute} {
+		withReadCacheTTL(t, ttl)
+		text, _ := callTool(t, newFakeRunner(), h, GetBalanceParams{Address: testAlice})
+		var response struct {
+			Details map[string]interface{} `json:"details"`
+		}
+		if err := json.Unmarshal([]byte(text), &response); err != nil {
+			t.Fatalf("response is not JSON: %v\n%s", err, text)
+		}
+		if _, ok := response.Details["fetchedAt"]; !ok {
+			t.Errorf("ttl %v: details missing fetchedAt: %s", ttl, text)
+		}
+	}
+}
+
+func TestReadCacheMarksCachedResponsesWithAge(t *testing.T) {
+	withReadCacheTTL(t, time.Minute)
+	calls := 0
+	h := withReadCache("tool", func(ctx context.Context, sess *mcp.ServerSession, params *mcp.CallToolParamsFor[GetBalanceParams]) (*mcp.CallToolResultFor[any], error) {
+		calls++
+		return textResult(`{"summary":"ok","details":{"balance":"1"}}`), nil
+	})
+
+	first, _ := callTool(t, newFakeRunner(), h, GetBalanceParams{Address: testAlice})
+	if strings.Contains(first, `"cached"`) {
+		t.Errorf("first call = %s, want a fresh result", first)
+	}
+	time.Sleep(time.Millisecond)
+	text, _ := callTool(t, newFakeRunner(), h, GetBalanceParams{Address: testAlice})
+
+	var response struct {
+		Details struct {
+			Cached bool   `json:"cached"`
+			Age    string `json:"age"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(text), &response); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, text)
+	}
+	age, err := time.ParseDuration(response.Details.Age)
+	if !response.Details.Cached || err != nil || age <= 0 {
+		t.Errorf("details = %+v, want cached with a non-zero age", response.Details)
+	}
+	if calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+}
+
+func TestNonReadToolsAreNotStampedWithFetchedAt(t *testing.T) {
+	runner := newFakeRunner().
+		on("status", testStatus).
+		on("query issuemarket show-auction", `{"Auction":{"id":"1","issue":"issue-1","status":"open"}}`)
+
+	blockHeight, _ := callTool(t, runner, blockHeightHandler, BlockHeightParams{})
+	auctionExists, _ := callTool(t, runner, auctionExistsHandler, AuctionExistsParams{AuctionId: "1"})
+	for name, text := range map[string]string{"block-height": blockHeight, "auction-exists": auctionExists} {
+		if !strings.Contains(text, `"details"`) || strings.Contains(text, "fetchedAt") {
+			t.Errorf("%s: response = %s, want details without fetchedAt", name, text)
+		}
+	}
+}
+
+func TestReadCacheEvictsAtCapacity(t *testing.T) {
+	withReadCacheTTL(t, time.Minute)
+	readCache.Lock()
+	for i := 0; i < maxReadCacheEntries; i++ {
+		readCache.entries[fmt.Sprintf("tool/%d", i)] = readCacheEntry{result: textResult("{}"), fetchedAt: time.Now().Add(time.Duration(i) * time.Millisecond)}
+	}
+	readCache.entries["tool/expired"] = readCacheEntry{result: textResult("{}"), fetchedAt: time.Now().Add(-time.Hour)}
+	readCache.Unlock()
+
+	storeReadCache("tool/new", readCacheEntry{result: textResult("{}"), fetchedAt: time.Now()})
+
+	readCache.Lock()
+	defer readCache.Unlock()
+	if len(readCache.entries) > maxReadCacheEntries {
+		t.Errorf("cache holds %d entries, want at most %d", len(readCache.entries), maxReadCacheEntries)
+	}
+	for _, key := range []string{"tool/expired", "tool/0"} {
+		if _, ok := readCache.entries[key]; ok {
+			t.Errorf("%s was not evicted", key)
+		}
+	}
+	if _, ok := readCache.entries["tool/new"]; !ok {
+		t.Error("new entry was not stored")
+	}
+}
+
+func TestRequestIDMatchesResponseAndLogs(t *testing.T) {
+	logs := captureLog(t)
+	runner := newFakeRunner().on("query bank balances", `{"balances":[{"denom":"token","amount":"5"}]}`)
+
+	text, _ := callTool(t, runner, withRequestID("get-balance", getBalanceHandler), GetBalanceParams{Address: testAlice})
+	var response struct {
+		Details struct {
+			RequestID string `json:"requestId"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(text), &response); err != nil || response.Details.RequestID == "" {
+		t.Fatalf("no requestId in response: %s", text)
+	}
+
+	tag := "[" + response.Details.RequestID + "] "
+	var tagged []string
+	for _, line := range strings.Split(logs.String(), "\n") {
+		if strings.Contains(line, tag) {
+			tagged = append(tagged, line)
+		}
+	}
+	for _, want := range []string{"get-balance called", "Executing command: swechaind [query bank balances", "get-balance finished"} {
+		if !slices.ContainsFunc(tagged, func(line string) bool { return strings.Contains(line, want) }) {
+			t.Errorf("no log line tagged %s containing %q in:\n%s", tag, want, logs)
+		}
+	}
+
+	other, _ := callTool(t, runner, withRequestID("get-balance", getBalanceHandler), GetBalanceParams{Address: testAlice})
+	if strings.Contains(other, response.Details.RequestID) {
+		t.Errorf("second call reused request id %s", response.Details.RequestID)
+	}
+}